@@ -5,10 +5,12 @@ import (
 
 	"github.com/disgoorg/disgo/cache"
 	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/eventstream"
 	"github.com/disgoorg/disgo/gateway"
 	"github.com/disgoorg/disgo/httpserver"
 	"github.com/disgoorg/disgo/rest"
 	"github.com/disgoorg/disgo/sharding"
+	"github.com/disgoorg/disgo/voice"
 	"github.com/disgoorg/log"
 	"github.com/disgoorg/snowflake/v2"
 )
@@ -40,6 +42,42 @@ type Client interface {
 	// Rest returns the rest.Rest used by the Client.
 	Rest() rest.Rest
 
+	// RetrieveGuild returns the discord.Guild from the cache.Caches or requests it via rest.Rest if it's not cached.
+	// The requested guild, its roles, emojis & stickers are put into the cache.Caches.
+	// Concurrent calls for the same guild share one request, which uses the rest.RequestOpt(s) of the first call.
+	RetrieveGuild(guildID snowflake.ID, opts ...rest.RequestOpt) (discord.Guild, error)
+
+	// RetrieveChannel returns the discord.Channel from the cache.Caches or requests it via rest.Rest if it's not cached.
+	// Concurrent calls for the same channel share one request, which uses the rest.RequestOpt(s) of the first call.
+	RetrieveChannel(channelID snowflake.ID, opts ...rest.RequestOpt) (discord.Channel, error)
+
+	// RetrieveMember returns the discord.Member from the cache.Caches or requests it via rest.Rest if it's not cached.
+	// Concurrent calls for the same member share one request, which uses the rest.RequestOpt(s) of the first call.
+	RetrieveMember(guildID snowflake.ID, userID snowflake.ID, opts ...rest.RequestOpt) (discord.Member, error)
+
+	// RetrieveRole returns the discord.Role from the cache.Caches or requests all roles of the guild via rest.Rest if it's not cached.
+	// Returns discord.ErrRoleNotFound if the guild has no such role.
+	// Concurrent calls for the same guild share one request, which uses the rest.RequestOpt(s) of the first call.
+	RetrieveRole(guildID snowflake.ID, roleID snowflake.ID, opts ...rest.RequestOpt) (discord.Role, error)
+
+	// RetrieveMessage returns the discord.Message from the cache.Caches or requests it via rest.Rest if it's not cached.
+	// Concurrent calls for the same message share one request, which uses the rest.RequestOpt(s) of the first call.
+	RetrieveMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...rest.RequestOpt) (discord.Message, error)
+
+	// RetrieveUser returns the discord.User of the self user or requests it via rest.Rest.
+	// Users are not cached on their own, so the requested user is not put into the cache.Caches.
+	// Use RetrieveMember if the user is a member of a guild, which is looked up in the cache.Caches directly.
+	// Concurrent calls for the same user share one request, which uses the rest.RequestOpt(s) of the first call.
+	RetrieveUser(userID snowflake.ID, opts ...rest.RequestOpt) (discord.User, error)
+
+	// RetrieveEmoji returns the discord.Emoji from the cache.Caches or requests it via rest.Rest if it's not cached.
+	// Concurrent calls for the same emoji share one request, which uses the rest.RequestOpt(s) of the first call.
+	RetrieveEmoji(guildID snowflake.ID, emojiID snowflake.ID, opts ...rest.RequestOpt) (discord.Emoji, error)
+
+	// RetrieveThreadMember returns the discord.ThreadMember from the cache.Caches or requests it via rest.Rest if it's not cached.
+	// Concurrent calls for the same thread member share one request, which uses the rest.RequestOpt(s) of the first call.
+	RetrieveThreadMember(threadID snowflake.ID, userID snowflake.ID, opts ...rest.RequestOpt) (discord.ThreadMember, error)
+
 	// AddEventListeners adds one or more EventListener(s) to the EventManager.
 	AddEventListeners(listeners ...EventListener)
 
@@ -67,6 +105,15 @@ type Client interface {
 	// HasShardManager returns whether the Client has a configured sharding.ShardManager.
 	HasShardManager() bool
 
+	// ConnectEventSource opens the configured eventstream.Source.
+	ConnectEventSource(ctx context.Context) error
+
+	// EventSource returns the eventstream.Source used by the Client.
+	EventSource() eventstream.Source
+
+	// HasEventSource returns whether the Client has a configured eventstream.Source.
+	HasEventSource() bool
+
 	// Shard returns the gateway.Gateway the specific guildID runs on.
 	Shard(guildID snowflake.ID) (gateway.Gateway, error)
 
@@ -76,6 +123,17 @@ type Client interface {
 	// Disconnect sends a discord.GatewayMessageDataVoiceStateUpdate to the specific gateway.Gateway and disconnects the bot from this guild.
 	Disconnect(ctx context.Context, guildID snowflake.ID) error
 
+	// UpdateVoiceState sends a discord.GatewayMessageDataVoiceStateUpdate to the specific gateway.Gateway. A nil channelID disconnects the bot from this guild.
+	UpdateVoiceState(ctx context.Context, guildID snowflake.ID, channelID *snowflake.ID, selfMute bool, selfDeaf bool) error
+
+	// VoiceManager returns the voice.Manager used by the Client to open voice connections.
+	VoiceManager() voice.Manager
+
+	// PlayAudio joins the specified channel if the bot is not connected to it yet and plays the voice.OpusFrameProvider.
+	// Use a voice.Queue or voice.Mixer to play multiple tracks. The returned voice.Conn can be used to stop playback.
+	// It returns discord.ErrNoVoiceManager if no voice.Manager is configured.
+	PlayAudio(ctx context.Context, guildID snowflake.ID, channelID snowflake.ID, provider voice.OpusFrameProvider) (voice.Conn, error)
+
 	// RequestMembers sends a discord.GatewayMessageDataRequestGuildMembers to the specific gateway.Gateway and requests the Member(s) of the specified guild.
 	//  guildID  : is the snowflake of the guild to request the members of.
 	//  presence : Weather or not to include discord.Presence data.
@@ -123,9 +181,16 @@ type clientImpl struct {
 	shardManager sharding.ShardManager
 	gateway      gateway.Gateway
 
+	eventSource            eventstream.Source
+	eventSourceHandlerFunc gateway.EventHandlerFunc
+	eventSink              eventstream.Sink
+
 	httpServer httpserver.Server
 
-	caches cache.Caches
+	voiceManager voice.Manager
+
+	caches         cache.Caches
+	retrieveGroups retrieveGroups
 
 	memberChunkingManager MemberChunkingManager
 }
@@ -135,6 +200,10 @@ func (c *clientImpl) Logger() log.Logger {
 }
 
 func (c *clientImpl) Close(ctx context.Context) {
+	// leave voice channels while the gateway is still connected
+	if c.voiceManager != nil {
+		c.voiceManager.Close(ctx)
+	}
 	if c.restServices != nil {
 		c.restServices.Close(ctx)
 	}
@@ -144,6 +213,12 @@ func (c *clientImpl) Close(ctx context.Context) {
 	if c.shardManager != nil {
 		c.shardManager.Close(ctx)
 	}
+	if c.eventSource != nil {
+		c.eventSource.Close(ctx)
+	}
+	if c.eventSink != nil {
+		c.eventSink.Close(ctx)
+	}
 	if c.httpServer != nil {
 		c.httpServer.Close(ctx)
 	}
@@ -215,6 +290,21 @@ func (c *clientImpl) HasShardManager() bool {
 	return c.shardManager != nil
 }
 
+func (c *clientImpl) ConnectEventSource(ctx context.Context) error {
+	if c.eventSource == nil {
+		return discord.ErrNoEventSource
+	}
+	return c.eventSource.Open(ctx, c.eventSourceHandlerFunc)
+}
+
+func (c *clientImpl) EventSource() eventstream.Source {
+	return c.eventSource
+}
+
+func (c *clientImpl) HasEventSource() bool {
+	return c.eventSource != nil
+}
+
 func (c *clientImpl) Shard(guildID snowflake.ID) (gateway.Gateway, error) {
 	if c.HasGateway() {
 		return c.gateway, nil
@@ -228,27 +318,44 @@ func (c *clientImpl) Shard(guildID snowflake.ID) (gateway.Gateway, error) {
 }
 
 func (c *clientImpl) Connect(ctx context.Context, guildID snowflake.ID, channelID snowflake.ID) error {
-	shard, err := c.Shard(guildID)
-	if err != nil {
-		return err
-	}
-	return shard.Send(ctx, discord.GatewayOpcodeVoiceStateUpdate, discord.GatewayMessageDataVoiceStateUpdate{
-		GuildID:   guildID,
-		ChannelID: &channelID,
-	})
+	return c.UpdateVoiceState(ctx, guildID, &channelID, false, false)
 }
 
 func (c *clientImpl) Disconnect(ctx context.Context, guildID snowflake.ID) error {
+	return c.UpdateVoiceState(ctx, guildID, nil, false, false)
+}
+
+func (c *clientImpl) UpdateVoiceState(ctx context.Context, guildID snowflake.ID, channelID *snowflake.ID, selfMute bool, selfDeaf bool) error {
 	shard, err := c.Shard(guildID)
 	if err != nil {
 		return err
 	}
 	return shard.Send(ctx, discord.GatewayOpcodeVoiceStateUpdate, discord.GatewayMessageDataVoiceStateUpdate{
 		GuildID:   guildID,
-		ChannelID: nil,
+		ChannelID: channelID,
+		SelfMute:  selfMute,
+		SelfDeaf:  selfDeaf,
 	})
 }
 
+func (c *clientImpl) VoiceManager() voice.Manager {
+	return c.voiceManager
+}
+
+func (c *clientImpl) PlayAudio(ctx context.Context, guildID snowflake.ID, channelID snowflake.ID, provider voice.OpusFrameProvider) (voice.Conn, error) {
+	if c.voiceManager == nil {
+		return nil, discord.ErrNoVoiceManager
+	}
+	conn := c.voiceManager.CreateConn(guildID)
+	if currentChannelID := conn.ChannelID(); currentChannelID == nil || *currentChannelID != channelID {
+		if err := conn.Open(ctx, channelID, false, false); err != nil {
+			return nil, err
+		}
+	}
+	conn.SetOpusFrameProvider(provider)
+	return conn, nil
+}
+
 func (c *clientImpl) RequestMembers(ctx context.Context, guildID snowflake.ID, presence bool, nonce string, userIDs ...snowflake.ID) error {
 	shard, err := c.Shard(guildID)
 	if err != nil {
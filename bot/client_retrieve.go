@@ -0,0 +1,159 @@
+package bot
+
+import (
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/internal/singleflight"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// retrieveGroups collapses concurrent rest requests for the same entity into one.
+type retrieveGroups struct {
+	guilds        singleflight.Group[snowflake.ID, discord.Guild]
+	channels      singleflight.Group[snowflake.ID, discord.Channel]
+	members       singleflight.Group[[2]snowflake.ID, discord.Member]
+	roles         singleflight.Group[snowflake.ID, []discord.Role]
+	messages      singleflight.Group[[2]snowflake.ID, discord.Message]
+	users         singleflight.Group[snowflake.ID, discord.User]
+	emojis        singleflight.Group[[2]snowflake.ID, discord.Emoji]
+	threadMembers singleflight.Group[[2]snowflake.ID, discord.ThreadMember]
+}
+
+func (c *clientImpl) RetrieveGuild(guildID snowflake.ID, opts ...rest.RequestOpt) (discord.Guild, error) {
+	if guild, ok := c.Caches().Guilds().Get(guildID); ok {
+		return guild, nil
+	}
+	guild, err, _ := c.retrieveGroups.guilds.Do(guildID, func() (discord.Guild, error) {
+		restGuild, err := c.Rest().GetGuild(guildID, false, opts...)
+		if err != nil {
+			return discord.Guild{}, err
+		}
+		c.Caches().Guilds().Put(guildID, restGuild.Guild)
+		for _, role := range restGuild.Roles {
+			c.Caches().Roles().Put(guildID, role.ID, role)
+		}
+		for _, emoji := range restGuild.Emojis {
+			c.Caches().Emojis().Put(guildID, emoji.ID, emoji)
+		}
+		for _, sticker := range restGuild.Stickers {
+			c.Caches().Stickers().Put(guildID, sticker.ID, sticker)
+		}
+		return restGuild.Guild, nil
+	})
+	return guild, err
+}
+
+func (c *clientImpl) RetrieveChannel(channelID snowflake.ID, opts ...rest.RequestOpt) (discord.Channel, error) {
+	if channel, ok := c.Caches().Channels().Get(channelID); ok {
+		return channel, nil
+	}
+	channel, err, _ := c.retrieveGroups.channels.Do(channelID, func() (discord.Channel, error) {
+		channel, err := c.Rest().GetChannel(channelID, opts...)
+		if err != nil {
+			return nil, err
+		}
+		c.Caches().Channels().Put(channelID, channel)
+		return channel, nil
+	})
+	return channel, err
+}
+
+func (c *clientImpl) RetrieveMember(guildID snowflake.ID, userID snowflake.ID, opts ...rest.RequestOpt) (discord.Member, error) {
+	if member, ok := c.Caches().Members().Get(guildID, userID); ok {
+		return member, nil
+	}
+	member, err, _ := c.retrieveGroups.members.Do([2]snowflake.ID{guildID, userID}, func() (discord.Member, error) {
+		member, err := c.Rest().GetMember(guildID, userID, opts...)
+		if err != nil {
+			return discord.Member{}, err
+		}
+		c.Caches().Members().Put(guildID, userID, *member)
+		return *member, nil
+	})
+	return member, err
+}
+
+func (c *clientImpl) RetrieveRole(guildID snowflake.ID, roleID snowflake.ID, opts ...rest.RequestOpt) (discord.Role, error) {
+	if role, ok := c.Caches().Roles().Get(guildID, roleID); ok {
+		return role, nil
+	}
+	// discord has no endpoint for a single role, so all roles of the guild are requested & cached at once
+	roles, err, _ := c.retrieveGroups.roles.Do(guildID, func() ([]discord.Role, error) {
+		roles, err := c.Rest().GetRoles(guildID, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, role := range roles {
+			c.Caches().Roles().Put(guildID, role.ID, role)
+		}
+		return roles, nil
+	})
+	if err != nil {
+		return discord.Role{}, err
+	}
+	for _, role := range roles {
+		if role.ID == roleID {
+			return role, nil
+		}
+	}
+	return discord.Role{}, discord.ErrRoleNotFound
+}
+
+func (c *clientImpl) RetrieveMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...rest.RequestOpt) (discord.Message, error) {
+	if message, ok := c.Caches().Messages().Get(channelID, messageID); ok {
+		return message, nil
+	}
+	message, err, _ := c.retrieveGroups.messages.Do([2]snowflake.ID{channelID, messageID}, func() (discord.Message, error) {
+		message, err := c.Rest().GetMessage(channelID, messageID, opts...)
+		if err != nil {
+			return discord.Message{}, err
+		}
+		c.Caches().Messages().Put(channelID, messageID, *message)
+		return *message, nil
+	})
+	return message, err
+}
+
+func (c *clientImpl) RetrieveUser(userID snowflake.ID, opts ...rest.RequestOpt) (discord.User, error) {
+	if selfUser, ok := c.Caches().GetSelfUser(); ok && selfUser.ID == userID {
+		return selfUser.User, nil
+	}
+	user, err, _ := c.retrieveGroups.users.Do(userID, func() (discord.User, error) {
+		user, err := c.Rest().GetUser(userID, opts...)
+		if err != nil {
+			return discord.User{}, err
+		}
+		return *user, nil
+	})
+	return user, err
+}
+
+func (c *clientImpl) RetrieveEmoji(guildID snowflake.ID, emojiID snowflake.ID, opts ...rest.RequestOpt) (discord.Emoji, error) {
+	if emoji, ok := c.Caches().Emojis().Get(guildID, emojiID); ok {
+		return emoji, nil
+	}
+	emoji, err, _ := c.retrieveGroups.emojis.Do([2]snowflake.ID{guildID, emojiID}, func() (discord.Emoji, error) {
+		emoji, err := c.Rest().GetEmoji(guildID, emojiID, opts...)
+		if err != nil {
+			return discord.Emoji{}, err
+		}
+		c.Caches().Emojis().Put(guildID, emojiID, *emoji)
+		return *emoji, nil
+	})
+	return emoji, err
+}
+
+func (c *clientImpl) RetrieveThreadMember(threadID snowflake.ID, userID snowflake.ID, opts ...rest.RequestOpt) (discord.ThreadMember, error) {
+	if threadMember, ok := c.Caches().ThreadMembers().Get(threadID, userID); ok {
+		return threadMember, nil
+	}
+	threadMember, err, _ := c.retrieveGroups.threadMembers.Do([2]snowflake.ID{threadID, userID}, func() (discord.ThreadMember, error) {
+		threadMember, err := c.Rest().GetThreadMember(threadID, userID, opts...)
+		if err != nil {
+			return discord.ThreadMember{}, err
+		}
+		c.Caches().ThreadMembers().Put(threadID, userID, *threadMember)
+		return *threadMember, nil
+	})
+	return threadMember, err
+}
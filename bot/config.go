@@ -5,11 +5,13 @@ import (
 
 	"github.com/disgoorg/disgo/cache"
 	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/eventstream"
 	"github.com/disgoorg/disgo/gateway"
 	"github.com/disgoorg/disgo/httpserver"
 	"github.com/disgoorg/disgo/internal/tokenhelper"
 	"github.com/disgoorg/disgo/rest"
 	"github.com/disgoorg/disgo/sharding"
+	"github.com/disgoorg/disgo/voice"
 	"github.com/disgoorg/log"
 )
 
@@ -33,11 +35,16 @@ type Config struct {
 	EventManager           EventManager
 	EventManagerConfigOpts []EventManagerConfigOpt
 
-	Gateway           gateway.Gateway
-	GatewayConfigOpts []gateway.ConfigOpt
+	Gateway                 gateway.Gateway
+	GatewayConfigOpts       []gateway.ConfigOpt
+	GatewayReconnectHandler func(client Client) gateway.ReconnectHandlerFunc
 
-	ShardManager           sharding.ShardManager
-	ShardManagerConfigOpts []sharding.ConfigOpt
+	ShardManager             sharding.ShardManager
+	ShardManagerConfigOpts   []sharding.ConfigOpt
+	ShardHealthChangeHandler func(client Client) sharding.HealthChangeHandlerFunc
+
+	EventSource eventstream.Source
+	EventSink   eventstream.Sink
 
 	HTTPServer           httpserver.Server
 	PublicKey            string
@@ -48,6 +55,9 @@ type Config struct {
 
 	MemberChunkingManager MemberChunkingManager
 	MemberChunkingFilter  MemberChunkingFilter
+
+	VoiceManager           voice.Manager
+	VoiceManagerConfigOpts []voice.ManagerConfigOpt
 }
 
 // ConfigOpt is a type alias for a function that takes a Config and is used to configure your Client.
@@ -128,6 +138,13 @@ func WithGatewayConfigOpts(opts ...gateway.ConfigOpt) ConfigOpt {
 	}
 }
 
+// WithGatewayReconnectHandler lets you set the gateway.ReconnectHandlerFunc of the default gateway.Gateway & the gateway.Gateway(s) of the default sharding.ShardManager.
+func WithGatewayReconnectHandler(gatewayReconnectHandler func(client Client) gateway.ReconnectHandlerFunc) ConfigOpt {
+	return func(config *Config) {
+		config.GatewayReconnectHandler = gatewayReconnectHandler
+	}
+}
+
 // WithShardManager lets you inject your own sharding.ShardManager.
 func WithShardManager(shardManager sharding.ShardManager) ConfigOpt {
 	return func(config *Config) {
@@ -142,6 +159,27 @@ func WithShardManagerConfigOpts(opts ...sharding.ConfigOpt) ConfigOpt {
 	}
 }
 
+// WithShardHealthChangeHandler lets you set the sharding.HealthChangeHandlerFunc of the default sharding.ShardManager.
+func WithShardHealthChangeHandler(shardHealthChangeHandler func(client Client) sharding.HealthChangeHandlerFunc) ConfigOpt {
+	return func(config *Config) {
+		config.ShardHealthChangeHandler = shardHealthChangeHandler
+	}
+}
+
+// WithEventSource lets you receive gateway events from an eventstream.Source instead of connecting to the gateway.
+func WithEventSource(eventSource eventstream.Source) ConfigOpt {
+	return func(config *Config) {
+		config.EventSource = eventSource
+	}
+}
+
+// WithEventSink lets you publish all received gateway events to an eventstream.Sink.
+func WithEventSink(eventSink eventstream.Sink) ConfigOpt {
+	return func(config *Config) {
+		config.EventSink = eventSink
+	}
+}
+
 // WithHTTPServer lets you inject your own httpserver.Server.
 func WithHTTPServer(httpServer httpserver.Server) ConfigOpt {
 	return func(config *Config) {
@@ -185,6 +223,20 @@ func WithMemberChunkingFilter(memberChunkingFilter MemberChunkingFilter) ConfigO
 	}
 }
 
+// WithVoiceManager lets you inject your own voice.Manager.
+func WithVoiceManager(voiceManager voice.Manager) ConfigOpt {
+	return func(config *Config) {
+		config.VoiceManager = voiceManager
+	}
+}
+
+// WithVoiceManagerConfigOpts lets you configure the default voice.Manager.
+func WithVoiceManagerConfigOpts(opts ...voice.ManagerConfigOpt) ConfigOpt {
+	return func(config *Config) {
+		config.VoiceManagerConfigOpts = append(config.VoiceManagerConfigOpts, opts...)
+	}
+}
+
 // BuildClient creates a new Client instance with the given token, Config, gateway handlers, http handlers os, name, github & version.
 func BuildClient(token string, config Config, gatewayEventHandlerFunc func(client Client) gateway.EventHandlerFunc, httpServerEventHandlerFunc func(client Client) httpserver.EventHandlerFunc, os string, name string, github string, version string) (Client, error) {
 	if token == "" {
@@ -224,6 +276,24 @@ func BuildClient(token string, config Config, gatewayEventHandlerFunc func(clien
 	}
 	client.eventManager = config.EventManager
 
+	if config.EventSink != nil {
+		eventHandlerFunc := gatewayEventHandlerFunc
+		gatewayEventHandlerFunc = func(client Client) gateway.EventHandlerFunc {
+			return eventstream.NewSinkEventHandlerFunc(config.EventSink, eventHandlerFunc(client))
+		}
+	}
+	client.eventSink = config.EventSink
+
+	if config.EventSource != nil {
+		client.eventSourceHandlerFunc = gatewayEventHandlerFunc(client)
+	}
+	client.eventSource = config.EventSource
+
+	var gatewayReconnectHandlerOpts []gateway.ConfigOpt
+	if config.GatewayReconnectHandler != nil {
+		gatewayReconnectHandlerOpts = append(gatewayReconnectHandlerOpts, gateway.WithReconnectHandlerFunc(config.GatewayReconnectHandler(client)))
+	}
+
 	if config.Gateway == nil && config.GatewayConfigOpts != nil {
 		var gatewayRs *discord.Gateway
 		gatewayRs, err = client.restServices.GetGateway()
@@ -240,29 +310,18 @@ func BuildClient(token string, config Config, gatewayEventHandlerFunc func(clien
 			func(config *gateway.Config) {
 				config.RateRateLimiterConfigOpts = append([]gateway.RateLimiterConfigOpt{gateway.WithRateLimiterLogger(client.logger)}, config.RateRateLimiterConfigOpts...)
 			},
-		}, config.GatewayConfigOpts...)
+		}, append(gatewayReconnectHandlerOpts, config.GatewayConfigOpts...)...)
 
 		config.Gateway = gateway.New(token, gatewayEventHandlerFunc(client), nil, config.GatewayConfigOpts...)
 	}
 	client.gateway = config.Gateway
 
 	if config.ShardManager == nil && config.ShardManagerConfigOpts != nil {
-		var gatewayBotRs *discord.GatewayBot
-		gatewayBotRs, err = client.restServices.GetGatewayBot()
-		if err != nil {
-			return nil, err
+		if config.ShardHealthChangeHandler != nil {
+			config.ShardManagerConfigOpts = append([]sharding.ConfigOpt{sharding.WithHealthChangeHandlerFunc(config.ShardHealthChangeHandler(client))}, config.ShardManagerConfigOpts...)
 		}
-
-		shardIDs := make([]int, gatewayBotRs.Shards-1)
-		for i := 0; i < gatewayBotRs.Shards-1; i++ {
-			shardIDs[i] = i
-		}
-
 		config.ShardManagerConfigOpts = append([]sharding.ConfigOpt{
-			sharding.WithShardCount(gatewayBotRs.Shards),
-			sharding.WithShardIDs(shardIDs...),
-			sharding.WithGatewayConfigOpts(
-				gateway.WithGatewayURL(gatewayBotRs.URL),
+			sharding.WithGatewayConfigOpts(append([]gateway.ConfigOpt{
 				gateway.WithLogger(client.logger),
 				gateway.WithOS(os),
 				gateway.WithBrowser(name),
@@ -270,14 +329,18 @@ func BuildClient(token string, config Config, gatewayEventHandlerFunc func(clien
 				func(config *gateway.Config) {
 					config.RateRateLimiterConfigOpts = append([]gateway.RateLimiterConfigOpt{gateway.WithRateLimiterLogger(client.logger)}, config.RateRateLimiterConfigOpts...)
 				},
-			),
+			}, gatewayReconnectHandlerOpts...)...),
 			sharding.WithLogger(client.logger),
 			func(config *sharding.Config) {
-				config.RateRateLimiterConfigOpts = append([]sharding.RateLimiterConfigOpt{sharding.WithRateLimiterLogger(client.logger), sharding.WithMaxConcurrency(gatewayBotRs.SessionStartLimit.MaxConcurrency)}, config.RateRateLimiterConfigOpts...)
+				config.RateRateLimiterConfigOpts = append([]sharding.RateLimiterConfigOpt{sharding.WithRateLimiterLogger(client.logger)}, config.RateRateLimiterConfigOpts...)
 			},
 		}, config.ShardManagerConfigOpts...)
 
-		config.ShardManager = sharding.New(token, gatewayEventHandlerFunc(client), config.ShardManagerConfigOpts...)
+		// the recommended shard count, gateway url & max concurrency are filled in from /gateway/bot
+		config.ShardManager, err = sharding.NewWithGatewayBot(token, client.restServices, gatewayEventHandlerFunc(client), config.ShardManagerConfigOpts...)
+		if err != nil {
+			return nil, err
+		}
 	}
 	client.shardManager = config.ShardManager
 
@@ -300,5 +363,20 @@ func BuildClient(token string, config Config, gatewayEventHandlerFunc func(clien
 	}
 	client.caches = config.Caches
 
+	if config.VoiceManager == nil {
+		config.VoiceManagerConfigOpts = append([]voice.ManagerConfigOpt{
+			voice.WithLogger(client.logger),
+			voice.WithConnConfigOpts(
+				voice.WithConnLogger(client.logger),
+				voice.WithConnVoiceStateCache(client.caches.VoiceStates()),
+				voice.WithConnGatewayConfigOpts(voice.WithGatewayLogger(client.logger)),
+				voice.WithConnUDPConnConfigOpts(voice.WithUDPConnLogger(client.logger)),
+			),
+		}, config.VoiceManagerConfigOpts...)
+
+		config.VoiceManager = voice.NewManager(client.UpdateVoiceState, client.applicationID, config.VoiceManagerConfigOpts...)
+	}
+	client.voiceManager = config.VoiceManager
+
 	return client, nil
 }
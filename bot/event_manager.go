@@ -2,6 +2,7 @@ package bot
 
 import (
 	"io"
+	"reflect"
 	"runtime/debug"
 	"sync"
 
@@ -49,7 +50,10 @@ type EventListener interface {
 	OnEvent(event Event)
 }
 
-var _ EventListener = (*ListenerFunc[Event])(nil)
+var (
+	_ EventListener      = (*ListenerFunc[Event])(nil)
+	_ TypedEventListener = (*ListenerFunc[Event])(nil)
+)
 
 // NewListenerFunc returns a new ListenerFunc for the given func(e E)
 func NewListenerFunc[E Event](f func(e E)) *ListenerFunc[E] {
@@ -68,6 +72,20 @@ func (l *ListenerFunc[E]) OnEvent(e Event) {
 	}
 }
 
+// EventType returns the type of E
+func (l *ListenerFunc[E]) EventType() reflect.Type {
+	return reflect.TypeOf((*E)(nil)).Elem()
+}
+
+// TypedEventListener is an EventListener which only listens to a single Event type.
+// It is used to find out which gateway events need to be decoded for the EventListener.
+type TypedEventListener interface {
+	EventListener
+
+	// EventType returns the type of the Event the EventListener listens to
+	EventType() reflect.Type
+}
+
 // Event the basic interface each event implement
 type Event interface {
 	Client() Client
@@ -100,6 +118,9 @@ func (e *eventManagerImpl) RawEventsEnabled() bool {
 }
 
 func (e *eventManagerImpl) HandleGatewayEvent(gatewayEventType discord.GatewayEventType, sequenceNumber int, shardID int, reader io.Reader) {
+	if e.config.GatewayEventFilter != nil && !e.config.GatewayEventFilter(gatewayEventType) {
+		return
+	}
 	e.mu.Lock()
 	defer e.mu.Unlock()
 	if handler, ok := e.config.GatewayHandlers[gatewayEventType]; ok {
@@ -1,6 +1,9 @@
 package bot
 
-import "github.com/disgoorg/disgo/discord"
+import (
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+)
 
 // DefaultEventManagerConfig returns a new EventManagerConfig with all default values.
 func DefaultEventManagerConfig() *EventManagerConfig {
@@ -13,8 +16,9 @@ type EventManagerConfig struct {
 	RawEventsEnabled   bool
 	AsyncEventsEnabled bool
 
-	GatewayHandlers   map[discord.GatewayEventType]GatewayEventHandler
-	HTTPServerHandler HTTPServerEventHandler
+	GatewayHandlers    map[discord.GatewayEventType]GatewayEventHandler
+	GatewayEventFilter gateway.EventFilter
+	HTTPServerHandler  HTTPServerEventHandler
 }
 
 // EventManagerConfigOpt is a functional option for configuring an EventManager.
@@ -60,6 +64,14 @@ func WithGatewayHandlers(handlers map[discord.GatewayEventType]GatewayEventHandl
 	}
 }
 
+// WithGatewayEventFilter sets the gateway.EventFilter which decides which gateway events are decoded & handled.
+// Raw events are dispatched before filtering.
+func WithGatewayEventFilter(filter gateway.EventFilter) EventManagerConfigOpt {
+	return func(config *EventManagerConfig) {
+		config.GatewayEventFilter = filter
+	}
+}
+
 // WithHTTPServerHandler overrides the given HTTPServerEventHandler in the EventManagerConfig.
 func WithHTTPServerHandler(handler HTTPServerEventHandler) EventManagerConfigOpt {
 	return func(config *EventManagerConfig) {
@@ -20,6 +20,7 @@ func DefaultConfig() *Config {
 		MessageCachePolicy:             PolicyDefault[discord.Message],
 		EmojiCachePolicy:               PolicyDefault[discord.Emoji],
 		StickerCachePolicy:             PolicyDefault[discord.Sticker],
+		AutoModerationRuleCachePolicy:  PolicyDefault[discord.AutoModerationRule],
 	}
 }
 
@@ -39,6 +40,7 @@ type Config struct {
 	MessageCachePolicy             Policy[discord.Message]
 	EmojiCachePolicy               Policy[discord.Emoji]
 	StickerCachePolicy             Policy[discord.Sticker]
+	AutoModerationRuleCachePolicy  Policy[discord.AutoModerationRule]
 }
 
 // ConfigOpt is a type alias for a function that takes a Config and is used to configure your Caches.
@@ -141,3 +143,10 @@ func WithStickerCachePolicy(policy Policy[discord.Sticker]) ConfigOpt {
 		config.StickerCachePolicy = policy
 	}
 }
+
+// WithAutoModerationRuleCachePolicy sets the Policy[discord.AutoModerationRule] of the Config.
+func WithAutoModerationRuleCachePolicy(policy Policy[discord.AutoModerationRule]) ConfigOpt {
+	return func(config *Config) {
+		config.AutoModerationRuleCachePolicy = policy
+	}
+}
@@ -1,5 +1,10 @@
 package cache
 
+import (
+	"github.com/disgoorg/disgo/discord"
+	"golang.org/x/exp/slices"
+)
+
 // Flags are used to enable/disable certain internal caches
 type Flags int
 
@@ -17,6 +22,7 @@ const (
 	FlagStickers
 	FlagVoiceStates
 	FlagStageInstances
+	FlagAutoModerationRules
 	FlagsNone Flags = 0
 
 	FlagsDefault = FlagsNone
@@ -29,7 +35,8 @@ const (
 		FlagStickers |
 		FlagVoiceStates |
 		FlagStageInstances |
-		FlagPresences
+		FlagPresences |
+		FlagAutoModerationRules
 )
 
 // Add allows you to add multiple bits together, producing a new bit
@@ -67,3 +74,118 @@ func (f Flags) Missing(bits ...Flags) bool {
 	}
 	return false
 }
+
+// flagGatewayEventTypes maps each flag to the discord.GatewayEventType(s) which update its cache.
+var flagGatewayEventTypes = map[Flags][]discord.GatewayEventType{
+	FlagGuilds: {
+		discord.GatewayEventTypeReady,
+		discord.GatewayEventTypeGuildCreate,
+		discord.GatewayEventTypeGuildUpdate,
+		discord.GatewayEventTypeGuildDelete,
+		discord.GatewayEventTypeGuildMemberAdd,
+		discord.GatewayEventTypeGuildMemberRemove,
+	},
+	FlagGuildScheduledEvents: {
+		discord.GatewayEventTypeGuildCreate,
+		discord.GatewayEventTypeGuildScheduledEventCreate,
+		discord.GatewayEventTypeGuildScheduledEventUpdate,
+		discord.GatewayEventTypeGuildScheduledEventDelete,
+	},
+	FlagMembers: {
+		discord.GatewayEventTypeGuildCreate,
+		discord.GatewayEventTypeGuildMemberAdd,
+		discord.GatewayEventTypeGuildMemberUpdate,
+		discord.GatewayEventTypeGuildMemberRemove,
+		discord.GatewayEventTypeGuildMembersChunk,
+		discord.GatewayEventTypeChannelUpdate,
+		discord.GatewayEventTypeThreadMembersUpdate,
+		discord.GatewayEventTypeMessageReactionAdd,
+		discord.GatewayEventTypeTypingStart,
+		discord.GatewayEventTypeVoiceStateUpdate,
+	},
+	FlagThreadMembers: {
+		discord.GatewayEventTypeGuildDelete,
+		discord.GatewayEventTypeChannelUpdate,
+		discord.GatewayEventTypeThreadCreate,
+		discord.GatewayEventTypeThreadDelete,
+		discord.GatewayEventTypeThreadMembersUpdate,
+	},
+	FlagMessages: {
+		discord.GatewayEventTypeGuildDelete,
+		discord.GatewayEventTypeMessageCreate,
+		discord.GatewayEventTypeMessageUpdate,
+		discord.GatewayEventTypeMessageDelete,
+		discord.GatewayEventTypeMessageDeleteBulk,
+	},
+	FlagPresences: {
+		discord.GatewayEventTypeGuildCreate,
+		discord.GatewayEventTypeGuildDelete,
+		discord.GatewayEventTypeThreadMembersUpdate,
+		discord.GatewayEventTypePresenceUpdate,
+	},
+	FlagChannels: {
+		discord.GatewayEventTypeGuildCreate,
+		discord.GatewayEventTypeGuildDelete,
+		discord.GatewayEventTypeChannelCreate,
+		discord.GatewayEventTypeChannelUpdate,
+		discord.GatewayEventTypeChannelDelete,
+		discord.GatewayEventTypeChannelPinsUpdate,
+		discord.GatewayEventTypeMessageCreate,
+		discord.GatewayEventTypeThreadCreate,
+		discord.GatewayEventTypeThreadUpdate,
+		discord.GatewayEventTypeThreadDelete,
+		discord.GatewayEventTypeThreadListSync,
+		discord.GatewayEventTypeThreadMembersUpdate,
+	},
+	FlagRoles: {
+		discord.GatewayEventTypeGuildCreate,
+		discord.GatewayEventTypeGuildDelete,
+		discord.GatewayEventTypeGuildRoleCreate,
+		discord.GatewayEventTypeGuildRoleUpdate,
+		discord.GatewayEventTypeGuildRoleDelete,
+	},
+	FlagEmojis: {
+		discord.GatewayEventTypeGuildCreate,
+		discord.GatewayEventTypeGuildDelete,
+		discord.GatewayEventTypeGuildEmojisUpdate,
+	},
+	FlagStickers: {
+		discord.GatewayEventTypeGuildCreate,
+		discord.GatewayEventTypeGuildDelete,
+		discord.GatewayEventTypeGuildStickersUpdate,
+	},
+	FlagVoiceStates: {
+		discord.GatewayEventTypeGuildCreate,
+		discord.GatewayEventTypeGuildDelete,
+		discord.GatewayEventTypeVoiceStateUpdate,
+	},
+	FlagStageInstances: {
+		discord.GatewayEventTypeGuildCreate,
+		discord.GatewayEventTypeGuildDelete,
+		discord.GatewayEventTypeStageInstanceCreate,
+		discord.GatewayEventTypeStageInstanceUpdate,
+		discord.GatewayEventTypeStageInstanceDelete,
+	},
+	FlagAutoModerationRules: {
+		discord.GatewayEventTypeGuildDelete,
+		discord.GatewayEventTypeAutoModerationRuleCreate,
+		discord.GatewayEventTypeAutoModerationRuleUpdate,
+		discord.GatewayEventTypeAutoModerationRuleDelete,
+	},
+}
+
+// GatewayEventTypes returns the discord.GatewayEventType(s) which are needed to keep the caches enabled by the Flags up to date.
+func (f Flags) GatewayEventTypes() []discord.GatewayEventType {
+	var gatewayEventTypes []discord.GatewayEventType
+	for flag, flagEventTypes := range flagGatewayEventTypes {
+		if !f.Has(flag) {
+			continue
+		}
+		for _, gatewayEventType := range flagEventTypes {
+			if !slices.Contains(gatewayEventTypes, gatewayEventType) {
+				gatewayEventTypes = append(gatewayEventTypes, gatewayEventType)
+			}
+		}
+	}
+	return gatewayEventTypes
+}
@@ -74,6 +74,10 @@ type Caches interface {
 
 	// GuildScheduledEvents returns the guild scheduled event cache.
 	GuildScheduledEvents() GroupedCache[discord.GuildScheduledEvent]
+
+	// AutoModerationRules returns the auto moderation rule cache.
+	// Discord does not send the rules with the guild, so only rules received via events are cached.
+	AutoModerationRules() GroupedCache[discord.AutoModerationRule]
 }
 
 // New returns a new default Caches instance with the given ConfigOpt(s) applied.
@@ -96,6 +100,7 @@ func New(opts ...ConfigOpt) Caches {
 		messageCache:             NewGroupedCache[discord.Message](config.CacheFlags, FlagMessages, config.MessageCachePolicy),
 		emojiCache:               NewGroupedCache[discord.Emoji](config.CacheFlags, FlagEmojis, config.EmojiCachePolicy),
 		stickerCache:             NewGroupedCache[discord.Sticker](config.CacheFlags, FlagStickers, config.StickerCachePolicy),
+		autoModerationRuleCache:  NewGroupedCache[discord.AutoModerationRule](config.CacheFlags, FlagAutoModerationRules, config.AutoModerationRuleCachePolicy),
 	}
 }
 
@@ -117,6 +122,7 @@ type cachesImpl struct {
 	messageCache             GroupedCache[discord.Message]
 	emojiCache               GroupedCache[discord.Emoji]
 	stickerCache             GroupedCache[discord.Sticker]
+	autoModerationRuleCache  GroupedCache[discord.AutoModerationRule]
 }
 
 func (c *cachesImpl) CacheFlags() Flags {
@@ -285,3 +291,7 @@ func (c *cachesImpl) StageInstances() GroupedCache[discord.StageInstance] {
 func (c *cachesImpl) GuildScheduledEvents() GroupedCache[discord.GuildScheduledEvent] {
 	return c.guildScheduledEventCache
 }
+
+func (c *cachesImpl) AutoModerationRules() GroupedCache[discord.AutoModerationRule] {
+	return c.autoModerationRuleCache
+}
@@ -3,6 +3,7 @@ package cache
 import (
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/snowflake/v2"
+	"golang.org/x/exp/slices"
 )
 
 // ChannelCache is a Cache for all channel types
@@ -56,6 +57,13 @@ type ChannelCache interface {
 
 	// GetGuildStageVoiceChannel returns a discord.GuildStageVoiceChannel from the ChannelCache and a bool indicating if it exists.
 	GetGuildStageVoiceChannel(channelID snowflake.ID) (discord.GuildStageVoiceChannel, bool)
+
+	// GetGuildForumChannel returns a discord.GuildForumChannel from the ChannelCache and a bool indicating if it exists.
+	GetGuildForumChannel(channelID snowflake.ID) (discord.GuildForumChannel, bool)
+
+	// GuildForumThreads returns all discord.GuildThread(s) in the given discord.GuildForumChannel from the ChannelCache.
+	// The threads can be filtered by the IDs of the discord.ForumTag(s) applied to them, in which case a thread needs to have all of them applied.
+	GuildForumThreads(channelID snowflake.ID, tagIDs ...snowflake.ID) []discord.GuildThread
 }
 
 // NewChannelCache returns a new channelCacheImpl with the given flags and policy.
@@ -217,3 +225,31 @@ func (c *channelCacheImpl) GetGuildStageVoiceChannel(channelID snowflake.ID) (di
 	}
 	return discord.GuildStageVoiceChannel{}, false
 }
+
+func (c *channelCacheImpl) GetGuildForumChannel(channelID snowflake.ID) (discord.GuildForumChannel, bool) {
+	if ch, ok := c.Get(channelID); ok {
+		if cCh, ok := ch.(discord.GuildForumChannel); ok {
+			return cCh, true
+		}
+	}
+	return discord.GuildForumChannel{}, false
+}
+
+func (c *channelCacheImpl) GuildForumThreads(channelID snowflake.ID, tagIDs ...snowflake.ID) []discord.GuildThread {
+	var threads []discord.GuildThread
+	for _, thread := range c.GuildThreadsInChannel(channelID) {
+		if hasAllTags(thread.AppliedTags, tagIDs) {
+			threads = append(threads, thread)
+		}
+	}
+	return threads
+}
+
+func hasAllTags(appliedTags []snowflake.ID, tagIDs []snowflake.ID) bool {
+	for _, tagID := range tagIDs {
+		if !slices.Contains(appliedTags, tagID) {
+			return false
+		}
+	}
+	return true
+}
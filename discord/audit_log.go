@@ -103,9 +103,18 @@ const (
 	AuditLogThreadDelete
 )
 
+// AuditLogAutoModerationRuleCreate
+const (
+	AuditLogAutoModerationRuleCreate AuditLogEvent = iota + 140
+	AuditLogAutoModerationRuleUpdate
+	AuditLogAutoModerationRuleDelete
+	AuditLogAutoModerationBlockMessage
+)
+
 // AuditLog (https://discord.com/developers/docs/resources/audit-log) These are logs of events that occurred, accessible via the Discord
 type AuditLog struct {
 	Entries              []AuditLogEntry       `json:"entries"`
+	AutoModerationRules  []AutoModerationRule  `json:"auto_moderation_rules"`
 	GuildScheduledEvents []GuildScheduledEvent `json:"guild_scheduled_events"`
 	Integrations         []Integration         `json:"integrations"`
 	Threads              []GuildThread         `json:"threads"`
@@ -0,0 +1,103 @@
+package discord
+
+import (
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// AutoModerationEventType indicates in what event context a AutoModerationRule should be checked (https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object-event-types)
+type AutoModerationEventType int
+
+const (
+	AutoModerationEventTypeMessageSend AutoModerationEventType = iota + 1
+)
+
+// AutoModerationTriggerType characterizes the type of content which can trigger the AutoModerationRule (https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object-trigger-types)
+type AutoModerationTriggerType int
+
+const (
+	AutoModerationTriggerTypeKeyword AutoModerationTriggerType = iota + 1
+	_
+	AutoModerationTriggerTypeSpam
+	AutoModerationTriggerTypeKeywordPreset
+	AutoModerationTriggerTypeMentionSpam
+)
+
+// AutoModerationTriggerMetadata is additional data used to determine whether a AutoModerationRule should be triggered.
+// Which fields are used depends on the AutoModerationTriggerType (https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object-trigger-metadata)
+type AutoModerationTriggerMetadata struct {
+	KeywordFilter     []string                      `json:"keyword_filter,omitempty"`
+	RegexPatterns     []string                      `json:"regex_patterns,omitempty"`
+	Presets           []AutoModerationKeywordPreset `json:"presets,omitempty"`
+	AllowList         []string                      `json:"allow_list,omitempty"`
+	MentionTotalLimit int                           `json:"mention_total_limit,omitempty"`
+}
+
+// AutoModerationKeywordPreset is a preset of words discord maintains for AutoModerationTriggerTypeKeywordPreset (https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object-keyword-preset-types)
+type AutoModerationKeywordPreset int
+
+const (
+	AutoModerationKeywordPresetProfanity AutoModerationKeywordPreset = iota + 1
+	AutoModerationKeywordPresetSexualContent
+	AutoModerationKeywordPresetSlurs
+)
+
+// AutoModerationActionType is the type of action which is executed when a AutoModerationRule is triggered (https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-action-object-action-types)
+type AutoModerationActionType int
+
+const (
+	AutoModerationActionTypeBlockMessage AutoModerationActionType = iota + 1
+	AutoModerationActionTypeSendAlertMessage
+	AutoModerationActionTypeTimeout
+)
+
+// AutoModerationAction is an action which is executed when a AutoModerationRule is triggered (https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-action-object)
+type AutoModerationAction struct {
+	Type     AutoModerationActionType      `json:"type"`
+	Metadata *AutoModerationActionMetadata `json:"metadata,omitempty"`
+}
+
+// AutoModerationActionMetadata is additional data used when an AutoModerationAction is executed.
+// Which fields are used depends on the AutoModerationActionType (https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-action-object-action-metadata)
+type AutoModerationActionMetadata struct {
+	ChannelID       snowflake.ID `json:"channel_id,omitempty"`
+	DurationSeconds int          `json:"duration_seconds,omitempty"`
+	CustomMessage   string       `json:"custom_message,omitempty"`
+}
+
+// AutoModerationRule is a rule which is checked by discord before content is sent in a Guild (https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object)
+type AutoModerationRule struct {
+	ID              snowflake.ID                   `json:"id"`
+	GuildID         snowflake.ID                   `json:"guild_id"`
+	Name            string                         `json:"name"`
+	CreatorID       snowflake.ID                   `json:"creator_id"`
+	EventType       AutoModerationEventType        `json:"event_type"`
+	TriggerType     AutoModerationTriggerType      `json:"trigger_type"`
+	TriggerMetadata *AutoModerationTriggerMetadata `json:"trigger_metadata"`
+	Actions         []AutoModerationAction         `json:"actions"`
+	Enabled         bool                           `json:"enabled"`
+	ExemptRoles     []snowflake.ID                 `json:"exempt_roles"`
+	ExemptChannels  []snowflake.ID                 `json:"exempt_channels"`
+}
+
+// AutoModerationRuleCreate is used to create an AutoModerationRule
+type AutoModerationRuleCreate struct {
+	Name            string                         `json:"name"`
+	EventType       AutoModerationEventType        `json:"event_type"`
+	TriggerType     AutoModerationTriggerType      `json:"trigger_type"`
+	TriggerMetadata *AutoModerationTriggerMetadata `json:"trigger_metadata,omitempty"`
+	Actions         []AutoModerationAction         `json:"actions"`
+	Enabled         *bool                          `json:"enabled,omitempty"`
+	ExemptRoles     []snowflake.ID                 `json:"exempt_roles,omitempty"`
+	ExemptChannels  []snowflake.ID                 `json:"exempt_channels,omitempty"`
+}
+
+// AutoModerationRuleUpdate is used to update an AutoModerationRule
+type AutoModerationRuleUpdate struct {
+	Name            *string                        `json:"name,omitempty"`
+	EventType       *AutoModerationEventType       `json:"event_type,omitempty"`
+	TriggerMetadata *AutoModerationTriggerMetadata `json:"trigger_metadata,omitempty"`
+	Actions         *[]AutoModerationAction        `json:"actions,omitempty"`
+	Enabled         *bool                          `json:"enabled,omitempty"`
+	ExemptRoles     *[]snowflake.ID                `json:"exempt_roles,omitempty"`
+	ExemptChannels  *[]snowflake.ID                `json:"exempt_channels,omitempty"`
+}
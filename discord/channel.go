@@ -28,6 +28,7 @@ const (
 	ChannelTypeGuildPrivateThread
 	ChannelTypeGuildStageVoice
 	ChannelTypeGuildDirectory
+	ChannelTypeGuildForum
 )
 
 type Channel interface {
@@ -166,6 +167,11 @@ func (u *UnmarshalChannel) UnmarshalJSON(data []byte) error {
 		err = json.Unmarshal(data, &v)
 		channel = v
 
+	case ChannelTypeGuildForum:
+		var v GuildForumChannel
+		err = json.Unmarshal(data, &v)
+		channel = v
+
 	default:
 		err = fmt.Errorf("unkown channel with type %d received", cType.Type)
 	}
@@ -723,6 +729,8 @@ type GuildThread struct {
 	MessageCount     int
 	MemberCount      int
 	ThreadMetadata   ThreadMetadata
+	// AppliedTags are the IDs of the ForumTag(s) applied to the GuildThread if it is in a GuildForumChannel.
+	AppliedTags []snowflake.ID
 }
 
 func (c *GuildThread) UnmarshalJSON(data []byte) error {
@@ -744,6 +752,7 @@ func (c *GuildThread) UnmarshalJSON(data []byte) error {
 	c.MessageCount = v.MessageCount
 	c.MemberCount = v.MemberCount
 	c.ThreadMetadata = v.ThreadMetadata
+	c.AppliedTags = v.AppliedTags
 	return nil
 }
 
@@ -762,6 +771,7 @@ func (c GuildThread) MarshalJSON() ([]byte, error) {
 		MessageCount:     c.MessageCount,
 		MemberCount:      c.MemberCount,
 		ThreadMetadata:   c.ThreadMetadata,
+		AppliedTags:      c.AppliedTags,
 	})
 }
 
@@ -927,6 +937,149 @@ func (GuildStageVoiceChannel) channel()           {}
 func (GuildStageVoiceChannel) guildChannel()      {}
 func (GuildStageVoiceChannel) guildAudioChannel() {}
 
+var (
+	_ Channel      = (*GuildForumChannel)(nil)
+	_ GuildChannel = (*GuildForumChannel)(nil)
+)
+
+// GuildForumChannel is a channel which only contains GuildThread(s) called posts.
+type GuildForumChannel struct {
+	id                   snowflake.ID
+	guildID              snowflake.ID
+	position             int
+	permissionOverwrites PermissionOverwrites
+	name                 string
+	parentID             *snowflake.ID
+	// LastThreadID is the ID of the last GuildThread created in the GuildForumChannel.
+	LastThreadID                  *snowflake.ID
+	Topic                         *string
+	NSFW                          bool
+	RateLimitPerUser              int
+	AvailableTags                 []ForumTag
+	DefaultReactionEmoji          *DefaultReactionEmoji
+	DefaultThreadRateLimitPerUser int
+	DefaultSortOrder              *DefaultSortOrder
+	DefaultAutoArchiveDuration    AutoArchiveDuration
+}
+
+func (c *GuildForumChannel) UnmarshalJSON(data []byte) error {
+	var v guildForumChannel
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	c.id = v.ID
+	c.guildID = v.GuildID
+	c.position = v.Position
+	c.permissionOverwrites = v.PermissionOverwrites
+	c.name = v.Name
+	c.parentID = v.ParentID
+	c.LastThreadID = v.LastThreadID
+	c.Topic = v.Topic
+	c.NSFW = v.NSFW
+	c.RateLimitPerUser = v.RateLimitPerUser
+	c.AvailableTags = v.AvailableTags
+	c.DefaultReactionEmoji = v.DefaultReactionEmoji
+	c.DefaultThreadRateLimitPerUser = v.DefaultThreadRateLimitPerUser
+	c.DefaultSortOrder = v.DefaultSortOrder
+	c.DefaultAutoArchiveDuration = v.DefaultAutoArchiveDuration
+	return nil
+}
+
+func (c GuildForumChannel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(guildForumChannel{
+		ID:                            c.id,
+		Type:                          c.Type(),
+		GuildID:                       c.guildID,
+		Position:                      c.position,
+		PermissionOverwrites:          c.permissionOverwrites,
+		Name:                          c.name,
+		ParentID:                      c.parentID,
+		LastThreadID:                  c.LastThreadID,
+		Topic:                         c.Topic,
+		NSFW:                          c.NSFW,
+		RateLimitPerUser:              c.RateLimitPerUser,
+		AvailableTags:                 c.AvailableTags,
+		DefaultReactionEmoji:          c.DefaultReactionEmoji,
+		DefaultThreadRateLimitPerUser: c.DefaultThreadRateLimitPerUser,
+		DefaultSortOrder:              c.DefaultSortOrder,
+		DefaultAutoArchiveDuration:    c.DefaultAutoArchiveDuration,
+	})
+}
+
+func (c GuildForumChannel) String() string {
+	return channelString(c)
+}
+
+func (c GuildForumChannel) Mention() string {
+	return ChannelMention(c.ID())
+}
+
+func (GuildForumChannel) Type() ChannelType {
+	return ChannelTypeGuildForum
+}
+
+func (c GuildForumChannel) ID() snowflake.ID {
+	return c.id
+}
+
+func (c GuildForumChannel) Name() string {
+	return c.name
+}
+
+func (c GuildForumChannel) GuildID() snowflake.ID {
+	return c.guildID
+}
+
+func (c GuildForumChannel) PermissionOverwrites() PermissionOverwrites {
+	return c.permissionOverwrites
+}
+
+func (c GuildForumChannel) Position() int {
+	return c.position
+}
+
+func (c GuildForumChannel) ParentID() *snowflake.ID {
+	return c.parentID
+}
+
+// Tag returns the ForumTag with the given ID and a bool indicating if it exists.
+func (c GuildForumChannel) Tag(tagID snowflake.ID) (ForumTag, bool) {
+	for _, tag := range c.AvailableTags {
+		if tag.ID == tagID {
+			return tag, true
+		}
+	}
+	return ForumTag{}, false
+}
+
+func (GuildForumChannel) channel()      {}
+func (GuildForumChannel) guildChannel() {}
+
+// ForumTag is a tag which can be applied to GuildThread(s) in a GuildForumChannel (https://discord.com/developers/docs/resources/channel#forum-tag-object)
+type ForumTag struct {
+	ID   snowflake.ID `json:"id,omitempty"`
+	Name string       `json:"name"`
+	// Moderated is true if the tag can only be added or removed by members with the PermissionManageThreads.
+	Moderated bool          `json:"moderated"`
+	EmojiID   *snowflake.ID `json:"emoji_id"`
+	EmojiName *string       `json:"emoji_name"`
+}
+
+// DefaultReactionEmoji is the emoji shown on GuildThread(s) in a GuildForumChannel to react with (https://discord.com/developers/docs/resources/channel#default-reaction-object)
+type DefaultReactionEmoji struct {
+	EmojiID   *snowflake.ID `json:"emoji_id"`
+	EmojiName *string       `json:"emoji_name"`
+}
+
+// DefaultSortOrder is the order GuildThread(s) in a GuildForumChannel are sorted by (https://discord.com/developers/docs/resources/channel#channel-object-sort-order-types)
+type DefaultSortOrder int
+
+const (
+	DefaultSortOrderLatestActivity DefaultSortOrder = iota
+	DefaultSortOrderCreationDate
+)
+
 type FollowedChannel struct {
 	ChannelID snowflake.ID `json:"channel_id"`
 	WebhookID snowflake.ID `json:"webhook_id"`
@@ -988,6 +1141,9 @@ func ApplyGuildIDToChannel(channel GuildChannel, guildID snowflake.ID) GuildChan
 	case GuildStageVoiceChannel:
 		c.guildID = guildID
 		return c
+	case GuildForumChannel:
+		c.guildID = guildID
+		return c
 	case GuildThread:
 		c.guildID = guildID
 		return c
@@ -180,6 +180,44 @@ func (c GuildStageVoiceChannelCreate) MarshalJSON() ([]byte, error) {
 func (GuildStageVoiceChannelCreate) channelCreate()      {}
 func (GuildStageVoiceChannelCreate) guildChannelCreate() {}
 
+var (
+	_ ChannelCreate      = (*GuildForumChannelCreate)(nil)
+	_ GuildChannelCreate = (*GuildForumChannelCreate)(nil)
+)
+
+type GuildForumChannelCreate struct {
+	Name                          string                `json:"name"`
+	Topic                         string                `json:"topic,omitempty"`
+	Position                      int                   `json:"position,omitempty"`
+	PermissionOverwrites          []PermissionOverwrite `json:"permission_overwrites,omitempty"`
+	ParentID                      snowflake.ID          `json:"parent_id,omitempty"`
+	NSFW                          bool                  `json:"nsfw,omitempty"`
+	RateLimitPerUser              int                   `json:"rate_limit_per_user,omitempty"`
+	AvailableTags                 []ForumTag            `json:"available_tags,omitempty"`
+	DefaultReactionEmoji          *DefaultReactionEmoji `json:"default_reaction_emoji,omitempty"`
+	DefaultThreadRateLimitPerUser int                   `json:"default_thread_rate_limit_per_user,omitempty"`
+	DefaultSortOrder              *DefaultSortOrder     `json:"default_sort_order,omitempty"`
+	DefaultAutoArchiveDuration    AutoArchiveDuration   `json:"default_auto_archive_duration,omitempty"`
+}
+
+func (c GuildForumChannelCreate) Type() ChannelType {
+	return ChannelTypeGuildForum
+}
+
+func (c GuildForumChannelCreate) MarshalJSON() ([]byte, error) {
+	type guildForumChannelCreate GuildForumChannelCreate
+	return json.Marshal(struct {
+		Type ChannelType `json:"type"`
+		guildForumChannelCreate
+	}{
+		Type:                    c.Type(),
+		guildForumChannelCreate: guildForumChannelCreate(c),
+	})
+}
+
+func (GuildForumChannelCreate) channelCreate()      {}
+func (GuildForumChannelCreate) guildChannelCreate() {}
+
 type DMChannelCreate struct {
 	RecipientID snowflake.ID `json:"recipient_id"`
 }
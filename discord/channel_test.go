@@ -0,0 +1,64 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalGuildForumChannel(t *testing.T) {
+	data := []byte(`{
+		"id": "1",
+		"type": 15,
+		"guild_id": "2",
+		"name": "help",
+		"last_message_id": "3",
+		"available_tags": [{"id": "4", "name": "solved", "moderated": true, "emoji_id": null, "emoji_name": "✅"}],
+		"default_reaction_emoji": {"emoji_id": "5", "emoji_name": null},
+		"default_thread_rate_limit_per_user": 10,
+		"default_sort_order": 1
+	}`)
+
+	var v UnmarshalChannel
+	require.NoError(t, json.Unmarshal(data, &v))
+	channel, ok := v.Channel.(GuildForumChannel)
+	require.True(t, ok)
+
+	assert.Equal(t, snowflake.ID(2), channel.GuildID())
+	assert.Equal(t, snowflake.ID(3), *channel.LastThreadID)
+	assert.Equal(t, 10, channel.DefaultThreadRateLimitPerUser)
+	assert.Equal(t, DefaultSortOrderCreationDate, *channel.DefaultSortOrder)
+	assert.Equal(t, snowflake.ID(5), *channel.DefaultReactionEmoji.EmojiID)
+
+	tag, ok := channel.Tag(4)
+	require.True(t, ok)
+	assert.Equal(t, "solved", tag.Name)
+	assert.True(t, tag.Moderated)
+
+	// marshaling keeps all forum fields
+	data, err := json.Marshal(channel)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &v))
+	assert.Equal(t, channel, v.Channel)
+}
+
+func TestUnmarshalForumThread(t *testing.T) {
+	data := []byte(`{
+		"id": "6",
+		"type": 11,
+		"guild_id": "2",
+		"parent_id": "1",
+		"name": "how do I",
+		"applied_tags": ["4"],
+		"message": {"id": "6", "channel_id": "6", "content": "question"}
+	}`)
+
+	var thread ForumThread
+	require.NoError(t, json.Unmarshal(data, &thread))
+	assert.Equal(t, snowflake.ID(1), *thread.ParentID())
+	assert.Equal(t, []snowflake.ID{4}, thread.AppliedTags)
+	assert.Equal(t, "question", thread.Message.Content)
+}
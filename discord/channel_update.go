@@ -74,6 +74,7 @@ type GuildThreadUpdate struct {
 	Locked              *bool                `json:"locked,omitempty"`
 	Invitable           *bool                `json:"invitable,omitempty"`
 	RateLimitPerUser    *int                 `json:"rate_limit_per_user,omitempty"`
+	AppliedTags         *[]snowflake.ID      `json:"applied_tags,omitempty"`
 }
 
 func (GuildThreadUpdate) channelUpdate()      {}
@@ -93,6 +94,24 @@ type GuildStageVoiceChannelUpdate struct {
 func (GuildStageVoiceChannelUpdate) channelUpdate()      {}
 func (GuildStageVoiceChannelUpdate) guildChannelUpdate() {}
 
+type GuildForumChannelUpdate struct {
+	Name                          *string                              `json:"name,omitempty"`
+	Position                      *int                                 `json:"position,omitempty"`
+	Topic                         *string                              `json:"topic,omitempty"`
+	NSFW                          *bool                                `json:"nsfw,omitempty"`
+	RateLimitPerUser              *int                                 `json:"rate_limit_per_user,omitempty"`
+	PermissionOverwrites          *[]PermissionOverwrite               `json:"permission_overwrites,omitempty"`
+	ParentID                      *snowflake.ID                        `json:"parent_id,omitempty"`
+	AvailableTags                 *[]ForumTag                          `json:"available_tags,omitempty"`
+	DefaultReactionEmoji          *json.Nullable[DefaultReactionEmoji] `json:"default_reaction_emoji,omitempty"`
+	DefaultThreadRateLimitPerUser *int                                 `json:"default_thread_rate_limit_per_user,omitempty"`
+	DefaultSortOrder              *json.Nullable[DefaultSortOrder]     `json:"default_sort_order,omitempty"`
+	DefaultAutoArchiveDuration    *AutoArchiveDuration                 `json:"default_auto_archive_duration,omitempty"`
+}
+
+func (GuildForumChannelUpdate) channelUpdate()      {}
+func (GuildForumChannelUpdate) guildChannelUpdate() {}
+
 type GuildChannelPositionUpdate struct {
 	ID              snowflake.ID                 `json:"id"`
 	Position        *json.Nullable[int]          `json:"position"`
@@ -89,6 +89,7 @@ type guildThread struct {
 	MessageCount     int            `json:"message_count"`
 	MemberCount      int            `json:"member_count"`
 	ThreadMetadata   ThreadMetadata `json:"thread_metadata"`
+	AppliedTags      []snowflake.ID `json:"applied_tags"`
 }
 
 type guildCategoryChannel struct {
@@ -173,6 +174,39 @@ func (t *guildStageVoiceChannel) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+type guildForumChannel struct {
+	ID                            snowflake.ID          `json:"id"`
+	Type                          ChannelType           `json:"type"`
+	GuildID                       snowflake.ID          `json:"guild_id"`
+	Position                      int                   `json:"position"`
+	PermissionOverwrites          []PermissionOverwrite `json:"permission_overwrites"`
+	Name                          string                `json:"name"`
+	ParentID                      *snowflake.ID         `json:"parent_id"`
+	LastThreadID                  *snowflake.ID         `json:"last_message_id"`
+	Topic                         *string               `json:"topic"`
+	NSFW                          bool                  `json:"nsfw"`
+	RateLimitPerUser              int                   `json:"rate_limit_per_user"`
+	AvailableTags                 []ForumTag            `json:"available_tags"`
+	DefaultReactionEmoji          *DefaultReactionEmoji `json:"default_reaction_emoji"`
+	DefaultThreadRateLimitPerUser int                   `json:"default_thread_rate_limit_per_user"`
+	DefaultSortOrder              *DefaultSortOrder     `json:"default_sort_order"`
+	DefaultAutoArchiveDuration    AutoArchiveDuration   `json:"default_auto_archive_duration"`
+}
+
+func (t *guildForumChannel) UnmarshalJSON(data []byte) error {
+	type guildForumChannelAlias guildForumChannel
+	var v struct {
+		PermissionOverwrites []UnmarshalPermissionOverwrite `json:"permission_overwrites"`
+		guildForumChannelAlias
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*t = guildForumChannel(v.guildForumChannelAlias)
+	t.PermissionOverwrites = parsePermissionOverwrites(v.PermissionOverwrites)
+	return nil
+}
+
 func parsePermissionOverwrites(overwrites []UnmarshalPermissionOverwrite) []PermissionOverwrite {
 	if len(overwrites) == 0 {
 		return nil
@@ -10,10 +10,13 @@ var (
 	ErrNoShardManager          = errors.New("no shard manager configured")
 	ErrNoGateway               = errors.New("no gateway configured")
 	ErrGatewayAlreadyConnected = errors.New("gateway is already connected")
+	ErrGatewayClosed           = errors.New("gateway was closed")
 	ErrShardNotConnected       = errors.New("shard is not connected")
 	ErrShardNotFound           = errors.New("shard not found in shard manager")
 	ErrGatewayCompressedData   = errors.New("disgo does not currently support compressed gateway data")
 	ErrNoHTTPServer            = errors.New("no http server configured")
+	ErrNoEventSource           = errors.New("no event source configured")
+	ErrNoVoiceManager          = errors.New("no voice manager configured")
 
 	ErrNoDisgoInstance = errors.New("no disgo instance injected")
 
@@ -32,4 +35,6 @@ var (
 	ErrMemberMustBeConnectedToChannel = errors.New("the member must be connected to the channel")
 
 	ErrStickerTypeGuild = errors.New("sticker type must be of type StickerTypeGuild")
+
+	ErrRoleNotFound = errors.New("role not found")
 )
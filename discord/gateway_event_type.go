@@ -8,6 +8,10 @@ const (
 	GatewayEventTypeReady                               GatewayEventType = "READY"
 	GatewayEventTypeResumed                             GatewayEventType = "RESUMED"
 	GatewayEventTypeApplicationCommandPermissionsUpdate GatewayEventType = "APPLICATION_COMMAND_PERMISSIONS_UPDATE"
+	GatewayEventTypeAutoModerationRuleCreate            GatewayEventType = "AUTO_MODERATION_RULE_CREATE"
+	GatewayEventTypeAutoModerationRuleUpdate            GatewayEventType = "AUTO_MODERATION_RULE_UPDATE"
+	GatewayEventTypeAutoModerationRuleDelete            GatewayEventType = "AUTO_MODERATION_RULE_DELETE"
+	GatewayEventTypeAutoModerationActionExecution       GatewayEventType = "AUTO_MODERATION_ACTION_EXECUTION"
 	GatewayEventTypeChannelCreate                       GatewayEventType = "CHANNEL_CREATE"
 	GatewayEventTypeChannelUpdate                       GatewayEventType = "CHANNEL_UPDATE"
 	GatewayEventTypeChannelDelete                       GatewayEventType = "CHANNEL_DELETE"
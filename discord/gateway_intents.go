@@ -22,6 +22,11 @@ const (
 	GatewayIntentDirectMessageTyping
 	GatewayIntentMessageContent
 	GatewayIntentGuildScheduledEvents
+	_
+	_
+	_
+	GatewayIntentAutoModerationConfiguration
+	GatewayIntentAutoModerationExecution
 
 	GatewayIntentsGuild = GatewayIntentGuilds |
 		GatewayIntentGuildMembers |
@@ -35,7 +40,9 @@ const (
 		GatewayIntentGuildMessages |
 		GatewayIntentGuildMessageReactions |
 		GatewayIntentGuildMessageTyping |
-		GatewayIntentGuildScheduledEvents
+		GatewayIntentGuildScheduledEvents |
+		GatewayIntentAutoModerationConfiguration |
+		GatewayIntentAutoModerationExecution
 
 	GatewayIntentsDirectMessage = GatewayIntentDirectMessages |
 		GatewayIntentDirectMessageReactions |
@@ -54,7 +61,9 @@ const (
 		GatewayIntentDirectMessages |
 		GatewayIntentDirectMessageReactions |
 		GatewayIntentDirectMessageTyping |
-		GatewayIntentGuildScheduledEvents
+		GatewayIntentGuildScheduledEvents |
+		GatewayIntentAutoModerationConfiguration |
+		GatewayIntentAutoModerationExecution
 
 	GatewayIntentsPrivileged = GatewayIntentGuildMembers |
 		GatewayIntentGuildPresences | GatewayIntentMessageContent
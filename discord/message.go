@@ -36,6 +36,7 @@ const (
 	MessageTypeThreadStarterMessage
 	MessageTypeGuildInviteReminder
 	MessageTypeContextMenuCommand
+	MessageTypeAutoModerationAction
 )
 
 // Message is a struct for messages sent in discord text-based channels
@@ -1,6 +1,9 @@
 package discord
 
-import "github.com/disgoorg/disgo/json"
+import (
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/snowflake/v2"
+)
 
 type ThreadCreateWithMessage struct {
 	Name                string              `json:"name"`
@@ -73,6 +76,45 @@ func (GuildPrivateThreadCreate) Type() ChannelType {
 	return ChannelTypeGuildPrivateThread
 }
 
+// ForumThreadCreate is used to create a post in a GuildForumChannel, which is a GuildThread with a starter Message.
+type ForumThreadCreate struct {
+	Name                string              `json:"name"`
+	AutoArchiveDuration AutoArchiveDuration `json:"auto_archive_duration,omitempty"`
+	RateLimitPerUser    int                 `json:"rate_limit_per_user,omitempty"`
+	// AppliedTags are the IDs of the ForumTag(s) of the GuildForumChannel to apply to the GuildThread.
+	AppliedTags []snowflake.ID `json:"applied_tags,omitempty"`
+	Message     MessageCreate  `json:"message"`
+}
+
+// ToBody returns the ForumThreadCreate ready for body
+func (c ForumThreadCreate) ToBody() (any, error) {
+	if len(c.Message.Files) > 0 {
+		c.Message.Attachments = parseAttachments(c.Message.Files)
+		return PayloadWithFiles(c, c.Message.Files...)
+	}
+	return c, nil
+}
+
+// ForumThread is a GuildThread in a GuildForumChannel together with its starter Message.
+type ForumThread struct {
+	GuildThread
+	Message Message
+}
+
+func (t *ForumThread) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Message Message `json:"message"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &t.GuildThread); err != nil {
+		return err
+	}
+	t.Message = v.Message
+	return nil
+}
+
 type GetThreads struct {
 	Threads []GuildThread  `json:"threads"`
 	Members []ThreadMember `json:"members"`
@@ -16,6 +16,14 @@
 //
 // Package sharding is used to connect and interact with the Discord Gateway.
 //
+// EventStream
+//
+// Package eventstream is used to move raw gateway events between processes.
+//
+// Voice
+//
+// Package voice is used to connect to Discord voice channels and send audio.
+//
 // Cache
 //
 // Package cache provides a generic cache interface for Discord entities.
@@ -98,6 +106,8 @@ func getOS() string {
 // New creates a new bot.Client with the provided token & bot.ConfigOpt(s)
 func New(token string, opts ...bot.ConfigOpt) (bot.Client, error) {
 	config := bot.DefaultConfig(handlers.GetGatewayHandlers(), handlers.GetHTTPServerHandler())
+	config.GatewayReconnectHandler = handlers.DefaultGatewayReconnectHandler
+	config.ShardHealthChangeHandler = handlers.DefaultShardHealthChangeHandler
 	config.Apply(opts)
 
 	return bot.BuildClient(token,
@@ -0,0 +1,178 @@
+package events
+
+import (
+	"reflect"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// requiredGatewayEventTypes are always needed to keep the bot.Client state like the self user, member chunking & voice connections working.
+var requiredGatewayEventTypes = []discord.GatewayEventType{
+	discord.GatewayEventTypeReady,
+	discord.GatewayEventTypeResumed,
+	discord.GatewayEventTypeUserUpdate,
+	discord.GatewayEventTypeGuildMembersChunk,
+	discord.GatewayEventTypeVoiceStateUpdate,
+	discord.GatewayEventTypeVoiceServerUpdate,
+}
+
+// eventGatewayEventTypes maps each bot.Event type to the discord.GatewayEventType(s) it is dispatched for.
+var eventGatewayEventTypes = map[reflect.Type][]discord.GatewayEventType{
+	eventType[*GatewayReconnect]():                         {},
+	eventType[*ShardHealthChange]():                        {},
+	eventType[*ApplicationCommandInteractionCreate]():      {discord.GatewayEventTypeInteractionCreate},
+	eventType[*AutocompleteInteractionCreate]():            {discord.GatewayEventTypeInteractionCreate},
+	eventType[*AutoModerationActionExecution]():            {discord.GatewayEventTypeAutoModerationActionExecution},
+	eventType[*AutoModerationRuleCreate]():                 {discord.GatewayEventTypeAutoModerationRuleCreate},
+	eventType[*AutoModerationRuleDelete]():                 {discord.GatewayEventTypeAutoModerationRuleDelete},
+	eventType[*AutoModerationRuleUpdate]():                 {discord.GatewayEventTypeAutoModerationRuleUpdate},
+	eventType[*ComponentInteractionCreate]():               {discord.GatewayEventTypeInteractionCreate},
+	eventType[*DMChannelCreate]():                          {discord.GatewayEventTypeChannelCreate},
+	eventType[*DMChannelDelete]():                          {discord.GatewayEventTypeChannelDelete},
+	eventType[*DMChannelPinsUpdate]():                      {discord.GatewayEventTypeChannelPinsUpdate},
+	eventType[*DMChannelUpdate]():                          {discord.GatewayEventTypeChannelUpdate},
+	eventType[*DMMessageCreate]():                          {discord.GatewayEventTypeMessageCreate},
+	eventType[*DMMessageDelete]():                          {discord.GatewayEventTypeMessageDelete, discord.GatewayEventTypeMessageDeleteBulk},
+	eventType[*DMMessageReactionAdd]():                     {discord.GatewayEventTypeMessageReactionAdd},
+	eventType[*DMMessageReactionRemove]():                  {discord.GatewayEventTypeMessageReactionRemove},
+	eventType[*DMMessageReactionRemoveAll]():               {discord.GatewayEventTypeMessageReactionRemoveAll},
+	eventType[*DMMessageReactionRemoveEmoji]():             {discord.GatewayEventTypeMessageReactionRemoveEmoji},
+	eventType[*DMMessageUpdate]():                          {discord.GatewayEventTypeMessageUpdate},
+	eventType[*DMUserTypingStart]():                        {discord.GatewayEventTypeTypingStart},
+	eventType[*EmojiCreate]():                              {discord.GatewayEventTypeGuildEmojisUpdate},
+	eventType[*EmojiDelete]():                              {discord.GatewayEventTypeGuildEmojisUpdate},
+	eventType[*EmojiUpdate]():                              {discord.GatewayEventTypeGuildEmojisUpdate},
+	eventType[*EmojisUpdate]():                             {discord.GatewayEventTypeGuildEmojisUpdate},
+	eventType[*GuildApplicationCommandPermissionsUpdate](): {discord.GatewayEventTypeApplicationCommandPermissionsUpdate},
+	eventType[*GuildAvailable]():                           {discord.GatewayEventTypeGuildCreate},
+	eventType[*GuildBan]():                                 {discord.GatewayEventTypeGuildBanAdd},
+	eventType[*GuildChannelCreate]():                       {discord.GatewayEventTypeChannelCreate},
+	eventType[*GuildChannelDelete]():                       {discord.GatewayEventTypeChannelDelete},
+	eventType[*GuildChannelPinsUpdate]():                   {discord.GatewayEventTypeChannelPinsUpdate},
+	eventType[*GuildChannelUpdate]():                       {discord.GatewayEventTypeChannelUpdate},
+	eventType[*GuildIntegrationsUpdate]():                  {discord.GatewayEventTypeGuildIntegrationsUpdate},
+	eventType[*GuildJoin]():                                {discord.GatewayEventTypeGuildCreate},
+	eventType[*GuildLeave]():                               {discord.GatewayEventTypeGuildDelete},
+	eventType[*GuildMemberJoin]():                          {discord.GatewayEventTypeGuildMemberAdd},
+	eventType[*GuildMemberLeave]():                         {discord.GatewayEventTypeGuildMemberRemove},
+	eventType[*GuildMemberTypingStart]():                   {discord.GatewayEventTypeTypingStart},
+	eventType[*GuildMemberUpdate]():                        {discord.GatewayEventTypeGuildMemberUpdate},
+	eventType[*GuildMessageCreate]():                       {discord.GatewayEventTypeMessageCreate},
+	eventType[*GuildMessageDelete]():                       {discord.GatewayEventTypeMessageDelete, discord.GatewayEventTypeMessageDeleteBulk},
+	eventType[*GuildMessageReactionAdd]():                  {discord.GatewayEventTypeMessageReactionAdd},
+	eventType[*GuildMessageReactionRemove]():               {discord.GatewayEventTypeMessageReactionRemove},
+	eventType[*GuildMessageReactionRemoveAll]():            {discord.GatewayEventTypeMessageReactionRemoveAll},
+	eventType[*GuildMessageReactionRemoveEmoji]():          {discord.GatewayEventTypeMessageReactionRemoveEmoji},
+	eventType[*GuildMessageUpdate]():                       {discord.GatewayEventTypeMessageUpdate},
+	eventType[*GuildReady]():                               {discord.GatewayEventTypeGuildCreate},
+	eventType[*GuildScheduledEventCreate]():                {discord.GatewayEventTypeGuildScheduledEventCreate},
+	eventType[*GuildScheduledEventDelete]():                {discord.GatewayEventTypeGuildScheduledEventDelete},
+	eventType[*GuildScheduledEventUpdate]():                {discord.GatewayEventTypeGuildScheduledEventUpdate},
+	eventType[*GuildScheduledEventUserAdd]():               {discord.GatewayEventTypeGuildScheduledEventUserAdd},
+	eventType[*GuildScheduledEventUserRemove]():            {discord.GatewayEventTypeGuildScheduledEventUserRemove},
+	eventType[*GuildUnavailable]():                         {discord.GatewayEventTypeGuildDelete},
+	eventType[*GuildUnban]():                               {discord.GatewayEventTypeGuildBanRemove},
+	eventType[*GuildUpdate]():                              {discord.GatewayEventTypeGuildUpdate},
+	eventType[*GuildVoiceJoin]():                           {discord.GatewayEventTypeVoiceStateUpdate},
+	eventType[*GuildVoiceLeave]():                          {discord.GatewayEventTypeVoiceStateUpdate},
+	eventType[*GuildVoiceMove]():                           {discord.GatewayEventTypeVoiceStateUpdate},
+	eventType[*GuildVoiceStateUpdate]():                    {discord.GatewayEventTypeVoiceStateUpdate},
+	eventType[*GuildsReady]():                              {discord.GatewayEventTypeGuildCreate},
+	eventType[*IntegrationCreate]():                        {discord.GatewayEventTypeIntegrationCreate},
+	eventType[*IntegrationDelete]():                        {discord.GatewayEventTypeIntegrationDelete},
+	eventType[*IntegrationUpdate]():                        {discord.GatewayEventTypeIntegrationUpdate},
+	eventType[*InteractionCreate]():                        {discord.GatewayEventTypeInteractionCreate},
+	eventType[*InviteCreate]():                             {discord.GatewayEventTypeInviteCreate},
+	eventType[*InviteDelete]():                             {discord.GatewayEventTypeInviteDelete},
+	eventType[*MessageCreate]():                            {discord.GatewayEventTypeMessageCreate},
+	eventType[*MessageDelete]():                            {discord.GatewayEventTypeMessageDelete, discord.GatewayEventTypeMessageDeleteBulk},
+	eventType[*MessageReactionAdd]():                       {discord.GatewayEventTypeMessageReactionAdd},
+	eventType[*MessageReactionRemove]():                    {discord.GatewayEventTypeMessageReactionRemove},
+	eventType[*MessageReactionRemoveAll]():                 {discord.GatewayEventTypeMessageReactionRemoveAll},
+	eventType[*MessageReactionRemoveEmoji]():               {discord.GatewayEventTypeMessageReactionRemoveEmoji},
+	eventType[*MessageUpdate]():                            {discord.GatewayEventTypeMessageUpdate},
+	eventType[*ModalSubmitInteractionCreate]():             {discord.GatewayEventTypeInteractionCreate},
+	eventType[*Ready]():                                    {discord.GatewayEventTypeReady},
+	eventType[*Resumed]():                                  {discord.GatewayEventTypeResumed},
+	eventType[*RoleCreate]():                               {discord.GatewayEventTypeGuildRoleCreate},
+	eventType[*RoleDelete]():                               {discord.GatewayEventTypeGuildRoleDelete},
+	eventType[*RoleUpdate]():                               {discord.GatewayEventTypeGuildRoleUpdate},
+	eventType[*SelfUpdate]():                               {discord.GatewayEventTypeUserUpdate},
+	eventType[*StageInstanceCreate]():                      {discord.GatewayEventTypeStageInstanceCreate},
+	eventType[*StageInstanceDelete]():                      {discord.GatewayEventTypeStageInstanceDelete},
+	eventType[*StageInstanceUpdate]():                      {discord.GatewayEventTypeStageInstanceUpdate},
+	eventType[*StickerCreate]():                            {discord.GatewayEventTypeGuildStickersUpdate},
+	eventType[*StickerDelete]():                            {discord.GatewayEventTypeGuildStickersUpdate},
+	eventType[*StickerUpdate]():                            {discord.GatewayEventTypeGuildStickersUpdate},
+	eventType[*StickersUpdate]():                           {discord.GatewayEventTypeGuildStickersUpdate},
+	eventType[*ThreadCreate]():                             {discord.GatewayEventTypeThreadCreate},
+	eventType[*ThreadDelete]():                             {discord.GatewayEventTypeThreadDelete},
+	eventType[*ThreadHide]():                               {discord.GatewayEventTypeChannelUpdate},
+	eventType[*ThreadMemberAdd]():                          {discord.GatewayEventTypeThreadMembersUpdate},
+	eventType[*ThreadMemberRemove]():                       {discord.GatewayEventTypeThreadMembersUpdate},
+	eventType[*ThreadMemberUpdate]():                       {discord.GatewayEventTypeThreadMemberUpdate},
+	eventType[*ThreadShow]():                               {discord.GatewayEventTypeThreadListSync},
+	eventType[*ThreadUpdate]():                             {discord.GatewayEventTypeThreadUpdate},
+	eventType[*UserActivityStart]():                        {discord.GatewayEventTypePresenceUpdate},
+	eventType[*UserActivityStop]():                         {discord.GatewayEventTypePresenceUpdate},
+	eventType[*UserActivityUpdate]():                       {discord.GatewayEventTypePresenceUpdate},
+	eventType[*UserClientStatusUpdate]():                   {discord.GatewayEventTypePresenceUpdate},
+	eventType[*UserStatusUpdate]():                         {discord.GatewayEventTypePresenceUpdate},
+	eventType[*UserTypingStart]():                          {discord.GatewayEventTypeTypingStart},
+	eventType[*VoiceServerUpdate]():                        {discord.GatewayEventTypeVoiceServerUpdate},
+	eventType[*WebhooksUpdate]():                           {discord.GatewayEventTypeWebhooksUpdate},
+}
+
+func eventType[E bot.Event]() reflect.Type {
+	return reflect.TypeOf((*E)(nil)).Elem()
+}
+
+// GatewayEventFilter returns a gateway.EventFilter which only allows the discord.GatewayEventType(s) needed by the given cache.Flags & bot.EventListener(s).
+// bot.TypedEventListener(s) and ListenerAdapter(s) are supported. If any other bot.EventListener is passed, all events are allowed.
+// bot.EventListener(s) added later on are not taken into account.
+func GatewayEventFilter(cacheFlags cache.Flags, listeners ...bot.EventListener) gateway.EventFilter {
+	gatewayEventTypes, ok := ListenerGatewayEventTypes(listeners...)
+	if !ok {
+		return gateway.EventFilterAll
+	}
+	gatewayEventTypes = append(gatewayEventTypes, requiredGatewayEventTypes...)
+	gatewayEventTypes = append(gatewayEventTypes, cacheFlags.GatewayEventTypes()...)
+	return gateway.EventFilterAllow(gatewayEventTypes...)
+}
+
+// ListenerGatewayEventTypes returns the discord.GatewayEventType(s) the given bot.EventListener(s) need to receive their events.
+// It returns false if the events of any bot.EventListener can't be determined.
+func ListenerGatewayEventTypes(listeners ...bot.EventListener) ([]discord.GatewayEventType, bool) {
+	var gatewayEventTypes []discord.GatewayEventType
+	for _, listener := range listeners {
+		switch l := listener.(type) {
+		case *ListenerAdapter:
+			v := reflect.ValueOf(l).Elem()
+			for i := 0; i < v.NumField(); i++ {
+				field := v.Field(i)
+				if field.Kind() != reflect.Func || field.IsNil() {
+					continue
+				}
+				types, ok := eventGatewayEventTypes[field.Type().In(0)]
+				if !ok {
+					return nil, false
+				}
+				gatewayEventTypes = append(gatewayEventTypes, types...)
+			}
+
+		case bot.TypedEventListener:
+			types, ok := eventGatewayEventTypes[l.EventType()]
+			if !ok {
+				return nil, false
+			}
+			gatewayEventTypes = append(gatewayEventTypes, types...)
+
+		default:
+			return nil, false
+		}
+	}
+	return gatewayEventTypes, true
+}
@@ -0,0 +1,40 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGatewayEventFilter(t *testing.T) {
+	filter := GatewayEventFilter(cache.FlagRoles,
+		&ListenerAdapter{
+			OnGuildMessageCreate: func(event *GuildMessageCreate) {},
+		},
+		bot.NewListenerFunc(func(e *GuildVoiceJoin) {}),
+	)
+
+	assert.True(t, filter(discord.GatewayEventTypeReady))
+	assert.True(t, filter(discord.GatewayEventTypeMessageCreate))
+	assert.True(t, filter(discord.GatewayEventTypeVoiceStateUpdate))
+	assert.True(t, filter(discord.GatewayEventTypeGuildRoleCreate))
+	assert.False(t, filter(discord.GatewayEventTypeTypingStart))
+	assert.False(t, filter(discord.GatewayEventTypePresenceUpdate))
+}
+
+func TestGatewayEventFilterUnknownListener(t *testing.T) {
+	filter := GatewayEventFilter(cache.FlagsNone,
+		bot.NewListenerFunc(func(e bot.Event) {}),
+	)
+	assert.True(t, filter(discord.GatewayEventTypeTypingStart))
+
+	filter = GatewayEventFilter(cache.FlagsNone,
+		&ListenerAdapter{
+			OnRaw: func(event *Raw) {},
+		},
+	)
+	assert.True(t, filter(discord.GatewayEventTypePresenceUpdate))
+}
@@ -1,7 +1,10 @@
 package events
 
 import (
+	"time"
+
 	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/sharding"
 )
 
 // Ready indicates we received the Ready from the gateway.Gateway
@@ -14,3 +17,21 @@ type Ready struct {
 type Resumed struct {
 	*GenericEvent
 }
+
+// GatewayReconnect indicates the gateway.Gateway lost its connection and is about to try reconnecting
+type GatewayReconnect struct {
+	*GenericEvent
+	// Try is the number of the reconnect try starting at 1
+	Try int
+	// Delay is the time the gateway.Gateway waits before connecting
+	Delay time.Duration
+	// Err is the error of the previous failed try if any
+	Err error
+}
+
+// ShardHealthChange indicates the status or health of a shard of the sharding.ShardManager changed or the shard was restarted
+type ShardHealthChange struct {
+	*GenericEvent
+	OldHealth sharding.ShardHealth
+	Health    sharding.ShardHealth
+}
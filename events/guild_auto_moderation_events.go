@@ -0,0 +1,45 @@
+package events
+
+import (
+	"github.com/disgoorg/disgo/discord"
+)
+
+// GenericAutoModerationRule is the base struct for all AutoModerationRule events.
+type GenericAutoModerationRule struct {
+	*GenericEvent
+	AutoModerationRule discord.AutoModerationRule
+}
+
+// AutoModerationRuleCreate is dispatched when a discord.AutoModerationRule is created.
+type AutoModerationRuleCreate struct {
+	*GenericAutoModerationRule
+}
+
+// AutoModerationRuleUpdate is dispatched when a discord.AutoModerationRule is updated.
+type AutoModerationRuleUpdate struct {
+	*GenericAutoModerationRule
+	OldAutoModerationRule discord.AutoModerationRule
+}
+
+// AutoModerationRuleDelete is dispatched when a discord.AutoModerationRule is deleted.
+type AutoModerationRuleDelete struct {
+	*GenericAutoModerationRule
+}
+
+// AutoModerationActionExecution is dispatched when a discord.AutoModerationRule is triggered and a discord.AutoModerationAction is executed.
+// This requires the discord.GatewayIntentAutoModerationExecution to be set.
+// The content fields are only filled if the discord.GatewayIntentMessageContent is set too.
+type AutoModerationActionExecution struct {
+	*GenericEvent
+	discord.GatewayEventAutoModerationActionExecution
+}
+
+// AutoModerationRule returns the discord.AutoModerationRule which was triggered from the cache.
+func (e *AutoModerationActionExecution) AutoModerationRule() (discord.AutoModerationRule, bool) {
+	return e.Client().Caches().AutoModerationRules().Get(e.GuildID, e.RuleID)
+}
+
+// Member returns the discord.Member who triggered the discord.AutoModerationRule from the cache.
+func (e *AutoModerationActionExecution) Member() (discord.Member, bool) {
+	return e.Client().Caches().Members().Get(e.GuildID, e.UserID)
+}
@@ -62,8 +62,10 @@ type ListenerAdapter struct {
 	OnStickerDelete  func(event *StickerDelete)
 
 	// gateway status Events
-	OnReady   func(event *Ready)
-	OnResumed func(event *Resumed)
+	OnReady             func(event *Ready)
+	OnResumed           func(event *Resumed)
+	OnGatewayReconnect  func(event *GatewayReconnect)
+	OnShardHealthChange func(event *ShardHealthChange)
 
 	// Guild Events
 	OnGuildJoin        func(event *GuildJoin)
@@ -113,6 +115,12 @@ type ListenerAdapter struct {
 	OnRoleUpdate func(event *RoleUpdate)
 	OnRoleDelete func(event *RoleDelete)
 
+	// Guild AutoModeration Events
+	OnAutoModerationRuleCreate      func(event *AutoModerationRuleCreate)
+	OnAutoModerationRuleUpdate      func(event *AutoModerationRuleUpdate)
+	OnAutoModerationRuleDelete      func(event *AutoModerationRuleDelete)
+	OnAutoModerationActionExecution func(event *AutoModerationActionExecution)
+
 	// Guild Scheduled Events
 	OnGuildScheduledEventCreate     func(event *GuildScheduledEventCreate)
 	OnGuildScheduledEventUpdate     func(event *GuildScheduledEventUpdate)
@@ -325,6 +333,14 @@ func (l *ListenerAdapter) OnEvent(event bot.Event) {
 		if listener := l.OnResumed; listener != nil {
 			listener(e)
 		}
+	case *GatewayReconnect:
+		if listener := l.OnGatewayReconnect; listener != nil {
+			listener(e)
+		}
+	case *ShardHealthChange:
+		if listener := l.OnShardHealthChange; listener != nil {
+			listener(e)
+		}
 
 	// Guild Events
 	case *GuildJoin:
@@ -470,6 +486,24 @@ func (l *ListenerAdapter) OnEvent(event bot.Event) {
 			listener(e)
 		}
 
+	// Guild AutoModeration Events
+	case *AutoModerationRuleCreate:
+		if listener := l.OnAutoModerationRuleCreate; listener != nil {
+			listener(e)
+		}
+	case *AutoModerationRuleUpdate:
+		if listener := l.OnAutoModerationRuleUpdate; listener != nil {
+			listener(e)
+		}
+	case *AutoModerationRuleDelete:
+		if listener := l.OnAutoModerationRuleDelete; listener != nil {
+			listener(e)
+		}
+	case *AutoModerationActionExecution:
+		if listener := l.OnAutoModerationActionExecution; listener != nil {
+			listener(e)
+		}
+
 	// Guild ScheduledEvents
 	case *GuildScheduledEventCreate:
 		if listener := l.OnGuildScheduledEventCreate; listener != nil {
@@ -0,0 +1,63 @@
+package eventstream
+
+import (
+	"time"
+
+	"github.com/disgoorg/log"
+)
+
+// DefaultConfig returns a Config with sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Logger:            log.Default(),
+		ReconnectDelay:    time.Second,
+		WriteTimeout:      10 * time.Second,
+		ConsumerQueueSize: 1024,
+	}
+}
+
+// Config lets you configure your Source or Sink instance.
+type Config struct {
+	Logger            log.Logger
+	ReconnectDelay    time.Duration
+	WriteTimeout      time.Duration
+	ConsumerQueueSize int
+}
+
+// ConfigOpt is a type alias for a function that takes a Config and is used to configure your Source or Sink.
+type ConfigOpt func(config *Config)
+
+// Apply applies the given ConfigOpt(s) to the Config
+func (c *Config) Apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithLogger sets the Logger for the Source or Sink.
+func WithLogger(logger log.Logger) ConfigOpt {
+	return func(config *Config) {
+		config.Logger = logger
+	}
+}
+
+// WithReconnectDelay sets how long a Source waits before reconnecting after its stream ended.
+func WithReconnectDelay(reconnectDelay time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.ReconnectDelay = reconnectDelay
+	}
+}
+
+// WithWriteTimeout sets how long a Sink waits for a consumer to accept an Event before dropping the consumer.
+func WithWriteTimeout(writeTimeout time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.WriteTimeout = writeTimeout
+	}
+}
+
+// WithConsumerQueueSize sets how many Event(s) a Sink queues per consumer before disconnecting it for falling behind.
+func WithConsumerQueueSize(consumerQueueSize int) ConfigOpt {
+	return func(config *Config) {
+		config.ConsumerQueueSize = consumerQueueSize
+	}
+}
@@ -0,0 +1,69 @@
+// Package eventstream is used to move raw gateway events between processes.
+// A gateway owning bot.Client publishes its events to a Sink, while stateless bot.Client(s) consume them from a Source instead of connecting to the gateway themselves.
+package eventstream
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/log"
+)
+
+// Event is a single raw gateway event as passed to a gateway.EventHandlerFunc.
+type Event struct {
+	Type     discord.GatewayEventType `json:"t"`
+	Sequence int                      `json:"s"`
+	ShardID  int                      `json:"shard_id"`
+	Payload  json.RawMessage          `json:"d"`
+}
+
+// Source provides raw gateway events from somewhere else than a gateway.Gateway or sharding.ShardManager.
+type Source interface {
+	// Logger returns the logger used by the Source.
+	Logger() log.Logger
+
+	// Open starts receiving events and passes them to the given gateway.EventHandlerFunc until the Source is closed.
+	Open(ctx context.Context, eventHandlerFunc gateway.EventHandlerFunc) error
+
+	// Close stops receiving events.
+	Close(ctx context.Context)
+}
+
+// Sink publishes raw gateway events to be consumed by a Source.
+type Sink interface {
+	// Logger returns the logger used by the Sink.
+	Logger() log.Logger
+
+	// Publish publishes the given Event.
+	Publish(event Event) error
+
+	// Close closes the Sink.
+	Close(ctx context.Context)
+}
+
+// NewSinkEventHandlerFunc returns a gateway.EventHandlerFunc which publishes every event to the given Sink before passing it to the given gateway.EventHandlerFunc.
+// The gateway.EventHandlerFunc can be nil if events should only be published.
+func NewSinkEventHandlerFunc(sink Sink, eventHandlerFunc gateway.EventHandlerFunc) gateway.EventHandlerFunc {
+	return func(gatewayEventType discord.GatewayEventType, sequenceNumber int, shardID int, payload io.Reader) {
+		data, err := io.ReadAll(payload)
+		if err != nil {
+			sink.Logger().Errorf("failed to read payload of event '%s'. error: %s", gatewayEventType, err)
+			return
+		}
+		if err = sink.Publish(Event{
+			Type:     gatewayEventType,
+			Sequence: sequenceNumber,
+			ShardID:  shardID,
+			Payload:  data,
+		}); err != nil {
+			sink.Logger().Errorf("failed to publish event '%s'. error: %s", gatewayEventType, err)
+		}
+		if eventHandlerFunc != nil {
+			eventHandlerFunc(gatewayEventType, sequenceNumber, shardID, bytes.NewReader(data))
+		}
+	}
+}
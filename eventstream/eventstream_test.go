@@ -0,0 +1,74 @@
+package eventstream
+
+import (
+	"context"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenerSinkToDialSource(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	sink := NewListenerSink(listener)
+	defer sink.Close(context.Background())
+
+	received := make(chan Event, 1)
+	source := NewDialSource("tcp", listener.Addr().String(), WithReconnectDelay(10*time.Millisecond))
+	require.NoError(t, source.Open(context.Background(), func(gatewayEventType discord.GatewayEventType, sequenceNumber int, shardID int, payload io.Reader) {
+		data, _ := io.ReadAll(payload)
+		received <- Event{Type: gatewayEventType, Sequence: sequenceNumber, ShardID: shardID, Payload: data}
+	}))
+	defer source.Close(context.Background())
+
+	expected := Event{
+		Type:     discord.GatewayEventTypeMessageCreate,
+		Sequence: 42,
+		ShardID:  3,
+		Payload:  []byte(`{"id":"1056964614286348329","content":"hello\nworld"}`),
+	}
+
+	// the consumer connection is accepted asynchronously, so publish until the event arrives
+	for {
+		require.NoError(t, sink.Publish(expected))
+		select {
+		case event := <-received:
+			assert.Equal(t, expected, event)
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestListenerSinkDisconnectsSlowConsumer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	sink := NewListenerSink(listener, WithConsumerQueueSize(1)).(*listenerSink)
+	defer sink.Close(context.Background())
+
+	consumerCount := func() int {
+		sink.mu.Lock()
+		defer sink.mu.Unlock()
+		return len(sink.consumers)
+	}
+
+	// the consumer never reads, so its writer goroutine blocks once the socket buffers are full
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	require.Eventually(t, func() bool { return consumerCount() == 1 }, time.Second, time.Millisecond)
+
+	payload := []byte(`"` + strings.Repeat("a", 64*1024) + `"`)
+	for i := 0; i < 1024 && consumerCount() > 0; i++ {
+		require.NoError(t, sink.Publish(Event{Type: discord.GatewayEventTypeMessageCreate, Sequence: i, Payload: payload}))
+	}
+	assert.Equal(t, 0, consumerCount())
+}
@@ -0,0 +1,134 @@
+package eventstream
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/log"
+)
+
+var _ Sink = (*listenerSink)(nil)
+
+// NewListenerSink creates a new Sink which accepts connections on the given net.Listener and writes every Event as a line of JSON to all of them.
+// Every consumer has its own queue, so Publish never waits for a consumer. Consumers which fall behind by more than the configured consumer queue size
+// or don't accept an Event within the configured write timeout are disconnected. Events published while no consumer is connected are dropped.
+func NewListenerSink(listener net.Listener, opts ...ConfigOpt) Sink {
+	config := DefaultConfig()
+	config.Apply(opts)
+
+	s := &listenerSink{
+		config:    *config,
+		listener:  listener,
+		consumers: map[*sinkConsumer]struct{}{},
+	}
+	go s.accept()
+	return s
+}
+
+type listenerSink struct {
+	config   Config
+	listener net.Listener
+
+	mu        sync.Mutex
+	consumers map[*sinkConsumer]struct{}
+	closed    bool
+}
+
+// sinkConsumer is a connected consumer with the queue of Event(s) its writer goroutine still has to write.
+type sinkConsumer struct {
+	conn  net.Conn
+	queue chan []byte
+}
+
+func (s *listenerSink) Logger() log.Logger {
+	return s.config.Logger
+}
+
+func (s *listenerSink) Publish(event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for consumer := range s.consumers {
+		select {
+		case consumer.queue <- data:
+		default:
+			s.Logger().Debugf("event stream consumer %s fell behind, disconnecting", consumer.conn.RemoteAddr())
+			s.removeLocked(consumer)
+		}
+	}
+	return nil
+}
+
+func (s *listenerSink) Close(_ context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	_ = s.listener.Close()
+	for consumer := range s.consumers {
+		s.removeLocked(consumer)
+	}
+}
+
+func (s *listenerSink) accept() {
+	defer s.Logger().Debug("exiting event sink accept goroutine...")
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			s.Logger().Error("failed to accept event stream consumer. error: ", err)
+			continue
+		}
+
+		consumer := &sinkConsumer{
+			conn:  conn,
+			queue: make(chan []byte, s.config.ConsumerQueueSize),
+		}
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			_ = conn.Close()
+			return
+		}
+		s.consumers[consumer] = struct{}{}
+		s.mu.Unlock()
+		s.Logger().Debug("event stream consumer connected: ", conn.RemoteAddr())
+		go s.write(consumer)
+	}
+}
+
+// write writes the queued Event(s) of the consumer until it is removed or a write fails.
+func (s *listenerSink) write(consumer *sinkConsumer) {
+	for data := range consumer.queue {
+		if s.config.WriteTimeout > 0 {
+			_ = consumer.conn.SetWriteDeadline(time.Now().Add(s.config.WriteTimeout))
+		}
+		if _, err := consumer.conn.Write(data); err != nil {
+			s.Logger().Debugf("failed to write event to %s, disconnecting. error: %s", consumer.conn.RemoteAddr(), err)
+			s.mu.Lock()
+			s.removeLocked(consumer)
+			s.mu.Unlock()
+			return
+		}
+	}
+}
+
+// removeLocked disconnects the consumer and stops its writer goroutine. It must be called with mu held.
+func (s *listenerSink) removeLocked(consumer *sinkConsumer) {
+	if _, ok := s.consumers[consumer]; !ok {
+		return
+	}
+	delete(s.consumers, consumer)
+	close(consumer.queue)
+	_ = consumer.conn.Close()
+}
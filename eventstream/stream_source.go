@@ -0,0 +1,153 @@
+package eventstream
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/log"
+)
+
+var _ Source = (*streamSource)(nil)
+
+// ErrSourceAlreadyOpen is returned when opening a Source which is already open.
+var ErrSourceAlreadyOpen = errors.New("event source is already open")
+
+// DialFunc opens a new line-delimited stream of JSON encoded Event(s).
+type DialFunc func(ctx context.Context) (io.ReadCloser, error)
+
+// NewStreamSource creates a new Source which reads line-delimited JSON encoded Event(s) from the streams opened by the given DialFunc.
+// When a stream ends, a new one is opened after the configured reconnect delay until the Source is closed.
+func NewStreamSource(dialFunc DialFunc, opts ...ConfigOpt) Source {
+	config := DefaultConfig()
+	config.Apply(opts)
+
+	return &streamSource{
+		config:   *config,
+		dialFunc: dialFunc,
+	}
+}
+
+// NewDialSource creates a new stream Source which connects to the given network address. See net.Dial for the supported networks like tcp or unix.
+func NewDialSource(network string, address string, opts ...ConfigOpt) Source {
+	var dialer net.Dialer
+	return NewStreamSource(func(ctx context.Context) (io.ReadCloser, error) {
+		return dialer.DialContext(ctx, network, address)
+	}, opts...)
+}
+
+type streamSource struct {
+	config   Config
+	dialFunc DialFunc
+
+	mu     sync.Mutex
+	stream io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (s *streamSource) Logger() log.Logger {
+	return s.config.Logger
+}
+
+func (s *streamSource) Open(ctx context.Context, eventHandlerFunc gateway.EventHandlerFunc) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return ErrSourceAlreadyOpen
+	}
+
+	stream, err := s.dialFunc(ctx)
+	if err != nil {
+		return err
+	}
+
+	listenCtx, cancel := context.WithCancel(context.Background())
+	s.stream = stream
+	s.cancel = cancel
+
+	go s.listen(listenCtx, stream, eventHandlerFunc)
+	return nil
+}
+
+func (s *streamSource) Close(_ context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.cancel = nil
+	_ = s.stream.Close()
+	s.stream = nil
+}
+
+func (s *streamSource) listen(ctx context.Context, stream io.ReadCloser, eventHandlerFunc gateway.EventHandlerFunc) {
+	defer s.Logger().Debug("exiting event source listen goroutine...")
+	for {
+		err := s.read(stream, eventHandlerFunc)
+		_ = stream.Close()
+		if ctx.Err() != nil {
+			return
+		}
+		s.Logger().Debug("event stream ended. reconnecting... error: ", err)
+
+		if stream = s.reconnect(ctx); stream == nil {
+			return
+		}
+	}
+}
+
+// reconnect opens a new stream until it succeeds or the context is cancelled.
+func (s *streamSource) reconnect(ctx context.Context) io.ReadCloser {
+	for {
+		timer := time.NewTimer(s.config.ReconnectDelay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		stream, err := s.dialFunc(ctx)
+		if err != nil {
+			s.Logger().Error("failed to reconnect event stream. error: ", err)
+			continue
+		}
+
+		s.mu.Lock()
+		// the source might have been closed while dialing
+		if ctx.Err() != nil {
+			s.mu.Unlock()
+			_ = stream.Close()
+			return nil
+		}
+		s.stream = stream
+		s.mu.Unlock()
+		return stream
+	}
+}
+
+func (s *streamSource) read(stream io.Reader, eventHandlerFunc gateway.EventHandlerFunc) error {
+	reader := bufio.NewReader(stream)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var event Event
+			if uErr := json.Unmarshal(line, &event); uErr != nil {
+				s.Logger().Error("failed to decode event from stream. error: ", uErr)
+			} else {
+				eventHandlerFunc(event.Type, event.Sequence, event.ShardID, bytes.NewReader(event.Payload))
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
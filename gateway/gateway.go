@@ -12,6 +12,35 @@ import (
 // Version defines which discord API version disgo should use to connect to discord.
 const Version = 10
 
+// Encoding is the payload encoding used by the Gateway to talk to discord.
+// See here for more information: https://discord.com/developers/docs/topics/gateway#encoding-and-compression
+type Encoding string
+
+// All Encoding(s) supported by the Gateway.
+const (
+	// EncodingJSON sends and receives payloads as JSON text frames.
+	EncodingJSON Encoding = "json"
+
+	// EncodingETF sends and receives payloads as Erlang External Term Format binary frames.
+	// Payloads are transcoded between ETF and JSON, so handlers receive the same data as with EncodingJSON.
+	// This is not a faster decoder: events are still parsed as JSON after transcoding, so EncodingETF costs slightly more CPU time than EncodingJSON.
+	// Only use it if the smaller frames matter more to you.
+	EncodingETF Encoding = "etf"
+)
+
+// TransportCompression is the compression used for the whole Gateway connection.
+// See here for more information: https://discord.com/developers/docs/topics/gateway#transport-compression
+type TransportCompression string
+
+// All TransportCompression(s) supported by the Gateway.
+const (
+	// TransportCompressionNone disables transport compression. Payload compression can still be enabled via Config.Compress.
+	TransportCompressionNone TransportCompression = ""
+
+	// TransportCompressionZlibStream compresses all payloads of a connection with one shared zlib context.
+	TransportCompressionZlibStream TransportCompression = "zlib-stream"
+)
+
 // Status is the state that the client is currently in.
 type Status int
 
@@ -51,6 +80,9 @@ const (
 	// StatusDisconnected is the state when the Gateway is disconnected.
 	// Either due to an error or because the Gateway was closed gracefully.
 	StatusDisconnected
+
+	// StatusReconnecting is the state when the Gateway lost its connection and waits for the ReconnectStrategy to reconnect.
+	StatusReconnecting
 )
 
 type (
@@ -62,6 +94,10 @@ type (
 
 	// CloseHandlerFunc is a function that is called when the Gateway is closed.
 	CloseHandlerFunc func(gateway Gateway, err error)
+
+	// ReconnectHandlerFunc is a function that is called before the Gateway tries to reconnect.
+	// try starts at 1, delay is the time the Gateway waits before connecting and err is the error of the previous failed try if any.
+	ReconnectHandlerFunc func(gateway Gateway, try int, delay time.Duration, err error)
 )
 
 // Gateway is what is used to connect to discord.
@@ -97,6 +133,11 @@ type Gateway interface {
 	// If the context is done, the Gateway connection will be killed.
 	CloseWithCode(ctx context.Context, code int, message string)
 
+	// Reconfigure applies the given ConfigOpt(s) and reconnects the Gateway with a new session,
+	// so changes to the identify payload like the intents, presence or compression take effect.
+	// It blocks until the Gateway is StatusReady again or the context is done.
+	Reconfigure(ctx context.Context, opts ...ConfigOpt) error
+
 	// Status returns the Status of the Gateway.
 	Status() Status
 
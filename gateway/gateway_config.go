@@ -1,6 +1,8 @@
 package gateway
 
 import (
+	"time"
+
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/log"
 	"github.com/gorilla/websocket"
@@ -14,6 +16,7 @@ func DefaultConfig() *Config {
 		LargeThreshold:    50,
 		GatewayIntents:    discord.GatewayIntentsDefault,
 		Compress:          true,
+		Encoding:          EncodingJSON,
 		ShardID:           0,
 		ShardCount:        1,
 		AutoReconnect:     true,
@@ -28,13 +31,19 @@ type Config struct {
 	LargeThreshold            int
 	GatewayIntents            discord.GatewayIntents
 	Compress                  bool
+	Encoding                  Encoding
+	TransportCompression      TransportCompression
 	GatewayURL                string
+	EventFilter               EventFilter
 	ShardID                   int
 	ShardCount                int
 	SessionID                 *string
 	LastSequenceReceived      *int
+	SessionStore              SessionStore
 	AutoReconnect             bool
 	MaxReconnectTries         int
+	ReconnectStrategy         ReconnectStrategy
+	ReconnectHandlerFunc      ReconnectHandlerFunc
 	RateLimiter               RateLimiter
 	RateRateLimiterConfigOpts []RateLimiterConfigOpt
 	Presence                  *discord.GatewayMessageDataPresenceUpdate
@@ -54,6 +63,9 @@ func (c *Config) Apply(opts []ConfigOpt) {
 	if c.RateLimiter == nil {
 		c.RateLimiter = NewRateLimiter(c.RateRateLimiterConfigOpts...)
 	}
+	if c.ReconnectStrategy == nil {
+		c.ReconnectStrategy = NewExponentialBackoffReconnectStrategy(time.Second, time.Minute, c.MaxReconnectTries)
+	}
 }
 
 // WithLogger sets the Logger for the Gateway.
@@ -86,6 +98,14 @@ func WithGatewayIntents(gatewayIntents ...discord.GatewayIntents) ConfigOpt {
 	}
 }
 
+// WithoutGatewayIntents removes the discord.GatewayIntents from the Gateway.
+// This is useful to drop privileged intents with Gateway.Reconfigure.
+func WithoutGatewayIntents(gatewayIntents ...discord.GatewayIntents) ConfigOpt {
+	return func(config *Config) {
+		config.GatewayIntents = config.GatewayIntents.Remove(gatewayIntents...)
+	}
+}
+
 // WithCompress sets whether this Gateway supports compression.
 // See here for more information: https://discord.com/developers/docs/topics/gateway#encoding-and-compression
 func WithCompress(compress bool) ConfigOpt {
@@ -94,6 +114,24 @@ func WithCompress(compress bool) ConfigOpt {
 	}
 }
 
+// WithTransportCompression sets the TransportCompression of the Gateway connection.
+// Payload compression via WithCompress is ignored when transport compression is enabled.
+// See here for more information: https://discord.com/developers/docs/topics/gateway#transport-compression
+func WithTransportCompression(transportCompression TransportCompression) ConfigOpt {
+	return func(config *Config) {
+		config.TransportCompression = transportCompression
+	}
+}
+
+// WithEncoding sets the Encoding the Gateway uses to send and receive payloads.
+// EncodingETF only changes the wire format and doesn't lower the CPU time spent decoding events, see EncodingETF.
+// See here for more information: https://discord.com/developers/docs/topics/gateway#encoding-and-compression
+func WithEncoding(encoding Encoding) ConfigOpt {
+	return func(config *Config) {
+		config.Encoding = encoding
+	}
+}
+
 // WithGatewayURL sets the Gateway URL for the Gateway.
 func WithGatewayURL(gatewayURL string) ConfigOpt {
 	return func(config *Config) {
@@ -101,6 +139,14 @@ func WithGatewayURL(gatewayURL string) ConfigOpt {
 	}
 }
 
+// WithEventFilter sets the EventFilter which decides which dispatches are passed to the EventHandlerFunc.
+// Filtered out dispatches are not dispatched as events.Raw. Use bot.WithGatewayEventFilter if you need those.
+func WithEventFilter(eventFilter EventFilter) ConfigOpt {
+	return func(config *Config) {
+		config.EventFilter = eventFilter
+	}
+}
+
 // WithShardID sets the shard ID for the Gateway.
 // See here for more information on sharding: https://discord.com/developers/docs/topics/gateway#sharding
 func WithShardID(shardID int) ConfigOpt {
@@ -133,6 +179,15 @@ func WithSequence(sequence int) ConfigOpt {
 	}
 }
 
+// WithSessionStore sets the SessionStore the Gateway uses to persist its session.
+// If no SessionID and sequence is set, the Gateway tries to resume the stored session of its shard.
+// With a SessionStore configured, Gateway.Close keeps the session resumable.
+func WithSessionStore(sessionStore SessionStore) ConfigOpt {
+	return func(config *Config) {
+		config.SessionStore = sessionStore
+	}
+}
+
 // WithAutoReconnect sets whether the Gateway should automatically reconnect to Discord.
 func WithAutoReconnect(autoReconnect bool) ConfigOpt {
 	return func(config *Config) {
@@ -141,12 +196,27 @@ func WithAutoReconnect(autoReconnect bool) ConfigOpt {
 }
 
 // WithMaxReconnectTries sets the maximum number of reconnect attempts before stopping.
+// This is only used by the default ReconnectStrategy.
 func WithMaxReconnectTries(maxReconnectTries int) ConfigOpt {
 	return func(config *Config) {
 		config.MaxReconnectTries = maxReconnectTries
 	}
 }
 
+// WithReconnectStrategy sets the ReconnectStrategy which decides how long the Gateway waits between reconnect tries and when it gives up.
+func WithReconnectStrategy(reconnectStrategy ReconnectStrategy) ConfigOpt {
+	return func(config *Config) {
+		config.ReconnectStrategy = reconnectStrategy
+	}
+}
+
+// WithReconnectHandlerFunc sets the ReconnectHandlerFunc which is called before every reconnect try.
+func WithReconnectHandlerFunc(reconnectHandlerFunc ReconnectHandlerFunc) ConfigOpt {
+	return func(config *Config) {
+		config.ReconnectHandlerFunc = reconnectHandlerFunc
+	}
+}
+
 // WithRateLimiter sets the grate.RateLimiter for the Gateway.
 func WithRateLimiter(rateLimiter RateLimiter) ConfigOpt {
 	return func(config *Config) {
@@ -0,0 +1,51 @@
+package gateway
+
+import "github.com/disgoorg/disgo/discord"
+
+// EventFilterAll is an EventFilter which allows all discord.GatewayEventType(s).
+func EventFilterAll(_ discord.GatewayEventType) bool { return true }
+
+// EventFilterAllow returns an EventFilter which only allows the given discord.GatewayEventType(s).
+func EventFilterAllow(gatewayEventTypes ...discord.GatewayEventType) EventFilter {
+	set := newEventTypeSet(gatewayEventTypes)
+	return func(gatewayEventType discord.GatewayEventType) bool {
+		_, ok := set[gatewayEventType]
+		return ok
+	}
+}
+
+// EventFilterDeny returns an EventFilter which allows all but the given discord.GatewayEventType(s).
+func EventFilterDeny(gatewayEventTypes ...discord.GatewayEventType) EventFilter {
+	set := newEventTypeSet(gatewayEventTypes)
+	return func(gatewayEventType discord.GatewayEventType) bool {
+		_, ok := set[gatewayEventType]
+		return !ok
+	}
+}
+
+// EventFilter decides which dispatched discord.GatewayEventType(s) are passed on to be decoded & handled.
+// Filtered out dispatches are dropped before their payload is unmarshalled. The sequence is still tracked, so resuming is not affected.
+// As they never reach the EventHandlerFunc, no events.Raw is dispatched for them either.
+type EventFilter func(gatewayEventType discord.GatewayEventType) bool
+
+// Or allows you to combine the EventFilter with another, meaning either of them needs to be true for the event to be allowed.
+func (f EventFilter) Or(filter EventFilter) EventFilter {
+	return func(gatewayEventType discord.GatewayEventType) bool {
+		return f(gatewayEventType) || filter(gatewayEventType)
+	}
+}
+
+// And allows you to require both EventFilter(s) to be true for the event to be allowed.
+func (f EventFilter) And(filter EventFilter) EventFilter {
+	return func(gatewayEventType discord.GatewayEventType) bool {
+		return f(gatewayEventType) && filter(gatewayEventType)
+	}
+}
+
+func newEventTypeSet(gatewayEventTypes []discord.GatewayEventType) map[discord.GatewayEventType]struct{} {
+	set := make(map[discord.GatewayEventType]struct{}, len(gatewayEventTypes))
+	for _, gatewayEventType := range gatewayEventTypes {
+		set[gatewayEventType] = struct{}{}
+	}
+	return set
+}
@@ -1,6 +1,7 @@
 package gateway
 
 import (
+	"bufio"
 	"bytes"
 	"compress/zlib"
 	"context"
@@ -9,9 +10,11 @@ import (
 	"io"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/internal/etf"
 	"github.com/disgoorg/disgo/internal/tokenhelper"
 	"github.com/disgoorg/disgo/json"
 	"github.com/disgoorg/log"
@@ -26,69 +29,143 @@ func New(token string, eventHandlerFunc EventHandlerFunc, closeHandlerFunc Close
 	config := DefaultConfig()
 	config.Apply(opts)
 
-	return &gatewayImpl{
-		config:           *config,
-		eventHandlerFunc: eventHandlerFunc,
-		closeHandlerFunc: closeHandlerFunc,
-		token:            token,
-		status:           StatusUnconnected,
+	g := &gatewayImpl{
+		eventHandlerFunc:     eventHandlerFunc,
+		closeHandlerFunc:     closeHandlerFunc,
+		token:                token,
+		status:               StatusUnconnected,
+		sessionID:            config.SessionID,
+		lastSequenceReceived: config.LastSequenceReceived,
 	}
+	g.currentConfig.Store(config)
+	g.loadSession()
+	return g
 }
 
 type gatewayImpl struct {
-	config           Config
+	// currentConfig holds the *Config. Reconfigure replaces it as a whole, so goroutines of the old connection keep reading a consistent Config
+	currentConfig    atomic.Value
 	eventHandlerFunc EventHandlerFunc
 	closeHandlerFunc CloseHandlerFunc
 	token            string
 
+	// connMu guards conn, status, the session & the heartbeat fields
 	conn            *websocket.Conn
 	connMu          sync.Mutex
-	heartbeatTicker *time.Ticker
+	heartbeatCancel context.CancelFunc
+	reconnectCtx    context.Context
+	reconnectCancel context.CancelFunc
 	status          Status
 
+	sessionID            *string
+	lastSequenceReceived *int
+
 	heartbeatInterval     time.Duration
 	lastHeartbeatSent     time.Time
 	lastHeartbeatReceived time.Time
 }
 
+func (g *gatewayImpl) config() *Config {
+	return g.currentConfig.Load().(*Config)
+}
+
 func (g *gatewayImpl) Logger() log.Logger {
-	return g.config.Logger
+	return g.config().Logger
 }
 
 func (g *gatewayImpl) ShardID() int {
-	return g.config.ShardID
+	return g.config().ShardID
 }
 
 func (g *gatewayImpl) ShardCount() int {
-	return g.config.ShardCount
+	return g.config().ShardCount
 }
 
 func (g *gatewayImpl) SessionID() *string {
-	return g.config.SessionID
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+	return g.sessionID
 }
 
 func (g *gatewayImpl) LastSequenceReceived() *int {
-	return g.config.LastSequenceReceived
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+	return g.lastSequenceReceived
 }
 
 func (g *gatewayImpl) GatewayIntents() discord.GatewayIntents {
-	return g.config.GatewayIntents
+	return g.config().GatewayIntents
 }
 
 func (g *gatewayImpl) formatLogsf(format string, a ...any) string {
-	if g.config.ShardCount > 1 {
-		return fmt.Sprintf("[%d/%d] %s", g.config.ShardID, g.config.ShardCount, fmt.Sprintf(format, a...))
+	config := g.config()
+	if config.ShardCount > 1 {
+		return fmt.Sprintf("[%d/%d] %s", config.ShardID, config.ShardCount, fmt.Sprintf(format, a...))
 	}
 	return fmt.Sprintf(format, a...)
 }
 
 func (g *gatewayImpl) formatLogs(a ...any) string {
-	if g.config.ShardCount > 1 {
-		return fmt.Sprintf("[%d/%d] %s", g.config.ShardID, g.config.ShardCount, fmt.Sprint(a...))
+	config := g.config()
+	if config.ShardCount > 1 {
+		return fmt.Sprintf("[%d/%d] %s", config.ShardID, config.ShardCount, fmt.Sprint(a...))
 	}
 	return fmt.Sprint(a...)
 }
 
+func (g *gatewayImpl) loadSession() {
+	config := g.config()
+	if config.SessionStore == nil || g.sessionID != nil || g.lastSequenceReceived != nil {
+		return
+	}
+	session, err := config.SessionStore.Get(config.ShardID)
+	if err != nil {
+		g.Logger().Error(g.formatLogs("failed to load session from session store. error: ", err))
+		return
+	}
+	// sessions can only be resumed with the same shard count
+	if session == nil || session.ShardCount != config.ShardCount {
+		return
+	}
+	g.Logger().Debug(g.formatLogs("loaded session from session store"))
+	g.sessionID = &session.ID
+	g.lastSequenceReceived = &session.Sequence
+}
+
+func (g *gatewayImpl) storeSession(sessionID *string, sequence *int) {
+	config := g.config()
+	if config.SessionStore == nil || sessionID == nil || sequence == nil {
+		return
+	}
+	if err := config.SessionStore.Put(config.ShardID, Session{
+		ID:         *sessionID,
+		Sequence:   *sequence,
+		ShardCount: config.ShardCount,
+	}); err != nil {
+		g.Logger().Error(g.formatLogs("failed to store session in session store. error: ", err))
+	}
+}
+
+func (g *gatewayImpl) storeSequence(sequence int) {
+	config := g.config()
+	if config.SessionStore == nil {
+		return
+	}
+	if err := config.SessionStore.UpdateSequence(config.ShardID, sequence); err != nil {
+		g.Logger().Error(g.formatLogs("failed to update sequence in session store. error: ", err))
+	}
+}
+
+func (g *gatewayImpl) deleteSession() {
+	config := g.config()
+	if config.SessionStore == nil {
+		return
+	}
+	if err := config.SessionStore.Delete(config.ShardID); err != nil {
+		g.Logger().Error(g.formatLogs("failed to delete session from session store. error: ", err))
+	}
+}
+
 func (g *gatewayImpl) Open(ctx context.Context) error {
 	g.Logger().Debug(g.formatLogs("opening gateway connection"))
 
@@ -97,13 +174,23 @@ func (g *gatewayImpl) Open(ctx context.Context) error {
 	if g.conn != nil {
 		return discord.ErrGatewayAlreadyConnected
 	}
+	// a reconnect which was cancelled by closing the Gateway while it waited for the lock must not connect anymore
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	g.status = StatusConnecting
 
-	gatewayURL := fmt.Sprintf("%s?v=%d&encoding=json", g.config.GatewayURL, Version)
+	// the connection keeps using this Config, even if Reconfigure replaces it before the connection is closed
+	config := g.config()
+	gatewayURL := fmt.Sprintf("%s?v=%d&encoding=%s", config.GatewayURL, Version, config.Encoding)
+	if config.TransportCompression != TransportCompressionNone {
+		gatewayURL += "&compress=" + string(config.TransportCompression)
+	}
 	g.lastHeartbeatSent = time.Now().UTC()
-	conn, rs, err := g.config.Dialer.DialContext(ctx, gatewayURL, nil)
+	conn, rs, err := config.Dialer.DialContext(ctx, gatewayURL, nil)
 	if err != nil {
-		g.Close(ctx)
+		// there is no connection to close yet and g.connMu is held, so don't call g.Close here
+		g.status = StatusDisconnected
 		body := "null"
 		if rs != nil && rs.Body != nil {
 			defer func() {
@@ -127,30 +214,60 @@ func (g *gatewayImpl) Open(ctx context.Context) error {
 	g.conn = conn
 
 	// reset rate limiter when connecting
-	g.config.RateLimiter.Reset()
+	config.RateLimiter.Reset()
 
 	g.status = StatusWaitingForHello
 
-	go g.listen(conn)
+	go g.listen(conn, config)
 
 	return nil
 }
 
 func (g *gatewayImpl) Close(ctx context.Context) {
-	g.CloseWithCode(ctx, websocket.CloseNormalClosure, "Shutting down")
+	g.CloseWithCode(ctx, g.shutdownCloseCode(), "Shutting down")
 }
 
-func (g *gatewayImpl) CloseWithCode(ctx context.Context, code int, message string) {
-	if g.heartbeatTicker != nil {
-		g.Logger().Debug(g.formatLogs("closing heartbeat goroutines..."))
-		g.heartbeatTicker.Stop()
-		g.heartbeatTicker = nil
+// shutdownCloseCode returns the close code used by Close.
+func (g *gatewayImpl) shutdownCloseCode() int {
+	// closing with websocket.CloseNormalClosure invalidates the session, so keep it resumable when we persist it
+	if g.config().SessionStore != nil {
+		return websocket.CloseServiceRestart
 	}
+	return websocket.CloseNormalClosure
+}
 
+func (g *gatewayImpl) CloseWithCode(ctx context.Context, code int, message string) {
+	g.closeWithCode(ctx, nil, code, message, false)
+}
+
+// closeWithCode closes the connection like CloseWithCode and starts reconnecting if reconnect is true.
+// The reconnect is started before the lock is released, so the Gateway never looks closed in between.
+// If conn is not nil, nothing happens unless conn is still the current connection. This keeps goroutines of an old connection from closing a newer one.
+func (g *gatewayImpl) closeWithCode(ctx context.Context, conn *websocket.Conn, code int, message string, reconnect bool) {
 	g.connMu.Lock()
 	defer g.connMu.Unlock()
+	if conn != nil && g.conn != conn {
+		return
+	}
+	graceful := code == websocket.CloseNormalClosure || code == websocket.CloseGoingAway
+	if g.reconnectCancel != nil {
+		g.reconnectCancel()
+		g.reconnectCtx, g.reconnectCancel = nil, nil
+		// the reconnect would have resumed the session, so it has to be cleared like the one of an open connection
+		if graceful {
+			g.sessionID = nil
+			g.lastSequenceReceived = nil
+			g.deleteSession()
+		}
+	}
+	g.status = StatusDisconnected
+	if g.heartbeatCancel != nil {
+		g.Logger().Debug(g.formatLogs("closing heartbeat goroutines..."))
+		g.heartbeatCancel()
+		g.heartbeatCancel = nil
+	}
 	if g.conn != nil {
-		g.config.RateLimiter.Close(ctx)
+		g.config().RateLimiter.Close(ctx)
 		g.Logger().Debug(g.formatLogsf("closing gateway connection with code: %d, message: %s", code, message))
 		if err := g.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, message)); err != nil && err != websocket.ErrCloseSent {
 			g.Logger().Debug(g.formatLogs("error writing close code. error: ", err))
@@ -159,12 +276,55 @@ func (g *gatewayImpl) CloseWithCode(ctx context.Context, code int, message strin
 		g.conn = nil
 
 		// clear resume data as we closed gracefully
-		if code == websocket.CloseNormalClosure || code == websocket.CloseGoingAway {
-			g.config.SessionID = nil
-			g.config.LastSequenceReceived = nil
+		if graceful {
+			g.sessionID = nil
+			g.lastSequenceReceived = nil
+			g.deleteSession()
+		} else {
+			g.storeSession(g.sessionID, g.lastSequenceReceived)
 		}
 	}
+	if reconnect {
+		g.reconnectLocked()
+	}
+}
+
+func (g *gatewayImpl) Reconfigure(ctx context.Context, opts ...ConfigOpt) error {
+	g.Logger().Debug(g.formatLogs("reconfiguring gateway"))
+	// the identify payload can only change with a new session, so don't keep the old one resumable.
+	// This also cancels a pending reconnect, and goroutines of the old connection can't reconnect or close the new one anymore.
+	g.CloseWithCode(ctx, websocket.CloseNormalClosure, "Reconfiguring")
+
+	// copy the Config, so goroutines of the old connection which are still running don't see it change
+	g.connMu.Lock()
+	config := *g.config()
+	config.Apply(opts)
+	g.currentConfig.Store(&config)
+	g.connMu.Unlock()
+
+	if err := g.Open(ctx); err != nil {
+		return err
+	}
 
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		g.connMu.Lock()
+		status, closed, reconnecting := g.status, g.conn == nil, g.reconnectCtx != nil
+		g.connMu.Unlock()
+		if status == StatusReady {
+			return nil
+		}
+		// the connection is only nil here if the Gateway was closed instead of reconnecting
+		if closed && status != StatusConnecting && !reconnecting {
+			return discord.ErrGatewayClosed
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
 }
 
 func (g *gatewayImpl) Status() Status {
@@ -181,100 +341,169 @@ func (g *gatewayImpl) Send(ctx context.Context, op discord.GatewayOpcode, d disc
 	if err != nil {
 		return err
 	}
-	return g.send(ctx, websocket.TextMessage, data)
+	g.Logger().Trace(g.formatLogs("sending gateway command: ", string(data)))
+
+	messageType := websocket.TextMessage
+	// commands are small, so transcoding them from JSON costs next to nothing
+	if g.config().Encoding == EncodingETF {
+		if data, err = etf.FromJSON(data); err != nil {
+			return err
+		}
+		messageType = websocket.BinaryMessage
+	}
+	return g.send(ctx, op, messageType, data)
 }
 
-func (g *gatewayImpl) send(ctx context.Context, messageType int, data []byte) error {
+func (g *gatewayImpl) send(ctx context.Context, op discord.GatewayOpcode, messageType int, data []byte) error {
 	g.connMu.Lock()
-	defer g.connMu.Unlock()
-	if g.conn == nil {
+	connected := g.conn != nil
+	g.connMu.Unlock()
+	if !connected {
 		return discord.ErrShardNotConnected
 	}
 
-	if err := g.config.RateLimiter.Wait(ctx); err != nil {
+	// wait without holding the connection lock, so commands with a higher priority or closing the connection are not blocked
+	rateLimiter := g.config().RateLimiter
+	if err := rateLimiter.Wait(ctx, op); err != nil {
 		return err
 	}
+	defer rateLimiter.Unlock()
 
-	defer g.config.RateLimiter.Unlock()
-	g.Logger().Trace(g.formatLogs("sending gateway command: ", string(data)))
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+	if g.conn == nil {
+		return discord.ErrShardNotConnected
+	}
 	return g.conn.WriteMessage(messageType, data)
 }
 
 func (g *gatewayImpl) Latency() time.Duration {
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
 	return g.lastHeartbeatReceived.Sub(g.lastHeartbeatSent)
 }
 
-func (g *gatewayImpl) reconnectTry(ctx context.Context, try int, delay time.Duration) error {
-	if try >= g.config.MaxReconnectTries-1 {
-		return fmt.Errorf("failed to reconnect. exceeded max reconnect tries of %d reached", g.config.MaxReconnectTries)
-	}
-	timer := time.NewTimer(time.Duration(try) * delay)
-	defer timer.Stop()
-	select {
-	case <-ctx.Done():
-		timer.Stop()
-		return ctx.Err()
-	case <-timer.C:
-	}
+func (g *gatewayImpl) setStatus(status Status) {
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+	g.status = status
+}
 
-	g.Logger().Debug(g.formatLogs("reconnecting gateway..."))
-	if err := g.Open(ctx); err != nil {
-		if err == discord.ErrGatewayAlreadyConnected {
+func (g *gatewayImpl) reconnectTry(ctx context.Context) error {
+	config := g.config()
+	var lastErr error
+	for try := 0; ; try++ {
+		delay, ok := config.ReconnectStrategy.NextDelay(try)
+		if !ok {
+			if lastErr == nil {
+				return fmt.Errorf("failed to reconnect. reconnect strategy gave up after %d tries", try)
+			}
+			return fmt.Errorf("failed to reconnect. reconnect strategy gave up after %d tries: %w", try, lastErr)
+		}
+		if config.ReconnectHandlerFunc != nil {
+			config.ReconnectHandlerFunc(g, try+1, delay, lastErr)
+		}
+		g.setStatus(StatusReconnecting)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		g.Logger().Debug(g.formatLogs("reconnecting gateway..."))
+		err := g.Open(ctx)
+		if err == nil || err == discord.ErrGatewayAlreadyConnected || ctx.Err() != nil {
 			return err
 		}
 		g.Logger().Error(g.formatLogs("failed to reconnect gateway. error: ", err))
-		g.status = StatusDisconnected
-		return g.reconnectTry(ctx, try+1, delay)
+		lastErr = err
 	}
-	return nil
 }
 
-func (g *gatewayImpl) reconnect(ctx context.Context) {
-	err := g.reconnectTry(ctx, 0, time.Second)
-	if err != nil {
-		g.Logger().Error(g.formatLogs("failed to reopen gateway", err))
-	}
+// reconnectLocked reconnects the Gateway in a new goroutine. Closing the Gateway cancels the reconnect. g.connMu must be held.
+func (g *gatewayImpl) reconnectLocked() {
+	ctx, cancel := context.WithCancel(context.Background())
+	g.reconnectCtx, g.reconnectCancel = ctx, cancel
+	g.status = StatusReconnecting
+
+	go func() {
+		defer func() {
+			g.connMu.Lock()
+			if g.reconnectCtx == ctx {
+				g.reconnectCtx, g.reconnectCancel = nil, nil
+			}
+			g.connMu.Unlock()
+			cancel()
+		}()
+		err := g.reconnectTry(ctx)
+		if err == nil || err == discord.ErrGatewayAlreadyConnected {
+			return
+		}
+		if ctx.Err() != nil {
+			g.Logger().Debug(g.formatLogs("reconnect cancelled as the gateway was closed"))
+			return
+		}
+		g.Logger().Error(g.formatLogs("failed to reopen gateway. error: ", err))
+		if g.closeHandlerFunc != nil {
+			g.closeHandlerFunc(g, err)
+		}
+	}()
 }
 
-func (g *gatewayImpl) heartbeat() {
-	g.heartbeatTicker = time.NewTicker(g.heartbeatInterval)
-	defer g.heartbeatTicker.Stop()
+// heartbeat sends a heartbeat every heartbeatInterval until ctx is cancelled by closing the connection.
+func (g *gatewayImpl) heartbeat(ctx context.Context, conn *websocket.Conn, heartbeatInterval time.Duration) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
 	defer g.Logger().Debug(g.formatLogs("exiting heartbeat goroutine..."))
 
-	for range g.heartbeatTicker.C {
-		g.sendHeartbeat()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			g.sendHeartbeat(conn)
+		}
 	}
 }
 
-func (g *gatewayImpl) sendHeartbeat() {
+func (g *gatewayImpl) sendHeartbeat(conn *websocket.Conn) {
 	g.Logger().Debug(g.formatLogs("sending heartbeat..."))
 
-	ctx, cancel := context.WithTimeout(context.Background(), g.heartbeatInterval)
+	g.connMu.Lock()
+	heartbeatInterval, lastSequenceReceived := g.heartbeatInterval, g.lastSequenceReceived
+	g.connMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), heartbeatInterval)
 	defer cancel()
-	if err := g.Send(ctx, discord.GatewayOpcodeHeartbeat, (*discord.GatewayMessageDataHeartbeat)(g.config.LastSequenceReceived)); err != nil && err != discord.ErrShardNotConnected {
+	if err := g.Send(ctx, discord.GatewayOpcodeHeartbeat, (*discord.GatewayMessageDataHeartbeat)(lastSequenceReceived)); err != nil && err != discord.ErrShardNotConnected {
 		g.Logger().Error(g.formatLogs("failed to send heartbeat. error: ", err))
-		g.CloseWithCode(context.TODO(), websocket.CloseServiceRestart, "heartbeat timeout")
-		go g.reconnect(context.TODO())
+		g.closeWithCode(context.TODO(), conn, websocket.CloseServiceRestart, "heartbeat timeout", true)
 		return
 	}
+	g.connMu.Lock()
 	g.lastHeartbeatSent = time.Now().UTC()
+	g.connMu.Unlock()
 }
 
 func (g *gatewayImpl) identify() {
-	g.status = StatusIdentifying
+	config := g.config()
+	g.setStatus(StatusIdentifying)
 	g.Logger().Debug(g.formatLogs("sending Identify command..."))
 
 	identify := discord.GatewayMessageDataIdentify{
 		Token: g.token,
 		Properties: discord.IdentifyCommandDataProperties{
-			OS:      g.config.OS,
-			Browser: g.config.Browser,
-			Device:  g.config.Device,
+			OS:      config.OS,
+			Browser: config.Browser,
+			Device:  config.Device,
 		},
-		Compress:       g.config.Compress,
-		LargeThreshold: g.config.LargeThreshold,
-		GatewayIntents: g.config.GatewayIntents,
-		Presence:       g.config.Presence,
+		Compress:       config.Compress && config.TransportCompression == TransportCompressionNone,
+		LargeThreshold: config.LargeThreshold,
+		GatewayIntents: config.GatewayIntents,
+		Presence:       config.Presence,
 	}
 	if g.ShardCount() > 1 {
 		identify.Shard = &[2]int{g.ShardID(), g.ShardCount()}
@@ -283,15 +512,15 @@ func (g *gatewayImpl) identify() {
 	if err := g.Send(context.TODO(), discord.GatewayOpcodeIdentify, identify); err != nil {
 		g.Logger().Error(g.formatLogs("error sending Identify command err: ", err))
 	}
-	g.status = StatusWaitingForReady
+	g.setStatus(StatusWaitingForReady)
 }
 
-func (g *gatewayImpl) resume() {
-	g.status = StatusResuming
+func (g *gatewayImpl) resume(sessionID string, sequence int) {
+	g.setStatus(StatusResuming)
 	resume := discord.GatewayMessageDataResume{
 		Token:     g.token,
-		SessionID: *g.config.SessionID,
-		Seq:       *g.config.LastSequenceReceived,
+		SessionID: sessionID,
+		Seq:       sequence,
 	}
 
 	g.Logger().Debug(g.formatLogs("sending Resume command..."))
@@ -300,8 +529,15 @@ func (g *gatewayImpl) resume() {
 	}
 }
 
-func (g *gatewayImpl) listen(conn *websocket.Conn) {
+func (g *gatewayImpl) listen(conn *websocket.Conn, config *Config) {
 	defer g.Logger().Debug(g.formatLogs("exiting listen goroutine..."))
+
+	// each connection has its own zlib-stream context
+	var inflater *zlibStreamInflater
+	if config.TransportCompression == TransportCompressionZlibStream {
+		inflater = newZlibStreamInflater()
+		defer inflater.close()
+	}
 loop:
 	for {
 		mt, reader, err := conn.NextReader()
@@ -327,13 +563,16 @@ loop:
 					} else {
 						intentsURL = "https://discord.com/developers/applications"
 					}
-					g.Logger().Error(g.formatLogsf("disallowed gateway intents supplied. go to %s and enable the privileged intent for your application. intents: %d", intentsURL, g.config.GatewayIntents))
+					g.Logger().Error(g.formatLogsf("disallowed gateway intents supplied. go to %s and enable the privileged intent for your application. intents: %d", intentsURL, config.GatewayIntents))
 				} else if closeCode == discord.GatewayCloseEventCodeInvalidSeq {
 					g.Logger().Error(g.formatLogs("invalid sequence provided. reconnecting..."))
-					g.config.LastSequenceReceived = nil
-					g.config.SessionID = nil
+					g.connMu.Lock()
+					g.lastSequenceReceived = nil
+					g.sessionID = nil
+					g.connMu.Unlock()
+					g.deleteSession()
 				} else {
-					g.Logger().Error(g.formatLogsf("gateway close received, reconnect: %t, code: %d, error: %s", g.config.AutoReconnect && reconnect, closeError.Code, closeError.Text))
+					g.Logger().Error(g.formatLogsf("gateway close received, reconnect: %t, code: %d, error: %s", config.AutoReconnect && reconnect, closeError.Code, closeError.Text))
 				}
 			} else if errors.Is(err, net.ErrClosed) {
 				// we closed the connection ourselves. Don't try to reconnect here
@@ -342,10 +581,11 @@ loop:
 				g.Logger().Debug(g.formatLogs("failed to read next message from gateway. error: ", err))
 			}
 
-			if g.config.AutoReconnect && reconnect {
-				go g.reconnect(context.TODO())
+			if config.AutoReconnect && reconnect {
+				// release the broken connection, so it can be opened again
+				g.closeWithCode(context.TODO(), conn, websocket.CloseServiceRestart, "reconnecting", true)
 			} else {
-				g.Close(context.TODO())
+				g.closeWithCode(context.TODO(), conn, g.shutdownCloseCode(), "Shutting down", false)
 				if g.closeHandlerFunc != nil {
 					go g.closeHandlerFunc(g, err)
 				}
@@ -353,23 +593,42 @@ loop:
 			break loop
 		}
 
-		event, err := g.parseGatewayMessage(mt, reader)
+		event, err := g.parseGatewayMessage(config, mt, reader, inflater)
 		if err != nil {
 			g.Logger().Error(g.formatLogs("error while parsing gateway event. error: ", err))
 			continue
 		}
+		// the message is split over multiple frames
+		if event == nil {
+			continue
+		}
 
 		switch event.Op {
 		case discord.GatewayOpcodeHello:
+			heartbeatInterval := time.Duration(event.D.(discord.GatewayMessageDataHello).HeartbeatInterval) * time.Millisecond
+
+			g.connMu.Lock()
+			// the connection was closed while the message was read, don't start heartbeating for it
+			if g.conn != conn {
+				g.connMu.Unlock()
+				return
+			}
 			g.lastHeartbeatReceived = time.Now().UTC()
-			go g.heartbeat()
+			g.heartbeatInterval = heartbeatInterval
+			if g.heartbeatCancel != nil {
+				g.heartbeatCancel()
+			}
+			heartbeatCtx, heartbeatCancel := context.WithCancel(context.Background())
+			g.heartbeatCancel = heartbeatCancel
+			sessionID, lastSequenceReceived := g.sessionID, g.lastSequenceReceived
+			g.connMu.Unlock()
 
-			g.heartbeatInterval = time.Duration(event.D.(discord.GatewayMessageDataHello).HeartbeatInterval) * time.Millisecond
+			go g.heartbeat(heartbeatCtx, conn, heartbeatInterval)
 
-			if g.config.LastSequenceReceived == nil || g.config.SessionID == nil {
+			if sessionID == nil || lastSequenceReceived == nil {
 				g.identify()
 			} else {
-				g.resume()
+				g.resume(*sessionID, *lastSequenceReceived)
 			}
 
 		case discord.GatewayOpcodeDispatch:
@@ -377,7 +636,9 @@ loop:
 			g.Logger().Trace(g.formatLogsf("received: OpcodeDispatch %s, data: %s", event.T, string(data)))
 
 			// set last sequence received
-			g.config.LastSequenceReceived = &event.S
+			g.connMu.Lock()
+			g.lastSequenceReceived = &event.S
+			g.connMu.Unlock()
 
 			// get session id here
 			if event.T == discord.GatewayEventTypeReady {
@@ -386,22 +647,35 @@ loop:
 					g.Logger().Error(g.formatLogs("Error parsing ready event. error: ", err))
 					continue
 				}
-				g.config.SessionID = &readyEvent.SessionID
+				g.connMu.Lock()
+				g.sessionID = &readyEvent.SessionID
 				g.status = StatusReady
+				g.connMu.Unlock()
 				g.Logger().Debug(g.formatLogs("ready event received"))
+				g.storeSession(&readyEvent.SessionID, &event.S)
+			} else if event.T == discord.GatewayEventTypeResumed {
+				g.setStatus(StatusReady)
+				g.Logger().Debug(g.formatLogs("resumed event received"))
+				g.storeSequence(event.S)
+			} else {
+				g.storeSequence(event.S)
+			}
+
+			if config.EventFilter != nil && !config.EventFilter(event.T) {
+				g.Logger().Trace(g.formatLogsf("skipping filtered event: %s", event.T))
+				continue
 			}
 
 			// push event to the command manager
-			g.eventHandlerFunc(event.T, event.S, g.config.ShardID, bytes.NewBuffer(data))
+			g.eventHandlerFunc(event.T, event.S, config.ShardID, bytes.NewBuffer(data))
 
 		case discord.GatewayOpcodeHeartbeat:
 			g.Logger().Debug(g.formatLogs("received: OpcodeHeartbeat"))
-			g.sendHeartbeat()
+			g.sendHeartbeat(conn)
 
 		case discord.GatewayOpcodeReconnect:
 			g.Logger().Debug(g.formatLogs("received: OpcodeReconnect"))
-			g.CloseWithCode(context.TODO(), websocket.CloseServiceRestart, "received reconnect")
-			go g.reconnect(context.TODO())
+			g.closeWithCode(context.TODO(), conn, websocket.CloseServiceRestart, "received reconnect", true)
 			break loop
 
 		case discord.GatewayOpcodeInvalidSession:
@@ -413,41 +687,105 @@ loop:
 				code = websocket.CloseServiceRestart
 			} else {
 				// clear resume info
-				g.config.SessionID = nil
-				g.config.LastSequenceReceived = nil
+				g.connMu.Lock()
+				g.sessionID = nil
+				g.lastSequenceReceived = nil
+				g.connMu.Unlock()
 			}
 
-			g.CloseWithCode(context.TODO(), code, "invalid session")
-			go g.reconnect(context.TODO())
+			g.closeWithCode(context.TODO(), conn, code, "invalid session", true)
 			break loop
 
 		case discord.GatewayOpcodeHeartbeatACK:
 			g.Logger().Debug(g.formatLogs("received: OpcodeHeartbeatACK"))
+			g.connMu.Lock()
 			g.lastHeartbeatReceived = time.Now().UTC()
+			g.connMu.Unlock()
 		}
 	}
 }
 
-func (g *gatewayImpl) parseGatewayMessage(mt int, reader io.Reader) (discord.GatewayMessage, error) {
-	var finalReadCloser io.ReadCloser
+func (g *gatewayImpl) parseGatewayMessage(config *Config, mt int, reader io.Reader, inflater *zlibStreamInflater) (*discord.GatewayMessage, error) {
 	if mt == websocket.BinaryMessage {
-		g.Logger().Trace(g.formatLogs("binary message received. decompressing..."))
-		readCloser, err := zlib.NewReader(reader)
-		if err != nil {
-			return discord.GatewayMessage{}, fmt.Errorf("failed to decompress zlib: %w", err)
+		if config.TransportCompression == TransportCompressionZlibStream {
+			data, err := inflater.inflate(reader)
+			if err != nil {
+				return nil, fmt.Errorf("failed to inflate zlib-stream: %w", err)
+			}
+			if data == nil {
+				return nil, nil
+			}
+			reader = bytes.NewReader(data)
+		} else {
+			bufReader := bufio.NewReader(reader)
+			// etf payloads are always binary, only compressed payloads don't start with the etf version byte
+			if b, err := bufReader.Peek(1); config.Encoding != EncodingETF || (err == nil && b[0] != etf.Version) {
+				g.Logger().Trace(g.formatLogs("binary message received. decompressing..."))
+				readCloser, err := zlib.NewReader(bufReader)
+				if err != nil {
+					return nil, fmt.Errorf("failed to decompress zlib: %w", err)
+				}
+				defer func() {
+					_ = readCloser.Close()
+				}()
+				reader = readCloser
+			} else {
+				reader = bufReader
+			}
 		}
-		finalReadCloser = readCloser
-	} else {
-		finalReadCloser = io.NopCloser(reader)
 	}
-	defer func() {
-		_ = finalReadCloser.Close()
-	}()
 
 	var message discord.GatewayMessage
-	if err := json.NewDecoder(finalReadCloser).Decode(&message); err != nil {
+	if config.Encoding == EncodingETF {
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+		if message, err = parseETFGatewayMessage(data); err != nil {
+			g.Logger().Error(g.formatLogs("error decoding etf websocket message: ", err))
+			return nil, err
+		}
+		return &message, nil
+	}
+
+	if err := json.NewDecoder(reader).Decode(&message); err != nil {
 		g.Logger().Error(g.formatLogs("error decoding websocket message: ", err))
-		return discord.GatewayMessage{}, err
+		return nil, err
+	}
+	return &message, nil
+}
+
+// parseETFGatewayMessage decodes the envelope of an ETF gateway message natively and only transcodes the d field to JSON.
+// Dispatch payloads are passed on as JSON without being parsed again, the small payloads of other op codes are unmarshalled like with EncodingJSON.
+func parseETFGatewayMessage(data []byte) (discord.GatewayMessage, error) {
+	var message discord.GatewayMessage
+	fields, err := etf.ToJSONObject(data)
+	if err != nil {
+		return message, err
+	}
+	if err = json.Unmarshal(fields["op"], &message.Op); err != nil {
+		return message, err
+	}
+
+	if message.Op != discord.GatewayOpcodeDispatch {
+		rawJSON, err := json.Marshal(fields)
+		if err != nil {
+			return message, err
+		}
+		err = json.Unmarshal(rawJSON, &message)
+		return message, err
+	}
+
+	if s, ok := fields["s"]; ok && string(s) != "null" {
+		if err = json.Unmarshal(s, &message.S); err != nil {
+			return message, err
+		}
+	}
+	if t, ok := fields["t"]; ok && string(t) != "null" {
+		if err = json.Unmarshal(t, &message.T); err != nil {
+			return message, err
+		}
 	}
+	message.D = discord.GatewayMessageDataDispatch(fields["d"])
 	return message, nil
 }
@@ -3,25 +3,50 @@ package gateway
 import (
 	"context"
 
+	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/log"
 )
 
 // RateLimiter provides handles the rate limiting logic for connecting to Discord's Gateway.
+// Commands waiting for the RateLimiter are queued by priority. See CommandPriority for more information.
 type RateLimiter interface {
 	// Logger returns the logger used by the RateLimiter.
 	Logger() log.Logger
 
-	// Close gracefully closes the RateLimiter.
+	// Close closes the RateLimiter and aborts all waiting commands with discord.ErrShardNotConnected.
 	// If the context deadline is exceeded, the RateLimiter will be closed immediately.
 	Close(ctx context.Context)
 
 	// Reset resets the RateLimiter to its initial state.
 	Reset()
 
-	// Wait waits for the RateLimiter to be ready to send a new message.
-	// If the context deadline is exceeded, Wait will return immediately and no message will be sent.
-	Wait(ctx context.Context) error
+	// Wait waits for the RateLimiter to be ready to send a new message with the given discord.GatewayOpcode.
+	// If the context deadline is exceeded or is shorter than the expected wait, Wait will return immediately and no message will be sent.
+	Wait(ctx context.Context, op discord.GatewayOpcode) error
 
 	// Unlock unlocks the RateLimiter and allows the next message to be sent.
 	Unlock()
 }
+
+// CommandPriority is the priority of a gateway command in the RateLimiter queue.
+type CommandPriority int
+
+// All CommandPriority(s) used by the RateLimiter.
+const (
+	// CommandPriorityNormal is used for all commands which are not CommandPriorityHigh.
+	CommandPriorityNormal CommandPriority = iota
+
+	// CommandPriorityHigh is used for commands which keep the connection alive.
+	// They are always sent before commands with CommandPriorityNormal and can use commands reserved in the rate limit.
+	CommandPriorityHigh
+)
+
+// CommandPriorityOf returns the CommandPriority of the given discord.GatewayOpcode.
+func CommandPriorityOf(op discord.GatewayOpcode) CommandPriority {
+	switch op {
+	case discord.GatewayOpcodeHeartbeat, discord.GatewayOpcodeIdentify, discord.GatewayOpcodeResume:
+		return CommandPriorityHigh
+	default:
+		return CommandPriorityNormal
+	}
+}
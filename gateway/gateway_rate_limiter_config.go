@@ -7,15 +7,19 @@ import (
 // DefaultRateLimiterConfig returns a RateLimiterConfig with sensible defaults.
 func DefaultRateLimiterConfig() *RateLimiterConfig {
 	return &RateLimiterConfig{
-		Logger:            log.Default(),
-		CommandsPerMinute: 120,
+		Logger:                   log.Default(),
+		CommandsPerMinute:        120,
+		ReservedCommands:         5,
+		PresenceUpdatesPerMinute: 5,
 	}
 }
 
 // RateLimiterConfig lets you configure your Gateway instance.
 type RateLimiterConfig struct {
-	Logger            log.Logger
-	CommandsPerMinute int
+	Logger                   log.Logger
+	CommandsPerMinute        int
+	ReservedCommands         int
+	PresenceUpdatesPerMinute int
 }
 
 // RateLimiterConfigOpt is a type alias for a function that takes a RateLimiterConfig and is used to configure your Server.
@@ -41,3 +45,19 @@ func WithCommandsPerMinute(commandsPerMinute int) RateLimiterConfigOpt {
 		config.CommandsPerMinute = commandsPerMinute
 	}
 }
+
+// WithReservedCommands sets the number of commands per minute which are reserved for commands with CommandPriorityHigh.
+// This makes sure heartbeats can always be sent, even after a burst of other commands.
+func WithReservedCommands(reservedCommands int) RateLimiterConfigOpt {
+	return func(config *RateLimiterConfig) {
+		config.ReservedCommands = reservedCommands
+	}
+}
+
+// WithPresenceUpdatesPerMinute sets the number of discord.GatewayOpcodePresenceUpdate commands per minute that the Gateway will allow.
+// Presence updates also count towards the commands per minute.
+func WithPresenceUpdatesPerMinute(presenceUpdatesPerMinute int) RateLimiterConfigOpt {
+	return func(config *RateLimiterConfig) {
+		config.PresenceUpdatesPerMinute = presenceUpdatesPerMinute
+	}
+}
@@ -2,81 +2,269 @@ package gateway
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
+	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/log"
-	"github.com/sasha-s/go-csync"
 )
 
+var _ RateLimiter = (*rateLimiterImpl)(nil)
+
 // NewRateLimiter creates a new default RateLimiter with the given RateLimiterConfigOpt(s).
 func NewRateLimiter(opts ...RateLimiterConfigOpt) RateLimiter {
 	config := DefaultRateLimiterConfig()
 	config.Apply(opts)
 
 	return &rateLimiterImpl{
-		config: *config,
+		config:  *config,
+		changed: make(chan struct{}),
 	}
 }
 
 type rateLimiterImpl struct {
-	mu csync.Mutex
+	mu sync.Mutex
+
+	// queue is sorted by priority and then by insertion order
+	queue []*queuedCommand
+	// changed is closed & replaced every time a queued command might be able to be sent
+	changed chan struct{}
+	sending bool
+	closed  bool
 
 	reset     time.Time
 	remaining int
 
+	presenceReset     time.Time
+	presenceRemaining int
+
 	config RateLimiterConfig
 }
 
+type queuedCommand struct {
+	priority CommandPriority
+	presence bool
+}
+
 func (l *rateLimiterImpl) Logger() log.Logger {
 	return l.config.Logger
 }
 
-func (l *rateLimiterImpl) Close(ctx context.Context) {
-	_ = l.mu.CLock(ctx)
+func (l *rateLimiterImpl) Close(_ context.Context) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closed = true
+	l.notify()
 }
 
 func (l *rateLimiterImpl) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	l.reset = time.Time{}
 	l.remaining = 0
-	l.mu = csync.Mutex{}
+	l.presenceReset = time.Time{}
+	l.presenceRemaining = 0
+	l.sending = false
+	l.closed = false
+	l.notify()
 }
 
-func (l *rateLimiterImpl) Wait(ctx context.Context) error {
+func (l *rateLimiterImpl) Wait(ctx context.Context, op discord.GatewayOpcode) error {
 	l.Logger().Trace("locking gateway rate limiter")
-	if err := l.mu.CLock(ctx); err != nil {
-		return err
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return discord.ErrShardNotConnected
 	}
 
-	now := time.Now()
+	cmd := &queuedCommand{
+		priority: CommandPriorityOf(op),
+		presence: op == discord.GatewayOpcodePresenceUpdate,
+	}
+	l.enqueue(cmd)
 
-	var until time.Time
+	for {
+		now := time.Now()
+		l.refill(now)
 
-	if l.remaining == 0 && l.reset.After(now) {
-		until = l.reset
-	}
+		if l.closed {
+			l.dequeue(cmd)
+			l.mu.Unlock()
+			return discord.ErrShardNotConnected
+		}
+
+		if l.next() == cmd {
+			l.dequeue(cmd)
+			l.sending = true
+			l.remaining--
+			if cmd.presence {
+				l.presenceRemaining--
+			}
+			l.mu.Unlock()
+			return nil
+		}
 
-	if until.After(now) {
-		// TODO: do we want to return early when we know rate limit bigger than ctx deadline?
+		until := l.expectedSendTime(cmd, now)
 		if deadline, ok := ctx.Deadline(); ok && until.After(deadline) {
-			return context.DeadlineExceeded
+			l.dequeue(cmd)
+			l.notify()
+			l.mu.Unlock()
+			return fmt.Errorf("expected gateway rate limit wait of %s exceeds context deadline: %w", until.Sub(now), context.DeadlineExceeded)
+		}
+
+		changed := l.changed
+		wakeUp := l.nextReset(now)
+		l.mu.Unlock()
+
+		var timer *time.Timer
+		var timerC <-chan time.Time
+		if !wakeUp.IsZero() {
+			timer = time.NewTimer(wakeUp.Sub(now))
+			timerC = timer.C
 		}
 
 		select {
 		case <-ctx.Done():
-			l.Unlock()
+			if timer != nil {
+				timer.Stop()
+			}
+			l.mu.Lock()
+			l.dequeue(cmd)
+			l.notify()
+			l.mu.Unlock()
 			return ctx.Err()
-		case <-time.After(until.Sub(now)):
+		case <-changed:
+		case <-timerC:
+		}
+		if timer != nil {
+			timer.Stop()
 		}
+		l.mu.Lock()
 	}
-	return nil
 }
 
 func (l *rateLimiterImpl) Unlock() {
 	l.Logger().Trace("unlocking gateway rate limiter")
-	now := time.Now()
-	if l.reset.Before(now) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sending = false
+	l.notify()
+}
+
+// notify wakes up all waiting commands. It must be called with the mutex held.
+func (l *rateLimiterImpl) notify() {
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+func (l *rateLimiterImpl) enqueue(cmd *queuedCommand) {
+	i := len(l.queue)
+	for i > 0 && l.queue[i-1].priority < cmd.priority {
+		i--
+	}
+	l.queue = append(l.queue, nil)
+	copy(l.queue[i+1:], l.queue[i:])
+	l.queue[i] = cmd
+}
+
+func (l *rateLimiterImpl) dequeue(cmd *queuedCommand) {
+	for i, c := range l.queue {
+		if c == cmd {
+			l.queue = append(l.queue[:i], l.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+func (l *rateLimiterImpl) refill(now time.Time) {
+	if !l.reset.After(now) {
 		l.reset = now.Add(time.Minute)
 		l.remaining = l.config.CommandsPerMinute
 	}
-	l.mu.Unlock()
+	if !l.presenceReset.After(now) {
+		l.presenceReset = now.Add(time.Minute)
+		l.presenceRemaining = l.config.PresenceUpdatesPerMinute
+	}
+}
+
+// available returns how many commands of the given kind can currently be sent.
+func (l *rateLimiterImpl) available(cmd *queuedCommand) int {
+	available := l.remaining
+	if cmd.priority < CommandPriorityHigh {
+		available -= l.config.ReservedCommands
+	}
+	if cmd.presence && l.presenceRemaining < available {
+		available = l.presenceRemaining
+	}
+	return available
+}
+
+// next returns the queued command which is allowed to be sent next or nil if none is.
+// Presence updates which exceeded their own limit are skipped, so they don't block other commands.
+func (l *rateLimiterImpl) next() *queuedCommand {
+	if l.sending {
+		return nil
+	}
+	for _, cmd := range l.queue {
+		if l.available(cmd) <= 0 {
+			if cmd.presence {
+				continue
+			}
+			return nil
+		}
+		return cmd
+	}
+	return nil
+}
+
+// expectedSendTime estimates when the given queued command will be sent based on the commands queued before it.
+func (l *rateLimiterImpl) expectedSendTime(cmd *queuedCommand, now time.Time) time.Time {
+	var ahead, presenceAhead int
+	for _, c := range l.queue {
+		if c == cmd {
+			break
+		}
+		ahead++
+		if c.presence {
+			presenceAhead++
+		}
+	}
+
+	perMinute := l.config.CommandsPerMinute
+	if cmd.priority < CommandPriorityHigh {
+		perMinute -= l.config.ReservedCommands
+	}
+	until := windowSendTime(ahead, l.available(&queuedCommand{priority: cmd.priority}), perMinute, l.reset, now)
+	if cmd.presence {
+		if presenceUntil := windowSendTime(presenceAhead, l.presenceRemaining, l.config.PresenceUpdatesPerMinute, l.presenceReset, now); presenceUntil.After(until) {
+			until = presenceUntil
+		}
+	}
+	return until
+}
+
+// windowSendTime returns when a command with the given number of commands ahead can be sent in a fixed window rate limit.
+func windowSendTime(ahead int, available int, perWindow int, reset time.Time, now time.Time) time.Time {
+	if ahead < available {
+		return now
+	}
+	if perWindow <= 0 {
+		// the command can never be sent
+		return now.Add(time.Duration(1<<63 - 1))
+	}
+	windows := (ahead-available)/perWindow + 1
+	return reset.Add(time.Duration(windows-1) * time.Minute)
+}
+
+// nextReset returns the next time a rate limit window resets.
+func (l *rateLimiterImpl) nextReset(now time.Time) time.Time {
+	reset := l.reset
+	if l.presenceReset.After(now) && l.presenceReset.Before(reset) {
+		reset = l.presenceReset
+	}
+	if !reset.After(now) {
+		return time.Time{}
+	}
+	return reset
 }
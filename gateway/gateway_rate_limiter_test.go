@@ -0,0 +1,65 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterReservesHighPriorityCommands(t *testing.T) {
+	limiter := NewRateLimiter(WithCommandsPerMinute(3), WithReservedCommands(1))
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, limiter.Wait(context.Background(), discord.GatewayOpcodeRequestGuildMembers))
+		limiter.Unlock()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := limiter.Wait(ctx, discord.GatewayOpcodeRequestGuildMembers)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	assert.NoError(t, limiter.Wait(context.Background(), discord.GatewayOpcodeHeartbeat))
+	limiter.Unlock()
+}
+
+func TestRateLimiterSendsHighPriorityFirst(t *testing.T) {
+	limiter := NewRateLimiter()
+
+	// hold the limiter so the following commands get queued
+	assert.NoError(t, limiter.Wait(context.Background(), discord.GatewayOpcodePresenceUpdate))
+
+	order := make(chan discord.GatewayOpcode, 2)
+	wait := func(op discord.GatewayOpcode) {
+		if err := limiter.Wait(context.Background(), op); err == nil {
+			order <- op
+			limiter.Unlock()
+		}
+	}
+	go wait(discord.GatewayOpcodeRequestGuildMembers)
+	time.Sleep(50 * time.Millisecond)
+	go wait(discord.GatewayOpcodeHeartbeat)
+	time.Sleep(50 * time.Millisecond)
+
+	limiter.Unlock()
+	assert.Equal(t, discord.GatewayOpcodeHeartbeat, <-order)
+	assert.Equal(t, discord.GatewayOpcodeRequestGuildMembers, <-order)
+}
+
+func TestRateLimiterCloseAbortsWaiting(t *testing.T) {
+	limiter := NewRateLimiter()
+	assert.NoError(t, limiter.Wait(context.Background(), discord.GatewayOpcodeHeartbeat))
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- limiter.Wait(context.Background(), discord.GatewayOpcodeHeartbeat)
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	limiter.Close(context.Background())
+	assert.Equal(t, discord.ErrShardNotConnected, <-errs)
+}
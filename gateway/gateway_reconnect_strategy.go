@@ -0,0 +1,56 @@
+package gateway
+
+import (
+	"math/rand"
+	"time"
+)
+
+// ReconnectStrategy decides how long the Gateway waits before trying to reconnect and when it gives up.
+type ReconnectStrategy interface {
+	// NextDelay returns how long to wait before the given reconnect try and whether it should be attempted at all.
+	// try starts at 0 for the first reconnect after the connection was lost and is increased for every failed try.
+	NextDelay(try int) (time.Duration, bool)
+}
+
+var _ ReconnectStrategy = (*exponentialBackoffReconnectStrategy)(nil)
+
+// NewExponentialBackoffReconnectStrategy returns a ReconnectStrategy which waits a random duration between 0 and baseDelay * 2^try, capped at maxDelay.
+// The random "full jitter" spreads out reconnects of many Gateway(s) which lost their connection at the same time.
+// It gives up after maxTries tries. If maxTries is 0 or less it never gives up.
+func NewExponentialBackoffReconnectStrategy(baseDelay time.Duration, maxDelay time.Duration, maxTries int) ReconnectStrategy {
+	return &exponentialBackoffReconnectStrategy{
+		baseDelay: baseDelay,
+		maxDelay:  maxDelay,
+		maxTries:  maxTries,
+	}
+}
+
+// NewRetryForeverReconnectStrategy returns a ReconnectStrategy which never gives up and uses exponential backoff with full jitter between baseDelay and maxDelay.
+// See NewExponentialBackoffReconnectStrategy for more information.
+func NewRetryForeverReconnectStrategy(baseDelay time.Duration, maxDelay time.Duration) ReconnectStrategy {
+	return NewExponentialBackoffReconnectStrategy(baseDelay, maxDelay, 0)
+}
+
+type exponentialBackoffReconnectStrategy struct {
+	baseDelay time.Duration
+	maxDelay  time.Duration
+	maxTries  int
+}
+
+func (s *exponentialBackoffReconnectStrategy) NextDelay(try int) (time.Duration, bool) {
+	if s.maxTries > 0 && try >= s.maxTries {
+		return 0, false
+	}
+
+	delay := s.maxDelay
+	// avoid overflowing the shift for high tries, the delay is capped at maxDelay anyway
+	if try < 32 {
+		if backoff := s.baseDelay << try; backoff > 0 && backoff < s.maxDelay {
+			delay = backoff
+		}
+	}
+	if delay <= 0 {
+		return 0, true
+	}
+	return time.Duration(rand.Int63n(int64(delay) + 1)), true
+}
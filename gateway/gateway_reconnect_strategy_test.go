@@ -0,0 +1,35 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponentialBackoffReconnectStrategy(t *testing.T) {
+	strategy := NewExponentialBackoffReconnectStrategy(time.Second, 10*time.Second, 5)
+
+	for try := 0; try < 5; try++ {
+		delay, ok := strategy.NextDelay(try)
+		assert.True(t, ok)
+		assert.GreaterOrEqual(t, delay, time.Duration(0))
+
+		maxDelay := time.Second << try
+		if maxDelay > 10*time.Second {
+			maxDelay = 10 * time.Second
+		}
+		assert.LessOrEqual(t, delay, maxDelay)
+	}
+
+	_, ok := strategy.NextDelay(5)
+	assert.False(t, ok)
+}
+
+func TestRetryForeverReconnectStrategy(t *testing.T) {
+	strategy := NewRetryForeverReconnectStrategy(time.Second, time.Minute)
+
+	delay, ok := strategy.NextDelay(1000)
+	assert.True(t, ok)
+	assert.LessOrEqual(t, delay, time.Minute)
+}
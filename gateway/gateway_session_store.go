@@ -0,0 +1,26 @@
+package gateway
+
+// Session holds the information needed to resume a Gateway session.
+type Session struct {
+	ID         string `json:"id"`
+	Sequence   int    `json:"sequence"`
+	ShardCount int    `json:"shard_count"`
+}
+
+// SessionStore persists the Session(s) of Gateway(s), so they can be resumed after a process restart instead of identifying again.
+type SessionStore interface {
+	// Get returns the Session stored for the given shard ID or nil if none is stored.
+	Get(shardID int) (*Session, error)
+
+	// Put stores the Session for the given shard ID.
+	// This is called when a discord.GatewayEventTypeReady is received and when the Gateway is closed.
+	Put(shardID int, session Session) error
+
+	// UpdateSequence updates the sequence of the stored Session for the given shard ID.
+	// This is called for every dispatch received, so implementations should make this cheap, for example by batching writes.
+	UpdateSequence(shardID int, sequence int) error
+
+	// Delete removes the Session for the given shard ID.
+	// This is called when the Session can no longer be resumed.
+	Delete(shardID int) error
+}
@@ -0,0 +1,136 @@
+package gateway
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/json"
+)
+
+var _ SessionStore = (*fileSessionStore)(nil)
+
+// NewFileSessionStore returns a new SessionStore which persists all Session(s) as JSON in the file at the given path.
+// The file is created if it does not exist yet. It is safe to share one file backed SessionStore between multiple Gateway(s).
+func NewFileSessionStore(path string, opts ...FileSessionStoreConfigOpt) SessionStore {
+	config := DefaultFileSessionStoreConfig()
+	config.Apply(opts)
+
+	return &fileSessionStore{
+		path:   path,
+		config: *config,
+	}
+}
+
+type fileSessionStore struct {
+	path   string
+	config FileSessionStoreConfig
+
+	mu         sync.Mutex
+	sessions   map[int]Session
+	flushTimer *time.Timer
+}
+
+func (s *fileSessionStore) load() error {
+	if s.sessions != nil {
+		return nil
+	}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		s.sessions = map[int]Session{}
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	sessions := map[int]Session{}
+	if err = json.Unmarshal(data, &sessions); err != nil {
+		// a corrupt file only costs the sessions a resume, it must not break the SessionStore. The next write replaces the file
+		s.config.Logger.Error("failed to read gateway sessions from file, starting without sessions: ", err)
+		sessions = map[int]Session{}
+	}
+	s.sessions = sessions
+	return nil
+}
+
+func (s *fileSessionStore) write() error {
+	if s.flushTimer != nil {
+		s.flushTimer.Stop()
+		s.flushTimer = nil
+	}
+	data, err := json.Marshal(s.sessions)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so we never leave a half written file behind
+	tmpPath := s.path + ".tmp"
+	if err = os.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, s.path)
+}
+
+func (s *fileSessionStore) Get(shardID int) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	session, ok := s.sessions[shardID]
+	if !ok {
+		return nil, nil
+	}
+	return &session, nil
+}
+
+func (s *fileSessionStore) Put(shardID int, session Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return err
+	}
+	s.sessions[shardID] = session
+	return s.write()
+}
+
+func (s *fileSessionStore) UpdateSequence(shardID int, sequence int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return err
+	}
+	session, ok := s.sessions[shardID]
+	if !ok {
+		return nil
+	}
+	session.Sequence = sequence
+	s.sessions[shardID] = session
+
+	if s.flushTimer == nil {
+		s.flushTimer = time.AfterFunc(s.config.FlushInterval, s.flush)
+	}
+	return nil
+}
+
+func (s *fileSessionStore) flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.write(); err != nil {
+		s.config.Logger.Error("failed to write gateway sessions to file: ", err)
+	}
+}
+
+func (s *fileSessionStore) Delete(shardID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return err
+	}
+	if _, ok := s.sessions[shardID]; !ok {
+		return nil
+	}
+	delete(s.sessions, shardID)
+	return s.write()
+}
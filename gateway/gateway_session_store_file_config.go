@@ -0,0 +1,46 @@
+package gateway
+
+import (
+	"time"
+
+	"github.com/disgoorg/log"
+)
+
+// DefaultFileSessionStoreConfig returns a FileSessionStoreConfig with sensible defaults.
+func DefaultFileSessionStoreConfig() *FileSessionStoreConfig {
+	return &FileSessionStoreConfig{
+		Logger:        log.Default(),
+		FlushInterval: 5 * time.Second,
+	}
+}
+
+// FileSessionStoreConfig lets you configure your file backed SessionStore instance.
+type FileSessionStoreConfig struct {
+	Logger        log.Logger
+	FlushInterval time.Duration
+}
+
+// FileSessionStoreConfigOpt is a type alias for a function that takes a FileSessionStoreConfig and is used to configure your SessionStore.
+type FileSessionStoreConfigOpt func(config *FileSessionStoreConfig)
+
+// Apply applies the given FileSessionStoreConfigOpt(s) to the FileSessionStoreConfig
+func (c *FileSessionStoreConfig) Apply(opts []FileSessionStoreConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithFileSessionStoreLogger sets the Logger for the SessionStore.
+func WithFileSessionStoreLogger(logger log.Logger) FileSessionStoreConfigOpt {
+	return func(config *FileSessionStoreConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithFlushInterval sets how long sequence updates are batched before they are written to the file.
+// Put and Delete are always written immediately.
+func WithFlushInterval(flushInterval time.Duration) FileSessionStoreConfigOpt {
+	return func(config *FileSessionStoreConfig) {
+		config.FlushInterval = flushInterval
+	}
+}
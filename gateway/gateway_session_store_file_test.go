@@ -0,0 +1,102 @@
+package gateway
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFileSessionStore(path string) SessionStore {
+	logger := log.New(log.LstdFlags)
+	logger.SetLevel(log.LevelPanic)
+	return NewFileSessionStore(path, WithFileSessionStoreLogger(logger), WithFlushInterval(10*time.Millisecond))
+}
+
+func TestFileSessionStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	store := newTestFileSessionStore(path)
+
+	session, err := store.Get(0)
+	require.NoError(t, err)
+	assert.Nil(t, session)
+
+	require.NoError(t, store.Put(0, Session{ID: "a", Sequence: 1, ShardCount: 2}))
+	require.NoError(t, store.Put(1, Session{ID: "b", Sequence: 2, ShardCount: 2}))
+	require.NoError(t, store.UpdateSequence(0, 5))
+	// sequences of unknown sessions are ignored
+	require.NoError(t, store.UpdateSequence(2, 5))
+
+	session, err = store.Get(0)
+	require.NoError(t, err)
+	assert.Equal(t, &Session{ID: "a", Sequence: 5, ShardCount: 2}, session)
+
+	require.NoError(t, store.Delete(1))
+	session, err = store.Get(1)
+	require.NoError(t, err)
+	assert.Nil(t, session)
+	session, err = store.Get(2)
+	require.NoError(t, err)
+	assert.Nil(t, session)
+}
+
+func TestFileSessionStoreReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	store := newTestFileSessionStore(path)
+
+	require.NoError(t, store.Put(0, Session{ID: "a", Sequence: 1, ShardCount: 1}))
+	require.NoError(t, store.UpdateSequence(0, 7))
+
+	// the sequence is flushed after the flush interval
+	assert.Eventually(t, func() bool {
+		session, err := newTestFileSessionStore(path).Get(0)
+		return err == nil && session != nil && session.Sequence == 7
+	}, time.Second, 10*time.Millisecond)
+
+	session, err := newTestFileSessionStore(path).Get(0)
+	require.NoError(t, err)
+	assert.Equal(t, &Session{ID: "a", Sequence: 7, ShardCount: 1}, session)
+}
+
+func TestFileSessionStoreCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"0":`), 0600))
+	store := newTestFileSessionStore(path)
+
+	// a corrupt file is treated like an empty one
+	session, err := store.Get(0)
+	require.NoError(t, err)
+	assert.Nil(t, session)
+
+	require.NoError(t, store.Put(0, Session{ID: "a", Sequence: 1, ShardCount: 1}))
+	session, err = newTestFileSessionStore(path).Get(0)
+	require.NoError(t, err)
+	assert.Equal(t, &Session{ID: "a", Sequence: 1, ShardCount: 1}, session)
+}
+
+func TestFileSessionStoreWritesAtomically(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sessions.json")
+	// a temporary file left behind by a crash is replaced
+	require.NoError(t, os.WriteFile(path+".tmp", []byte(`garbage`), 0600))
+	store := newTestFileSessionStore(path)
+
+	require.NoError(t, store.Put(0, Session{ID: "a", Sequence: 1, ShardCount: 1}))
+
+	_, err := os.Stat(path + ".tmp")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var sessions map[int]Session
+	require.NoError(t, json.Unmarshal(data, &sessions))
+	assert.Equal(t, map[int]Session{0: {ID: "a", Sequence: 1, ShardCount: 1}}, sessions)
+}
@@ -0,0 +1,159 @@
+package gateway
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"io"
+	"sync"
+)
+
+// zlibStreamSuffix is the Z_SYNC_FLUSH suffix discord ends every complete zlib-stream message with.
+var zlibStreamSuffix = []byte{0x00, 0x00, 0xff, 0xff}
+
+var (
+	errInvalidZlibHeader = errors.New("invalid zlib-stream header")
+	errZlibStreamClosed  = errors.New("zlib-stream inflater closed")
+)
+
+// newZlibStreamInflater returns a zlibStreamInflater with a fresh inflate context. Call close once the connection is closed.
+func newZlibStreamInflater() *zlibStreamInflater {
+	z := &zlibStreamInflater{
+		input:   make(chan []byte),
+		results: make(chan zlibStreamResult, 1),
+		done:    make(chan struct{}),
+	}
+	go z.run()
+	return z
+}
+
+// zlibStreamInflater holds the inflate context of a single gateway connection using zlib-stream transport compression.
+// All messages of a connection share the same deflate stream, so a new zlibStreamInflater is needed for every new connection.
+//
+// A single flate reader inflates the whole stream in its own goroutine and reads the compressed messages from a frame buffer.
+// As every message ends with a sync flush, the flate reader has returned all data of a message once it asks the frame buffer for more input.
+// This keeps the flate reader from running into the end of the buffered data, which it can't recover from.
+type zlibStreamInflater struct {
+	buf        bytes.Buffer
+	headerRead bool
+
+	input     chan []byte
+	results   chan zlibStreamResult
+	done      chan struct{}
+	closeOnce sync.Once
+
+	// only used by the inflate goroutine
+	pending []byte
+	output  []byte
+	started bool
+}
+
+type zlibStreamResult struct {
+	message []byte
+	err     error
+}
+
+// inflate buffers the given frame and returns the inflated message once a frame ending with zlibStreamSuffix was received.
+// It returns nil if the message continues in the next frame.
+func (z *zlibStreamInflater) inflate(frame io.Reader) ([]byte, error) {
+	if _, err := z.buf.ReadFrom(frame); err != nil {
+		return nil, err
+	}
+	if !bytes.HasSuffix(z.buf.Bytes(), zlibStreamSuffix) {
+		return nil, nil
+	}
+	defer z.buf.Reset()
+
+	data := z.buf.Bytes()
+	if !z.headerRead {
+		// the 2 byte zlib header is only sent once at the start of the stream
+		if len(data) < 2 || data[0]&0x0f != 8 || (uint16(data[0])<<8|uint16(data[1]))%31 != 0 {
+			return nil, errInvalidZlibHeader
+		}
+		data = data[2:]
+		z.headerRead = true
+	}
+
+	select {
+	case z.input <- append([]byte(nil), data...):
+	case <-z.done:
+		return nil, errZlibStreamClosed
+	}
+
+	result, ok := <-z.results
+	if !ok {
+		return nil, errZlibStreamClosed
+	}
+	return result.message, result.err
+}
+
+// close stops the inflate goroutine. The zlibStreamInflater can't be used afterwards.
+func (z *zlibStreamInflater) close() {
+	z.closeOnce.Do(func() {
+		close(z.input)
+	})
+}
+
+func (z *zlibStreamInflater) run() {
+	defer close(z.done)
+	defer close(z.results)
+
+	reader := flate.NewReader(zlibStreamSource{z})
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := reader.Read(buf)
+		z.output = append(z.output, buf[:n]...)
+		if err != nil {
+			// the input is only closed after the connection was closed, so there is nobody waiting for the error
+			if z.pending != nil || !errors.Is(err, io.ErrUnexpectedEOF) {
+				z.results <- zlibStreamResult{err: err}
+			}
+			return
+		}
+	}
+}
+
+// zlibStreamSource is the frame buffer the flate reader reads the compressed messages from.
+// Once a message is fully read, it hands the inflated output to inflate and waits for the next message.
+type zlibStreamSource struct {
+	z *zlibStreamInflater
+}
+
+func (s zlibStreamSource) next() bool {
+	for len(s.z.pending) == 0 {
+		if s.z.started {
+			message := s.z.output
+			if message == nil {
+				message = []byte{}
+			}
+			s.z.results <- zlibStreamResult{message: message}
+			s.z.output = nil
+		}
+		pending, ok := <-s.z.input
+		if !ok {
+			s.z.pending = nil
+			return false
+		}
+		s.z.pending = pending
+		s.z.started = true
+	}
+	return true
+}
+
+func (s zlibStreamSource) Read(p []byte) (int, error) {
+	if !s.next() {
+		return 0, io.EOF
+	}
+	n := copy(p, s.z.pending)
+	s.z.pending = s.z.pending[n:]
+	return n, nil
+}
+
+func (s zlibStreamSource) ReadByte() (byte, error) {
+	if !s.next() {
+		return 0, io.EOF
+	}
+	b := s.z.pending[0]
+	s.z.pending = s.z.pending[1:]
+	return b, nil
+}
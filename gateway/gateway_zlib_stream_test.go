@@ -0,0 +1,44 @@
+package gateway
+
+import (
+	"bytes"
+	"compress/zlib"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZlibStreamInflater(t *testing.T) {
+	var compressed bytes.Buffer
+	writer := zlib.NewWriter(&compressed)
+
+	messages := []string{
+		`{"op":10,"d":{"heartbeat_interval":41250}}`,
+		`{"op":11}`,
+		// big enough to span multiple frames and reference previous output
+		`{"op":0,"t":"GUILD_CREATE","d":{"name":"` + strings.Repeat("disgo", 20000) + `"}}`,
+		`{"op":11}`,
+	}
+
+	inflater := newZlibStreamInflater()
+	defer inflater.close()
+	for _, message := range messages {
+		_, err := writer.Write([]byte(message))
+		assert.NoError(t, err)
+		assert.NoError(t, writer.Flush())
+
+		data := compressed.Bytes()
+		compressed.Reset()
+
+		// split every message into two frames
+		half := len(data) / 2
+		rs, err := inflater.inflate(bytes.NewReader(data[:half]))
+		assert.NoError(t, err)
+		assert.Nil(t, rs)
+
+		rs, err = inflater.inflate(bytes.NewReader(data[half:]))
+		assert.NoError(t, err)
+		assert.Equal(t, message, string(rs))
+	}
+}
@@ -0,0 +1,73 @@
+package gatewaytest
+
+import (
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/log"
+)
+
+// DefaultConfig returns a Config with sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Logger:            log.Default(),
+		HeartbeatInterval: 41250 * time.Millisecond,
+	}
+}
+
+// Config lets you configure your Server instance.
+type Config struct {
+	Logger             log.Logger
+	HeartbeatInterval  time.Duration
+	Token              string
+	RequiredShardCount int
+	User               discord.OAuth2User
+}
+
+// ConfigOpt is a type alias for a function that takes a Config and is used to configure your Server.
+type ConfigOpt func(config *Config)
+
+// Apply applies the given ConfigOpt(s) to the Config
+func (c *Config) Apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithLogger sets the Logger for the Server.
+func WithLogger(logger log.Logger) ConfigOpt {
+	return func(config *Config) {
+		config.Logger = logger
+	}
+}
+
+// WithHeartbeatInterval sets the heartbeat interval the Server sends in the discord.GatewayOpcodeHello packet.
+func WithHeartbeatInterval(heartbeatInterval time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.HeartbeatInterval = heartbeatInterval
+	}
+}
+
+// WithToken sets the token clients have to identify or resume with.
+// Connections with a different token are closed with discord.GatewayCloseEventCodeAuthenticationFailed.
+// If no token is set, every token is accepted.
+func WithToken(token string) ConfigOpt {
+	return func(config *Config) {
+		config.Token = token
+	}
+}
+
+// WithRequiredShardCount sets the minimum shard count clients have to identify with.
+// Connections identifying with fewer shards are closed with discord.GatewayCloseEventCodeShardingRequired.
+func WithRequiredShardCount(shardCount int) ConfigOpt {
+	return func(config *Config) {
+		config.RequiredShardCount = shardCount
+	}
+}
+
+// WithUser sets the discord.OAuth2User sent in the discord.GatewayEventTypeReady event.
+func WithUser(user discord.OAuth2User) ConfigOpt {
+	return func(config *Config) {
+		config.User = user
+	}
+}
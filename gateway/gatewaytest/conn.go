@@ -0,0 +1,425 @@
+package gatewaytest
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/internal/etf"
+	"github.com/disgoorg/disgo/json"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	encodingJSON       = "json"
+	encodingETF        = "etf"
+	compressZlibStream = "zlib-stream"
+)
+
+// ErrConnClosed is returned when writing to a Conn which has already been closed.
+var ErrConnClosed = errors.New("gatewaytest: connection closed")
+
+// message is a gateway message sent by the Server.
+// discord.GatewayMessage can't be used here as discord.GatewayMessageDataDispatch does not marshal to raw JSON.
+type message struct {
+	Op discord.GatewayOpcode    `json:"op"`
+	S  int                      `json:"s,omitempty"`
+	T  discord.GatewayEventType `json:"t,omitempty"`
+	D  any                      `json:"d"`
+}
+
+func newConn(server *Server, ws *websocket.Conn, version string, encoding string, zlibStream bool) *Conn {
+	c := &Conn{
+		server:   server,
+		ws:       ws,
+		version:  version,
+		encoding: encoding,
+		commands: make(chan discord.GatewayMessage, 128),
+		done:     make(chan struct{}),
+	}
+	if zlibStream {
+		c.zlibWriter = zlib.NewWriter(&c.zlibBuf)
+	}
+	return c
+}
+
+// Conn is a single client connection to the Server.
+// It is used to script what the Server sends to the client.
+type Conn struct {
+	server   *Server
+	ws       *websocket.Conn
+	version  string
+	encoding string
+
+	writeMu    sync.Mutex
+	zlibBuf    bytes.Buffer
+	zlibWriter *zlib.Writer
+
+	mu       sync.Mutex
+	identify *discord.GatewayMessageDataIdentify
+	resumed  bool
+	session  *session
+
+	commands  chan discord.GatewayMessage
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Identify returns the discord.GatewayMessageDataIdentify the client identified with or nil if it resumed or did not identify yet.
+func (c *Conn) Identify() *discord.GatewayMessageDataIdentify {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.identify
+}
+
+// Resumed returns whether the client resumed an existing session on this connection.
+func (c *Conn) Resumed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.resumed
+}
+
+// SessionID returns the id of the session of this connection or an empty string if the client did not identify or resume yet.
+func (c *Conn) SessionID() string {
+	sess := c.getSession()
+	if sess == nil {
+		return ""
+	}
+	return sess.id
+}
+
+// Shard returns the shard id and shard count of the session of this connection.
+func (c *Conn) Shard() (shardID int, shardCount int) {
+	sess := c.getSession()
+	if sess == nil {
+		return 0, 0
+	}
+	return sess.shardID, sess.shardCount
+}
+
+// Commands returns a channel receiving all commands the client sent besides heartbeats, identify and resume.
+func (c *Conn) Commands() <-chan discord.GatewayMessage {
+	return c.commands
+}
+
+// Done returns a channel which is closed once the connection is closed.
+func (c *Conn) Done() <-chan struct{} {
+	return c.done
+}
+
+// Dispatch sends a discord.GatewayOpcodeDispatch with the given discord.GatewayEventType and data to the client.
+// The data is marshalled to JSON unless it is already a json.RawMessage.
+// Dispatched events are replayed when the session is resumed with an older sequence.
+func (c *Conn) Dispatch(eventType discord.GatewayEventType, d any) error {
+	data, ok := d.(json.RawMessage)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(d); err != nil {
+			return err
+		}
+	}
+
+	sess := c.getSession()
+	if sess == nil {
+		return errors.New("gatewaytest: connection has no session")
+	}
+
+	// hold the write lock while assigning the sequence, so events are sent in sequence order
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.server.mu.Lock()
+	sess.seq++
+	seq := sess.seq
+	sess.dispatches = append(sess.dispatches, dispatch{seq: seq, eventType: eventType, data: data})
+	c.server.mu.Unlock()
+
+	return c.writeLocked(message{
+		Op: discord.GatewayOpcodeDispatch,
+		S:  seq,
+		T:  eventType,
+		D:  data,
+	})
+}
+
+// RequestHeartbeat sends a discord.GatewayOpcodeHeartbeat to the client, which should answer with a heartbeat immediately.
+func (c *Conn) RequestHeartbeat() error {
+	return c.write(message{Op: discord.GatewayOpcodeHeartbeat})
+}
+
+// Reconnect sends a discord.GatewayOpcodeReconnect to the client, which should reconnect and resume.
+func (c *Conn) Reconnect() error {
+	return c.write(message{Op: discord.GatewayOpcodeReconnect})
+}
+
+// InvalidateSession sends a discord.GatewayOpcodeInvalidSession to the client.
+// If resumable is false, the session is deleted and the client has to identify again.
+func (c *Conn) InvalidateSession(resumable bool) error {
+	if !resumable {
+		if sess := c.getSession(); sess != nil {
+			c.server.deleteSession(sess)
+		}
+	}
+	return c.write(message{Op: discord.GatewayOpcodeInvalidSession, D: resumable})
+}
+
+// Close closes the connection with the given close code and text like Discord would.
+// Sessions closed with a code which does not allow resuming are deleted.
+func (c *Conn) Close(code int, text string) error {
+	closeCode := discord.GatewayCloseEventCode(code)
+	if !closeCode.ShouldReconnect() || closeCode == discord.GatewayCloseEventCodeInvalidSeq || closeCode == discord.GatewayCloseEventCodeSessionTimedOut {
+		if sess := c.getSession(); sess != nil {
+			c.server.deleteSession(sess)
+		}
+	}
+	err := c.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, text), time.Now().Add(time.Second))
+	c.closeConn()
+	if err != nil && err != websocket.ErrCloseSent {
+		return err
+	}
+	return nil
+}
+
+// closeConn closes the underlying connection without sending a close frame.
+func (c *Conn) closeConn() {
+	c.closeOnce.Do(func() {
+		_ = c.ws.Close()
+		close(c.done)
+	})
+}
+
+func (c *Conn) getSession() *session {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.session
+}
+
+func (c *Conn) serve() {
+	defer c.closeConn()
+
+	if err := c.write(message{
+		Op: discord.GatewayOpcodeHello,
+		D:  discord.GatewayMessageDataHello{HeartbeatInterval: int(c.server.config.HeartbeatInterval.Milliseconds())},
+	}); err != nil {
+		c.server.Logger().Error("gatewaytest: failed to send hello: ", err)
+		return
+	}
+
+	for {
+		mt, data, err := c.ws.ReadMessage()
+		if err != nil {
+			// closing with a normal close code invalidates the session
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				if sess := c.getSession(); sess != nil {
+					c.server.deleteSession(sess)
+				}
+			}
+			return
+		}
+
+		if mt == websocket.BinaryMessage {
+			if c.encoding != encodingETF {
+				_ = c.Close(int(discord.GatewayCloseEventCodeDecodeError), "Error while decoding payload.")
+				return
+			}
+			if data, err = etf.ToJSON(data); err != nil {
+				_ = c.Close(int(discord.GatewayCloseEventCodeDecodeError), "Error while decoding payload.")
+				return
+			}
+		}
+
+		if !c.handle(data) {
+			return
+		}
+	}
+}
+
+// handle handles a single command sent by the client and returns whether the connection is still open.
+func (c *Conn) handle(data []byte) bool {
+	var v struct {
+		Op discord.GatewayOpcode `json:"op"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		_ = c.Close(int(discord.GatewayCloseEventCodeDecodeError), "Error while decoding payload.")
+		return false
+	}
+
+	switch v.Op {
+	case discord.GatewayOpcodeHeartbeat, discord.GatewayOpcodeIdentify, discord.GatewayOpcodeResume, discord.GatewayOpcodePresenceUpdate,
+		discord.GatewayOpcodeVoiceStateUpdate, discord.GatewayOpcodeRequestGuildMembers:
+	default:
+		_ = c.Close(int(discord.GatewayCloseEventCodeUnknownOpcode), "Unknown opcode.")
+		return false
+	}
+
+	var msg discord.GatewayMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		_ = c.Close(int(discord.GatewayCloseEventCodeDecodeError), "Error while decoding payload.")
+		return false
+	}
+
+	switch msg.Op {
+	case discord.GatewayOpcodeHeartbeat:
+		if err := c.write(message{Op: discord.GatewayOpcodeHeartbeatACK}); err != nil {
+			return false
+		}
+
+	case discord.GatewayOpcodeIdentify:
+		return c.handleIdentify(msg.D.(discord.GatewayMessageDataIdentify))
+
+	case discord.GatewayOpcodeResume:
+		return c.handleResume(msg.D.(discord.GatewayMessageDataResume))
+
+	default:
+		if c.getSession() == nil {
+			_ = c.Close(int(discord.GatewayCloseEventCodeNotAuthenticated), "Not authenticated.")
+			return false
+		}
+		select {
+		case c.commands <- msg:
+		default:
+			c.server.Logger().Warn("gatewaytest: command buffer full, dropping command with opcode ", msg.Op)
+		}
+	}
+	return true
+}
+
+func (c *Conn) handleIdentify(identify discord.GatewayMessageDataIdentify) bool {
+	if c.getSession() != nil {
+		_ = c.Close(int(discord.GatewayCloseEventCodeAlreadyAuthenticated), "Already authenticated.")
+		return false
+	}
+	if c.server.config.Token != "" && identify.Token != c.server.config.Token {
+		_ = c.Close(int(discord.GatewayCloseEventCodeAuthenticationFailed), "Authentication failed.")
+		return false
+	}
+
+	shardID, shardCount := 0, 1
+	if identify.Shard != nil {
+		shardID, shardCount = identify.Shard[0], identify.Shard[1]
+	}
+	if shardCount < 1 || shardID < 0 || shardID >= shardCount {
+		_ = c.Close(int(discord.GatewayCloseEventCodeInvalidShard), "Invalid shard.")
+		return false
+	}
+	if shardCount < c.server.config.RequiredShardCount {
+		_ = c.Close(int(discord.GatewayCloseEventCodeShardingRequired), "Sharding required.")
+		return false
+	}
+
+	sess := c.server.newSession(c, shardID, shardCount)
+	c.mu.Lock()
+	c.identify = &identify
+	c.session = sess
+	c.mu.Unlock()
+
+	version, _ := strconv.Atoi(c.version)
+	ready := discord.GatewayEventReady{
+		Version:     version,
+		User:        c.server.config.User,
+		Guilds:      []discord.UnavailableGuild{},
+		SessionID:   sess.id,
+		Application: discord.PartialApplication{ID: c.server.config.User.ID},
+	}
+	if identify.Shard != nil {
+		ready.Shard = []int{shardID, shardCount}
+	}
+	if err := c.Dispatch(discord.GatewayEventTypeReady, ready); err != nil {
+		return false
+	}
+	c.server.connReady(c)
+	return true
+}
+
+func (c *Conn) handleResume(resume discord.GatewayMessageDataResume) bool {
+	if c.getSession() != nil {
+		_ = c.Close(int(discord.GatewayCloseEventCodeAlreadyAuthenticated), "Already authenticated.")
+		return false
+	}
+	if c.server.config.Token != "" && resume.Token != c.server.config.Token {
+		_ = c.Close(int(discord.GatewayCloseEventCodeAuthenticationFailed), "Authentication failed.")
+		return false
+	}
+
+	sess, oldConn := c.server.resumeSession(c, resume.SessionID, resume.Seq)
+	if sess == nil {
+		return c.write(message{Op: discord.GatewayOpcodeInvalidSession, D: false}) == nil
+	}
+	if oldConn != nil && oldConn != c {
+		oldConn.closeConn()
+	}
+
+	c.mu.Lock()
+	c.resumed = true
+	c.session = sess
+	c.mu.Unlock()
+
+	// replay all events the client missed
+	c.writeMu.Lock()
+	c.server.mu.Lock()
+	var missed []dispatch
+	for _, d := range sess.dispatches {
+		if d.seq > resume.Seq {
+			missed = append(missed, d)
+		}
+	}
+	c.server.mu.Unlock()
+	for _, d := range missed {
+		if err := c.writeLocked(message{Op: discord.GatewayOpcodeDispatch, S: d.seq, T: d.eventType, D: d.data}); err != nil {
+			c.writeMu.Unlock()
+			return false
+		}
+	}
+	c.writeMu.Unlock()
+
+	if err := c.Dispatch(discord.GatewayEventTypeResumed, nil); err != nil {
+		return false
+	}
+	c.server.connReady(c)
+	return true
+}
+
+func (c *Conn) write(msg message) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.writeLocked(msg)
+}
+
+// writeLocked encodes and writes the given message. It must be called with writeMu held.
+func (c *Conn) writeLocked(msg message) error {
+	select {
+	case <-c.done:
+		return ErrConnClosed
+	default:
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	messageType := websocket.TextMessage
+	if c.encoding == encodingETF {
+		if data, err = etf.FromJSON(data); err != nil {
+			return err
+		}
+		messageType = websocket.BinaryMessage
+	}
+
+	if c.zlibWriter != nil {
+		c.zlibBuf.Reset()
+		if _, err = c.zlibWriter.Write(data); err != nil {
+			return err
+		}
+		// every message ends with a sync flush, which is what clients look for to detect complete messages
+		if err = c.zlibWriter.Flush(); err != nil {
+			return err
+		}
+		data = c.zlibBuf.Bytes()
+		messageType = websocket.BinaryMessage
+	}
+	return c.ws.WriteMessage(messageType, data)
+}
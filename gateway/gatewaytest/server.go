@@ -0,0 +1,183 @@
+// Package gatewaytest implements an in-process fake Discord gateway server.
+// It speaks the gateway protocol over a local websocket, so gateway.Gateway and sharding.ShardManager can be tested
+// by pointing them at Server.URL with gateway.WithGatewayURL.
+package gatewaytest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/internal/insecurerandstr"
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/log"
+	"github.com/gorilla/websocket"
+)
+
+// NewServer starts a new Server with the given ConfigOpt(s). The Server has to be closed with Server.Close.
+func NewServer(opts ...ConfigOpt) *Server {
+	config := DefaultConfig()
+	config.Apply(opts)
+
+	s := &Server{
+		config:   *config,
+		conns:    map[*Conn]struct{}{},
+		sessions: map[string]*session{},
+		ready:    make(chan *Conn, 64),
+	}
+	s.httpServer = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Server is a fake Discord gateway server.
+// It sends discord.GatewayOpcodeHello, answers heartbeats, handles identify & resume and lets tests script everything else via Conn.
+type Server struct {
+	config     Config
+	httpServer *httptest.Server
+	upgrader   websocket.Upgrader
+
+	mu       sync.Mutex
+	conns    map[*Conn]struct{}
+	sessions map[string]*session
+	ready    chan *Conn
+}
+
+// session is a gateway session which can be resumed by a new connection.
+type session struct {
+	id         string
+	shardID    int
+	shardCount int
+	seq        int
+	dispatches []dispatch
+	conn       *Conn
+}
+
+type dispatch struct {
+	seq       int
+	eventType discord.GatewayEventType
+	data      json.RawMessage
+}
+
+// Logger returns the logger used by the Server.
+func (s *Server) Logger() log.Logger {
+	return s.config.Logger
+}
+
+// URL returns the websocket URL of the Server. Pass it to gateway.WithGatewayURL.
+func (s *Server) URL() string {
+	return "ws" + strings.TrimPrefix(s.httpServer.URL, "http")
+}
+
+// Close closes all open connections and shuts down the Server.
+func (s *Server) Close() {
+	for _, conn := range s.Conns() {
+		conn.closeConn()
+	}
+	s.httpServer.Close()
+}
+
+// NextConn waits for the next connection which successfully identified or resumed.
+func (s *Server) NextConn(ctx context.Context) (*Conn, error) {
+	select {
+	case conn := <-s.ready:
+		return conn, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Conns returns all currently open connections.
+func (s *Server) Conns() []*Conn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conns := make([]*Conn, 0, len(s.conns))
+	for conn := range s.conns {
+		conns = append(conns, conn)
+	}
+	return conns
+}
+
+// SessionCount returns the number of sessions which can currently be resumed.
+func (s *Server) SessionCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.sessions)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	encoding := query.Get("encoding")
+	if encoding == "" {
+		encoding = encodingJSON
+	}
+	if encoding != encodingJSON && encoding != encodingETF {
+		http.Error(w, "invalid encoding", http.StatusBadRequest)
+		return
+	}
+	compress := query.Get("compress")
+	if compress != "" && compress != compressZlibStream {
+		http.Error(w, "invalid compression", http.StatusBadRequest)
+		return
+	}
+
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.Logger().Error("gatewaytest: failed to upgrade connection: ", err)
+		return
+	}
+
+	conn := newConn(s, ws, query.Get("v"), encoding, compress == compressZlibStream)
+	s.mu.Lock()
+	s.conns[conn] = struct{}{}
+	s.mu.Unlock()
+
+	conn.serve()
+
+	s.mu.Lock()
+	delete(s.conns, conn)
+	s.mu.Unlock()
+}
+
+// newSession creates a new session for the given identify payload.
+func (s *Server) newSession(conn *Conn, shardID int, shardCount int) *session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess := &session{
+		id:         insecurerandstr.RandStr(32),
+		shardID:    shardID,
+		shardCount: shardCount,
+		conn:       conn,
+	}
+	s.sessions[sess.id] = sess
+	return sess
+}
+
+// resumeSession hands the session with the given id over to the given connection and returns the previous connection of the session.
+func (s *Server) resumeSession(conn *Conn, sessionID string, seq int) (*session, *Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[sessionID]
+	if !ok || seq > sess.seq {
+		return nil, nil
+	}
+	oldConn := sess.conn
+	sess.conn = conn
+	return sess, oldConn
+}
+
+func (s *Server) deleteSession(sess *session) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, sess.id)
+}
+
+func (s *Server) connReady(conn *Conn) {
+	select {
+	case s.ready <- conn:
+	default:
+		s.Logger().Warn("gatewaytest: ready connection buffer full, dropping connection notification")
+	}
+}
@@ -0,0 +1,257 @@
+package gatewaytest
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/log"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEvent struct {
+	eventType discord.GatewayEventType
+	sequence  int
+}
+
+func newTestGateway(t *testing.T, server *Server, opts ...gateway.ConfigOpt) (gateway.Gateway, <-chan testEvent, <-chan error) {
+	events := make(chan testEvent, 16)
+	closed := make(chan error, 1)
+
+	logger := log.New(log.LstdFlags)
+	logger.SetLevel(log.LevelPanic)
+	opts = append([]gateway.ConfigOpt{
+		gateway.WithLogger(logger),
+		gateway.WithGatewayURL(server.URL()),
+		gateway.WithReconnectStrategy(gateway.NewRetryForeverReconnectStrategy(10*time.Millisecond, 10*time.Millisecond)),
+	}, opts...)
+
+	g := gateway.New("token",
+		func(gatewayEventType discord.GatewayEventType, sequenceNumber int, shardID int, payload io.Reader) {
+			events <- testEvent{eventType: gatewayEventType, sequence: sequenceNumber}
+		},
+		func(gateway gateway.Gateway, err error) {
+			// only the first close is of interest, don't block the gateway on later ones
+			select {
+			case closed <- err:
+			default:
+			}
+		},
+		opts...,
+	)
+	t.Cleanup(func() {
+		g.Close(context.Background())
+	})
+	return g, events, closed
+}
+
+func nextConn(t *testing.T, server *Server) *Conn {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := server.NextConn(ctx)
+	require.NoError(t, err)
+	return conn
+}
+
+func nextEvent(t *testing.T, events <-chan testEvent) testEvent {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+		return testEvent{}
+	}
+}
+
+func nextCommand(t *testing.T, conn *Conn) discord.GatewayMessage {
+	select {
+	case command := <-conn.Commands():
+		return command
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for command")
+		return discord.GatewayMessage{}
+	}
+}
+
+func TestServerIdentifyAndDispatch(t *testing.T) {
+	for _, opts := range [][]gateway.ConfigOpt{
+		nil,
+		{gateway.WithEncoding(gateway.EncodingETF)},
+		{gateway.WithTransportCompression(gateway.TransportCompressionZlibStream)},
+		{gateway.WithEncoding(gateway.EncodingETF), gateway.WithTransportCompression(gateway.TransportCompressionZlibStream)},
+	} {
+		server := NewServer(WithToken("token"))
+
+		g, events, _ := newTestGateway(t, server, opts...)
+		require.NoError(t, g.Open(context.Background()))
+
+		conn := nextConn(t, server)
+		assert.False(t, conn.Resumed())
+		assert.Equal(t, testEvent{eventType: discord.GatewayEventTypeReady, sequence: 1}, nextEvent(t, events))
+		assert.Equal(t, gateway.StatusReady, g.Status())
+
+		require.NoError(t, conn.Dispatch(discord.GatewayEventTypeTypingStart, discord.GatewayEventTypingStart{ChannelID: 1, UserID: 2}))
+		assert.Equal(t, testEvent{eventType: discord.GatewayEventTypeTypingStart, sequence: 2}, nextEvent(t, events))
+
+		require.NoError(t, g.Send(context.Background(), discord.GatewayOpcodePresenceUpdate, discord.GatewayMessageDataPresenceUpdate{Status: discord.OnlineStatusIdle}))
+		command := nextCommand(t, conn)
+		assert.Equal(t, discord.GatewayOpcodePresenceUpdate, command.Op)
+		assert.Equal(t, discord.OnlineStatusIdle, command.D.(discord.GatewayMessageDataPresenceUpdate).Status)
+
+		g.Close(context.Background())
+		server.Close()
+	}
+}
+
+func TestServerReconnectResumes(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	g, events, _ := newTestGateway(t, server)
+	require.NoError(t, g.Open(context.Background()))
+
+	conn := nextConn(t, server)
+	nextEvent(t, events)
+
+	require.NoError(t, conn.Reconnect())
+	resumed := nextConn(t, server)
+	assert.True(t, resumed.Resumed())
+	assert.Equal(t, conn.SessionID(), resumed.SessionID())
+	assert.Equal(t, testEvent{eventType: discord.GatewayEventTypeResumed, sequence: 2}, nextEvent(t, events))
+}
+
+func TestServerInvalidSessionIdentifiesAgain(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	g, events, _ := newTestGateway(t, server)
+	require.NoError(t, g.Open(context.Background()))
+
+	conn := nextConn(t, server)
+	nextEvent(t, events)
+
+	require.NoError(t, conn.InvalidateSession(false))
+	identified := nextConn(t, server)
+	assert.False(t, identified.Resumed())
+	assert.NotEqual(t, conn.SessionID(), identified.SessionID())
+	assert.Equal(t, testEvent{eventType: discord.GatewayEventTypeReady, sequence: 1}, nextEvent(t, events))
+}
+
+func TestServerShardingRequired(t *testing.T) {
+	server := NewServer(WithRequiredShardCount(2))
+	defer server.Close()
+
+	g, _, closed := newTestGateway(t, server)
+	require.NoError(t, g.Open(context.Background()))
+
+	select {
+	case err := <-closed:
+		assert.True(t, websocket.IsCloseError(err, int(discord.GatewayCloseEventCodeShardingRequired)))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for close")
+	}
+}
+
+func TestServerReconfigureIdentifiesAgain(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	g, events, _ := newTestGateway(t, server, gateway.WithGatewayIntents(discord.GatewayIntentGuilds, discord.GatewayIntentGuildMembers))
+	require.NoError(t, g.Open(context.Background()))
+
+	conn := nextConn(t, server)
+	nextEvent(t, events)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, g.Reconfigure(ctx, gateway.WithoutGatewayIntents(discord.GatewayIntentGuildMembers)))
+	assert.Equal(t, gateway.StatusReady, g.Status())
+
+	identified := nextConn(t, server)
+	assert.False(t, identified.Resumed())
+	assert.NotEqual(t, conn.SessionID(), identified.SessionID())
+	assert.False(t, identified.Identify().GatewayIntents.Has(discord.GatewayIntentGuildMembers))
+	assert.True(t, identified.Identify().GatewayIntents.Has(discord.GatewayIntentGuilds))
+}
+
+func TestServerCloseReconnects(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	g, events, _ := newTestGateway(t, server)
+	require.NoError(t, g.Open(context.Background()))
+
+	conn := nextConn(t, server)
+	nextEvent(t, events)
+
+	require.NoError(t, conn.Close(int(discord.GatewayCloseEventCodeUnknownError), "Unknown error."))
+	resumed := nextConn(t, server)
+	assert.True(t, resumed.Resumed())
+	assert.Equal(t, conn.SessionID(), resumed.SessionID())
+}
+
+type fixedReconnectStrategy time.Duration
+
+func (s fixedReconnectStrategy) NextDelay(_ int) (time.Duration, bool) {
+	return time.Duration(s), true
+}
+
+func TestServerCloseCancelsReconnect(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	g, events, _ := newTestGateway(t, server, gateway.WithReconnectStrategy(fixedReconnectStrategy(100*time.Millisecond)))
+	require.NoError(t, g.Open(context.Background()))
+
+	conn := nextConn(t, server)
+	nextEvent(t, events)
+
+	require.NoError(t, conn.Close(int(discord.GatewayCloseEventCodeUnknownError), "Unknown error."))
+	time.Sleep(20 * time.Millisecond)
+	g.Close(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	_, err := server.NextConn(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, server.Conns())
+}
+
+func TestServerReconfigureCancelsReconnect(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	g, events, _ := newTestGateway(t, server, gateway.WithReconnectStrategy(fixedReconnectStrategy(100*time.Millisecond)))
+	require.NoError(t, g.Open(context.Background()))
+
+	conn := nextConn(t, server)
+	nextEvent(t, events)
+
+	require.NoError(t, conn.Close(int(discord.GatewayCloseEventCodeUnknownError), "Unknown error."))
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, g.Reconfigure(ctx, gateway.WithEventFilter(func(eventType discord.GatewayEventType) bool {
+		return eventType != discord.GatewayEventTypeTypingStart
+	})))
+
+	identified := nextConn(t, server)
+	assert.False(t, identified.Resumed())
+	assert.Equal(t, testEvent{eventType: discord.GatewayEventTypeReady, sequence: 1}, nextEvent(t, events))
+
+	require.NoError(t, identified.Dispatch(discord.GatewayEventTypeTypingStart, discord.GatewayEventTypingStart{ChannelID: 1, UserID: 2}))
+	require.NoError(t, identified.Dispatch(discord.GatewayEventTypeMessageDelete, discord.GatewayEventMessageDelete{ID: 3, ChannelID: 1}))
+	assert.Equal(t, testEvent{eventType: discord.GatewayEventTypeMessageDelete, sequence: 3}, nextEvent(t, events))
+
+	// the reconnect which was pending before reconfiguring must not open a second connection
+	ctx, cancel = context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	_, err := server.NextConn(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -8,11 +8,13 @@ require (
 	github.com/gorilla/websocket v1.5.0
 	github.com/sasha-s/go-csync v0.0.0-20210812194225-61421b77c44b
 	github.com/stretchr/testify v1.7.0
+	golang.org/x/crypto v0.10.0
 	golang.org/x/exp v0.0.0-20220325121720-054d8573a5d8
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.9.0 // indirect
 	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
 )
@@ -2,12 +2,14 @@ package handlers
 
 import (
 	"io"
+	"time"
 
 	"github.com/disgoorg/disgo/bot"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
 	"github.com/disgoorg/disgo/gateway"
 	"github.com/disgoorg/disgo/httpserver"
+	"github.com/disgoorg/disgo/sharding"
 )
 
 // DefaultHTTPServerEventHandler is the default handler for the httpserver.Server and sends payloads to the bot.EventManager.
@@ -29,6 +31,29 @@ func DefaultGatewayEventHandler(client bot.Client) gateway.EventHandlerFunc {
 	}
 }
 
+// DefaultGatewayReconnectHandler is the default reconnect handler for the gateway.Gateway and dispatches an events.GatewayReconnect to the bot.EventManager.
+func DefaultGatewayReconnectHandler(client bot.Client) gateway.ReconnectHandlerFunc {
+	return func(g gateway.Gateway, try int, delay time.Duration, err error) {
+		client.EventManager().DispatchEvent(&events.GatewayReconnect{
+			GenericEvent: events.NewGenericEvent(client, -1, g.ShardID()),
+			Try:          try,
+			Delay:        delay,
+			Err:          err,
+		})
+	}
+}
+
+// DefaultShardHealthChangeHandler is the default health change handler for the sharding.ShardManager and dispatches an events.ShardHealthChange to the bot.EventManager.
+func DefaultShardHealthChangeHandler(client bot.Client) sharding.HealthChangeHandlerFunc {
+	return func(oldHealth sharding.ShardHealth, newHealth sharding.ShardHealth) {
+		client.EventManager().DispatchEvent(&events.ShardHealthChange{
+			GenericEvent: events.NewGenericEvent(client, -1, newHealth.ShardID),
+			OldHealth:    oldHealth,
+			Health:       newHealth,
+		})
+	}
+}
+
 // GetGatewayHandlers returns the default gateway.Gateway event handlers for processing the raw payload which gets passed into the bot.EventManager
 func GetGatewayHandlers() map[discord.GatewayEventType]bot.GatewayEventHandler {
 	handlers := make(map[discord.GatewayEventType]bot.GatewayEventHandler, len(allEventHandlers))
@@ -44,6 +69,11 @@ var allEventHandlers = []bot.GatewayEventHandler{
 
 	&gatewayHandlerApplicationCommandPermissionsUpdate{},
 
+	&gatewayHandlerAutoModerationRuleCreate{},
+	&gatewayHandlerAutoModerationRuleUpdate{},
+	&gatewayHandlerAutoModerationRuleDelete{},
+	&gatewayHandlerAutoModerationActionExecution{},
+
 	&gatewayHandlerChannelCreate{},
 	&gatewayHandlerChannelUpdate{},
 	&gatewayHandlerChannelDelete{},
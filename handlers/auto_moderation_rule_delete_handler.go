@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+type gatewayHandlerAutoModerationRuleDelete struct{}
+
+func (h *gatewayHandlerAutoModerationRuleDelete) EventType() discord.GatewayEventType {
+	return discord.GatewayEventTypeAutoModerationRuleDelete
+}
+
+func (h *gatewayHandlerAutoModerationRuleDelete) New() any {
+	return &discord.AutoModerationRule{}
+}
+
+func (h *gatewayHandlerAutoModerationRuleDelete) HandleGatewayEvent(client bot.Client, sequenceNumber int, shardID int, v any) {
+	autoModerationRule := *v.(*discord.AutoModerationRule)
+
+	client.Caches().AutoModerationRules().Remove(autoModerationRule.GuildID, autoModerationRule.ID)
+
+	client.EventManager().DispatchEvent(&events.AutoModerationRuleDelete{
+		GenericAutoModerationRule: &events.GenericAutoModerationRule{
+			GenericEvent:       events.NewGenericEvent(client, sequenceNumber, shardID),
+			AutoModerationRule: autoModerationRule,
+		},
+	})
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+type gatewayHandlerAutoModerationRuleUpdate struct{}
+
+func (h *gatewayHandlerAutoModerationRuleUpdate) EventType() discord.GatewayEventType {
+	return discord.GatewayEventTypeAutoModerationRuleUpdate
+}
+
+func (h *gatewayHandlerAutoModerationRuleUpdate) New() any {
+	return &discord.AutoModerationRule{}
+}
+
+func (h *gatewayHandlerAutoModerationRuleUpdate) HandleGatewayEvent(client bot.Client, sequenceNumber int, shardID int, v any) {
+	autoModerationRule := *v.(*discord.AutoModerationRule)
+
+	oldAutoModerationRule, _ := client.Caches().AutoModerationRules().Get(autoModerationRule.GuildID, autoModerationRule.ID)
+	client.Caches().AutoModerationRules().Put(autoModerationRule.GuildID, autoModerationRule.ID, autoModerationRule)
+
+	client.EventManager().DispatchEvent(&events.AutoModerationRuleUpdate{
+		GenericAutoModerationRule: &events.GenericAutoModerationRule{
+			GenericEvent:       events.NewGenericEvent(client, sequenceNumber, shardID),
+			AutoModerationRule: autoModerationRule,
+		},
+		OldAutoModerationRule: oldAutoModerationRule,
+	})
+}
@@ -40,6 +40,7 @@ func (h *gatewayHandlerGuildDelete) HandleGatewayEvent(client bot.Client, sequen
 	client.Caches().Stickers().RemoveAll(unavailableGuild.ID)
 	client.Caches().Roles().RemoveAll(unavailableGuild.ID)
 	client.Caches().StageInstances().RemoveAll(unavailableGuild.ID)
+	client.Caches().AutoModerationRules().RemoveAll(unavailableGuild.ID)
 
 	client.Caches().Messages().RemoveIf(func(channelID snowflake.ID, message discord.Message) bool {
 		return message.GuildID != nil && *message.GuildID == unavailableGuild.ID
@@ -19,6 +19,10 @@ func (h *gatewayHandlerVoiceServerUpdate) New() any {
 func (h *gatewayHandlerVoiceServerUpdate) HandleGatewayEvent(client bot.Client, sequenceNumber int, shardID int, v any) {
 	payload := *v.(*discord.VoiceServerUpdate)
 
+	if voiceManager := client.VoiceManager(); voiceManager != nil {
+		voiceManager.HandleVoiceServerUpdate(payload)
+	}
+
 	client.EventManager().DispatchEvent(&events.VoiceServerUpdate{
 		GenericEvent:      events.NewGenericEvent(client, sequenceNumber, shardID),
 		VoiceServerUpdate: payload,
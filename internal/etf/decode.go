@@ -0,0 +1,533 @@
+package etf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"unicode/utf8"
+)
+
+// ToJSON transcodes the given ETF encoded term into its JSON representation.
+//
+// Atoms nil & null are written as null, true & false as booleans and all other atoms as strings.
+// Binaries are written as strings, maps as objects and lists & tuples as arrays.
+// Integers are written as strings if they can't be represented exactly as a float64, or if they are the value of a map key which holds snowflakes in Discord payloads (see isSnowflakeKey).
+// Discord sends snowflakes as integers over ETF, but snowflake.ID only unmarshals them from JSON strings.
+func ToJSON(data []byte) ([]byte, error) {
+	if len(data) == 0 || data[0] != Version {
+		return nil, ErrInvalidVersion
+	}
+	d := decoder{data: data, pos: 1}
+	buf := bytes.NewBuffer(make([]byte, 0, len(data)*2))
+	if err := d.term(buf); err != nil {
+		return nil, err
+	}
+	if d.pos != len(d.data) {
+		return nil, ErrTrailingData
+	}
+	return buf.Bytes(), nil
+}
+
+// ToJSONObject transcodes the given ETF encoded map like ToJSON, but returns the JSON representation of each value by its key.
+// Callers can read single fields like the op code without parsing the JSON of the whole map again.
+func ToJSONObject(data []byte) (map[string]json.RawMessage, error) {
+	if len(data) == 0 || data[0] != Version {
+		return nil, ErrInvalidVersion
+	}
+	if len(data) < 2 || data[1] != tagMap {
+		// compressed terms & other terms are rare, so they take the slower path
+		rawJSON, err := ToJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		var fields map[string]json.RawMessage
+		if err = json.Unmarshal(rawJSON, &fields); err != nil {
+			return nil, err
+		}
+		return fields, nil
+	}
+
+	d := decoder{data: data, pos: 2}
+	n, err := d.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]json.RawMessage, n)
+	var key bytes.Buffer
+	for i := 0; i < int(n); i++ {
+		key.Reset()
+		if err = d.key(&key); err != nil {
+			return nil, err
+		}
+		var k string
+		if err = json.Unmarshal(key.Bytes(), &k); err != nil {
+			return nil, err
+		}
+		value := bytes.NewBuffer(make([]byte, 0, 16))
+		if err = d.term(value); err != nil {
+			return nil, err
+		}
+		fields[k] = value.Bytes()
+	}
+	if d.pos != len(d.data) {
+		return nil, ErrTrailingData
+	}
+	return fields, nil
+}
+
+type decoder struct {
+	data    []byte
+	pos     int
+	scratch [64]byte
+	// snowflakes is true while decoding the value of a map key which holds snowflakes
+	snowflakes bool
+}
+
+func (d *decoder) read(n int) ([]byte, error) {
+	if n < 0 || len(d.data)-d.pos < n {
+		return nil, ErrUnexpectedEOF
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *decoder) readUint8() (uint8, error) {
+	b, err := d.read(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (d *decoder) readUint16() (uint16, error) {
+	b, err := d.read(2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint16(b), nil
+}
+
+func (d *decoder) readUint32() (uint32, error) {
+	b, err := d.read(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(b), nil
+}
+
+func (d *decoder) term(buf *bytes.Buffer) error {
+	tag, err := d.readUint8()
+	if err != nil {
+		return err
+	}
+
+	switch tag {
+	case tagSmallInteger:
+		i, err := d.readUint8()
+		if err != nil {
+			return err
+		}
+		d.writeInt(buf, false, uint64(i))
+		return nil
+
+	case tagInteger:
+		u, err := d.readUint32()
+		if err != nil {
+			return err
+		}
+		if i := int64(int32(u)); i < 0 {
+			d.writeInt(buf, true, uint64(-i))
+		} else {
+			d.writeInt(buf, false, uint64(i))
+		}
+		return nil
+
+	case tagNewFloat:
+		b, err := d.read(8)
+		if err != nil {
+			return err
+		}
+		d.writeFloat(buf, math.Float64frombits(binary.BigEndian.Uint64(b)))
+		return nil
+
+	case tagFloat:
+		b, err := d.read(31)
+		if err != nil {
+			return err
+		}
+		f, err := strconv.ParseFloat(string(bytes.TrimRight(b, "\x00")), 64)
+		if err != nil {
+			return err
+		}
+		d.writeFloat(buf, f)
+		return nil
+
+	case tagAtom, tagAtomUTF8:
+		n, err := d.readUint16()
+		if err != nil {
+			return err
+		}
+		return d.atom(buf, int(n))
+
+	case tagSmallAtom, tagSmallAtomUTF8:
+		n, err := d.readUint8()
+		if err != nil {
+			return err
+		}
+		return d.atom(buf, int(n))
+
+	case tagBinary:
+		n, err := d.readUint32()
+		if err != nil {
+			return err
+		}
+		b, err := d.read(int(n))
+		if err != nil {
+			return err
+		}
+		writeString(buf, b)
+		return nil
+
+	case tagString:
+		// STRING_EXT is a list of bytes, Discord uses it for small integer lists like the shard array
+		n, err := d.readUint16()
+		if err != nil {
+			return err
+		}
+		b, err := d.read(int(n))
+		if err != nil {
+			return err
+		}
+		buf.WriteByte('[')
+		for i, c := range b {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			d.writeInt(buf, false, uint64(c))
+		}
+		buf.WriteByte(']')
+		return nil
+
+	case tagNil:
+		buf.WriteString("[]")
+		return nil
+
+	case tagList:
+		n, err := d.readUint32()
+		if err != nil {
+			return err
+		}
+		if err = d.array(buf, int(n)); err != nil {
+			return err
+		}
+		// proper lists end with NIL_EXT as tail, improper list tails are not representable, so we ignore them
+		return d.skipTail()
+
+	case tagSmallTuple:
+		n, err := d.readUint8()
+		if err != nil {
+			return err
+		}
+		return d.array(buf, int(n))
+
+	case tagLargeTuple:
+		n, err := d.readUint32()
+		if err != nil {
+			return err
+		}
+		return d.array(buf, int(n))
+
+	case tagMap:
+		n, err := d.readUint32()
+		if err != nil {
+			return err
+		}
+		return d.object(buf, int(n))
+
+	case tagSmallBig:
+		n, err := d.readUint8()
+		if err != nil {
+			return err
+		}
+		return d.bigInt(buf, int(n))
+
+	case tagLargeBig:
+		n, err := d.readUint32()
+		if err != nil {
+			return err
+		}
+		return d.bigInt(buf, int(n))
+
+	case tagCompressed:
+		size, err := d.readUint32()
+		if err != nil {
+			return err
+		}
+		r, err := zlib.NewReader(bytes.NewReader(d.data[d.pos:]))
+		if err != nil {
+			return err
+		}
+		// size comes from the data, so don't allocate it upfront. The buffer only grows with the data which actually inflates
+		var inflated bytes.Buffer
+		n, err := inflated.ReadFrom(io.LimitReader(r, int64(size)))
+		if err != nil {
+			return err
+		}
+		if n != int64(size) {
+			return ErrUnexpectedEOF
+		}
+		sub := decoder{data: inflated.Bytes(), snowflakes: d.snowflakes}
+		if err = sub.term(buf); err != nil {
+			return err
+		}
+		// a compressed term is always the last term in the data
+		d.pos = len(d.data)
+		return nil
+	}
+	return UnsupportedTagError{Tag: tag}
+}
+
+func (d *decoder) skipTail() error {
+	tag, err := d.readUint8()
+	if err != nil {
+		return err
+	}
+	if tag == tagNil {
+		return nil
+	}
+	d.pos--
+	var discard bytes.Buffer
+	return d.term(&discard)
+}
+
+func (d *decoder) atom(buf *bytes.Buffer, n int) error {
+	b, err := d.read(n)
+	if err != nil {
+		return err
+	}
+	switch string(b) {
+	case "nil", "null":
+		buf.WriteString("null")
+	case "true":
+		buf.WriteString("true")
+	case "false":
+		buf.WriteString("false")
+	default:
+		writeString(buf, b)
+	}
+	return nil
+}
+
+func (d *decoder) array(buf *bytes.Buffer, n int) error {
+	buf.WriteByte('[')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := d.term(buf); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte(']')
+	return nil
+}
+
+func (d *decoder) object(buf *bytes.Buffer, n int) error {
+	snowflakes := d.snowflakes
+	defer func() {
+		d.snowflakes = snowflakes
+	}()
+
+	buf.WriteByte('{')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		start := buf.Len()
+		if err := d.key(buf); err != nil {
+			return err
+		}
+		d.snowflakes = isSnowflakeKey(buf.Bytes()[start:])
+		buf.WriteByte(':')
+		if err := d.term(buf); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// snowflakeKeys are the map keys without an _id or _ids suffix which hold snowflakes in Discord payloads.
+var snowflakeKeys = map[string]struct{}{
+	`"id"`:              {},
+	`"roles"`:           {},
+	`"mention_roles"`:   {},
+	`"exempt_roles"`:    {},
+	`"exempt_channels"`: {},
+	`"applied_tags"`:    {},
+}
+
+// isSnowflakeKey reports whether the given JSON encoded map key holds snowflakes in Discord payloads.
+// The integers of its value are written as strings, so they can be unmarshalled into snowflake.ID.
+// Lists of objects like the roles of a guild are fine, as the keys of each object decide for their own values.
+func isSnowflakeKey(key []byte) bool {
+	if bytes.HasSuffix(key, []byte(`_id"`)) || bytes.HasSuffix(key, []byte(`_ids"`)) {
+		return true
+	}
+	_, ok := snowflakeKeys[string(key)]
+	return ok
+}
+
+// key writes a map key, JSON object keys are always strings so non string terms are transcoded and quoted.
+func (d *decoder) key(buf *bytes.Buffer) error {
+	if d.pos < len(d.data) {
+		var (
+			n   int
+			err error
+		)
+		switch d.data[d.pos] {
+		case tagBinary:
+			return d.term(buf)
+		case tagAtom, tagAtomUTF8:
+			d.pos++
+			var u uint16
+			u, err = d.readUint16()
+			n = int(u)
+		case tagSmallAtom, tagSmallAtomUTF8:
+			d.pos++
+			var u uint8
+			u, err = d.readUint8()
+			n = int(u)
+		default:
+			var key bytes.Buffer
+			if err = d.term(&key); err != nil {
+				return err
+			}
+			// terms like big integers are already written as JSON strings and must not be escaped again
+			if b := key.Bytes(); len(b) > 0 && b[0] == '"' {
+				buf.Write(b)
+				return nil
+			}
+			writeString(buf, key.Bytes())
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		b, err := d.read(n)
+		if err != nil {
+			return err
+		}
+		writeString(buf, b)
+		return nil
+	}
+	return ErrUnexpectedEOF
+}
+
+func (d *decoder) bigInt(buf *bytes.Buffer, n int) error {
+	sign, err := d.readUint8()
+	if err != nil {
+		return err
+	}
+	b, err := d.read(n)
+	if err != nil {
+		return err
+	}
+
+	if n <= 8 {
+		var u uint64
+		for i := n - 1; i >= 0; i-- {
+			u = u<<8 | uint64(b[i])
+		}
+		d.writeInt(buf, sign != 0, u)
+		return nil
+	}
+
+	// big.Int expects big endian bytes
+	be := make([]byte, n)
+	for i := range b {
+		be[n-1-i] = b[i]
+	}
+	i := new(big.Int).SetBytes(be)
+	if sign != 0 {
+		i.Neg(i)
+	}
+	buf.WriteByte('"')
+	buf.WriteString(i.String())
+	buf.WriteByte('"')
+	return nil
+}
+
+// writeInt writes the integer with the absolute value u as number, or as string if it is a snowflake or can't be represented exactly as a float64.
+func (d *decoder) writeInt(buf *bytes.Buffer, negative bool, u uint64) {
+	quote := d.snowflakes || u > maxSafeInteger
+	if quote {
+		buf.WriteByte('"')
+	}
+	if negative {
+		buf.WriteByte('-')
+	}
+	buf.Write(strconv.AppendUint(d.scratch[:0], u, 10))
+	if quote {
+		buf.WriteByte('"')
+	}
+}
+
+func (d *decoder) writeFloat(buf *bytes.Buffer, f float64) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		buf.WriteString("null")
+		return
+	}
+	buf.Write(strconv.AppendFloat(d.scratch[:0], f, 'g', -1, 64))
+}
+
+const hex = "0123456789abcdef"
+
+func writeString(buf *bytes.Buffer, s []byte) {
+	buf.WriteByte('"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' {
+				i++
+				continue
+			}
+			buf.Write(s[start:i])
+			switch c {
+			case '"', '\\':
+				buf.WriteByte('\\')
+				buf.WriteByte(c)
+			case '\n':
+				buf.WriteString(`\n`)
+			case '\r':
+				buf.WriteString(`\r`)
+			case '\t':
+				buf.WriteString(`\t`)
+			default:
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hex[c>>4])
+				buf.WriteByte(hex[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRune(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf.Write(s[start:i])
+			buf.WriteString(`\ufffd`)
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	buf.Write(s[start:])
+	buf.WriteByte('"')
+}
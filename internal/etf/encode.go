@@ -0,0 +1,148 @@
+package etf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// FromJSON transcodes the given JSON value into an ETF encoded term.
+//
+// null is written as the atom nil, booleans as atoms, strings as binaries, objects as maps with binary keys and arrays as lists.
+// Integers are written as the smallest fitting integer term, all other numbers as floats.
+func FromJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, len(data)))
+	buf.WriteByte(Version)
+	if err := encode(buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encode(buf *bytes.Buffer, v any) error {
+	switch v := v.(type) {
+	case nil:
+		writeAtom(buf, "nil")
+
+	case bool:
+		if v {
+			writeAtom(buf, "true")
+		} else {
+			writeAtom(buf, "false")
+		}
+
+	case json.Number:
+		return writeNumber(buf, v)
+
+	case string:
+		buf.WriteByte(tagBinary)
+		writeUint32(buf, uint32(len(v)))
+		buf.WriteString(v)
+
+	case []any:
+		if len(v) == 0 {
+			buf.WriteByte(tagNil)
+			return nil
+		}
+		buf.WriteByte(tagList)
+		writeUint32(buf, uint32(len(v)))
+		for _, e := range v {
+			if err := encode(buf, e); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(tagNil)
+
+	case map[string]any:
+		buf.WriteByte(tagMap)
+		writeUint32(buf, uint32(len(v)))
+		for key, value := range v {
+			buf.WriteByte(tagBinary)
+			writeUint32(buf, uint32(len(key)))
+			buf.WriteString(key)
+			if err := encode(buf, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeAtom(buf *bytes.Buffer, atom string) {
+	buf.WriteByte(tagSmallAtomUTF8)
+	buf.WriteByte(uint8(len(atom)))
+	buf.WriteString(atom)
+}
+
+func writeNumber(buf *bytes.Buffer, n json.Number) error {
+	s := n.String()
+	if !strings.ContainsAny(s, ".eE") {
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			writeInt(buf, i)
+			return nil
+		}
+		if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+			writeBig(buf, 0, u)
+			return nil
+		}
+	}
+
+	f, err := n.Float64()
+	if err != nil {
+		return err
+	}
+	buf.WriteByte(tagNewFloat)
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], math.Float64bits(f))
+	buf.Write(b[:])
+	return nil
+}
+
+func writeInt(buf *bytes.Buffer, i int64) {
+	switch {
+	case i >= 0 && i <= math.MaxUint8:
+		buf.WriteByte(tagSmallInteger)
+		buf.WriteByte(uint8(i))
+
+	case i >= math.MinInt32 && i <= math.MaxInt32:
+		buf.WriteByte(tagInteger)
+		writeUint32(buf, uint32(int32(i)))
+
+	case i < 0:
+		writeBig(buf, 1, uint64(-i))
+
+	default:
+		writeBig(buf, 0, uint64(i))
+	}
+}
+
+func writeBig(buf *bytes.Buffer, sign uint8, u uint64) {
+	var digits [8]byte
+	n := 0
+	for u > 0 {
+		digits[n] = byte(u)
+		u >>= 8
+		n++
+	}
+	buf.WriteByte(tagSmallBig)
+	buf.WriteByte(uint8(n))
+	buf.WriteByte(sign)
+	buf.Write(digits[:n])
+}
+
+func writeUint32(buf *bytes.Buffer, u uint32) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], u)
+	buf.Write(b[:])
+}
@@ -0,0 +1,55 @@
+// Package etf implements the Erlang External Term Format used by the Discord gateway when connecting with encoding=etf.
+// Terms are transcoded from and to JSON, so the rest of disgo can keep working with the same payloads regardless of the wire format.
+package etf
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Version is the version byte every ETF encoded term starts with.
+const Version = 131
+
+const (
+	tagNewFloat      = 70
+	tagCompressed    = 80
+	tagSmallInteger  = 97
+	tagInteger       = 98
+	tagFloat         = 99
+	tagAtom          = 100
+	tagSmallTuple    = 104
+	tagLargeTuple    = 105
+	tagNil           = 106
+	tagString        = 107
+	tagList          = 108
+	tagBinary        = 109
+	tagSmallBig      = 110
+	tagLargeBig      = 111
+	tagSmallAtom     = 115
+	tagMap           = 116
+	tagAtomUTF8      = 118
+	tagSmallAtomUTF8 = 119
+
+	// maxSafeInteger is the biggest integer a float64 can represent exactly.
+	maxSafeInteger = 1<<53 - 1
+)
+
+var (
+	// ErrInvalidVersion is returned when the data does not start with the ETF Version byte.
+	ErrInvalidVersion = errors.New("etf: invalid version byte")
+
+	// ErrUnexpectedEOF is returned when the data ends in the middle of a term.
+	ErrUnexpectedEOF = errors.New("etf: unexpected end of data")
+
+	// ErrTrailingData is returned when there is data left after the top level term.
+	ErrTrailingData = errors.New("etf: trailing data after term")
+)
+
+// UnsupportedTagError is returned when a term with a tag which has no JSON representation is encountered.
+type UnsupportedTagError struct {
+	Tag byte
+}
+
+func (e UnsupportedTagError) Error() string {
+	return "etf: unsupported term tag " + strconv.Itoa(int(e.Tag))
+}
@@ -0,0 +1,88 @@
+package etf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundTrip(t *testing.T) {
+	cases := []string{
+		`null`,
+		`true`,
+		`{"op":10,"d":{"heartbeat_interval":41250}}`,
+		`{"op":0,"s":1,"t":"READY","d":{"session_id":"abc","shard":[0,1],"user":{"id":"1007331446009704488","bot":true}}}`,
+		`[1,-1,300,-300,2147483648,1.5,"a\"b\\c\n"]`,
+		`[]`,
+	}
+
+	for _, c := range cases {
+		data, err := FromJSON([]byte(c))
+		assert.NoError(t, err)
+
+		rawJSON, err := ToJSON(data)
+		assert.NoError(t, err)
+		assert.JSONEq(t, c, string(rawJSON))
+	}
+}
+
+func TestToJSON(t *testing.T) {
+	cases := []struct {
+		data []byte
+		json string
+	}{
+		// atom nil
+		{[]byte{131, 119, 3, 'n', 'i', 'l'}, `null`},
+		// STRING_EXT is decoded as list of integers
+		{[]byte{131, 107, 0, 2, 0, 1}, `[0,1]`},
+		// snowflakes are sent as SMALL_BIG_EXT and need to be decoded as strings
+		{[]byte{131, 110, 8, 0, 104, 48, 125, 242, 156, 194, 250, 13}, `"1007331446009704552"`},
+		// small big ints which fit into a float64 stay numbers
+		{[]byte{131, 110, 4, 1, 0, 0, 0, 128}, `-2147483648`},
+		// map with atom key
+		{[]byte{131, 116, 0, 0, 0, 1, 100, 0, 2, 'o', 'p', 97, 11}, `{"op":11}`},
+		// map with a snowflake as key is not escaped twice
+		{[]byte{131, 116, 0, 0, 0, 1, 110, 8, 0, 104, 48, 125, 242, 156, 194, 250, 13, 97, 1}, `{"1007331446009704552":1}`},
+		// snowflakes which fit into a float64 are still decoded as strings
+		{[]byte{131, 116, 0, 0, 0, 3,
+			100, 0, 2, 'i', 'd', 97, 5,
+			100, 0, 5, 'r', 'o', 'l', 'e', 's', 107, 0, 2, 1, 2,
+			100, 0, 5, 'f', 'l', 'a', 'g', 's', 98, 0, 1, 0, 0,
+		}, `{"id":"5","roles":["1","2"],"flags":65536}`},
+		// compressed term
+		{[]byte{131, 80, 0, 0, 0, 2, 120, 156, 75, 100, 4, 0, 0, 197, 0, 99}, `1`},
+	}
+
+	for _, c := range cases {
+		rawJSON, err := ToJSON(c.data)
+		assert.NoError(t, err)
+		assert.Equal(t, c.json, string(rawJSON))
+	}
+}
+
+func TestToJSONInvalid(t *testing.T) {
+	_, err := ToJSON([]byte{130, 106})
+	assert.ErrorIs(t, err, ErrInvalidVersion)
+
+	_, err = ToJSON([]byte{131, 109, 0, 0, 0, 5, 'a'})
+	assert.ErrorIs(t, err, ErrUnexpectedEOF)
+
+	// the uncompressed size is bigger than the data inflates to
+	_, err = ToJSON([]byte{131, 80, 255, 255, 255, 255, 120, 156, 75, 100, 4, 0, 0, 197, 0, 99})
+	assert.Error(t, err)
+}
+
+func TestToJSONObject(t *testing.T) {
+	data, err := FromJSON([]byte(`{"op":0,"s":42,"t":"GUILD_CREATE","d":{"id":"1007331446009704488","name":"test"}}`))
+	assert.NoError(t, err)
+
+	fields, err := ToJSONObject(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "0", string(fields["op"]))
+	assert.Equal(t, "42", string(fields["s"]))
+	assert.Equal(t, `"GUILD_CREATE"`, string(fields["t"]))
+	assert.JSONEq(t, `{"id":"1007331446009704488","name":"test"}`, string(fields["d"]))
+
+	_, err = ToJSONObject([]byte{Version, tagMap, 0, 0})
+	assert.ErrorIs(t, err, ErrUnexpectedEOF)
+}
@@ -0,0 +1,61 @@
+// Package singleflight collapses concurrent calls for the same key into one.
+package singleflight
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrPanicked is returned to the callers waiting on a function which panicked.
+var ErrPanicked = errors.New("singleflight: function panicked")
+
+type call[V any] struct {
+	wg  sync.WaitGroup
+	val V
+	err error
+}
+
+// Group runs at most one function per key at a time. The zero value is ready to use.
+type Group[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*call[V]
+}
+
+// Do runs the given function for the key and returns its result.
+// If a call for the key is already running, Do waits for it and returns its result instead, in which case shared is true.
+// If the function panics, the waiting callers get ErrPanicked and the panic is passed on to the caller which ran it.
+func (g *Group[K, V]) Do(key K, fn func() (V, error)) (v V, err error, shared bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[K]*call[V]{}
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.val, c.err, true
+	}
+	c := &call[V]{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	returned := false
+	defer func() {
+		var r any
+		if !returned {
+			r = recover()
+			c.err = fmt.Errorf("%w: %v", ErrPanicked, r)
+		}
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		c.wg.Done()
+		if !returned {
+			panic(r)
+		}
+	}()
+	c.val, c.err = fn()
+	returned = true
+	return c.val, c.err, false
+}
@@ -0,0 +1,94 @@
+package singleflight
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupDoCollapsesCalls(t *testing.T) {
+	var (
+		g       Group[int, string]
+		calls   int32
+		release = make(chan struct{})
+		wg      sync.WaitGroup
+	)
+	started := make(chan struct{})
+	go func() {
+		_, _, _ = g.Do(1, func() (string, error) {
+			atomic.AddInt32(&calls, 1)
+			close(started)
+			<-release
+			return "value", nil
+		})
+	}()
+	<-started
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err, shared := g.Do(1, func() (string, error) {
+				atomic.AddInt32(&calls, 1)
+				return "other", nil
+			})
+			assert.NoError(t, err)
+			assert.True(t, shared)
+			assert.Equal(t, "value", v)
+		}()
+	}
+	// give the other calls time to join the running one
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestGroupDoAfterCall(t *testing.T) {
+	var g Group[int, string]
+	testErr := errors.New("test")
+
+	_, err, _ := g.Do(1, func() (string, error) { return "", testErr })
+	assert.ErrorIs(t, err, testErr)
+
+	v, err, shared := g.Do(1, func() (string, error) { return "value", nil })
+	assert.NoError(t, err)
+	assert.False(t, shared)
+	assert.Equal(t, "value", v)
+}
+
+func TestGroupDoPanic(t *testing.T) {
+	var g Group[int, string]
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	panicked := make(chan any)
+	go func() {
+		defer func() { panicked <- recover() }()
+		_, _, _ = g.Do(1, func() (string, error) {
+			close(started)
+			<-release
+			panic("test")
+		})
+	}()
+	<-started
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		v, err, shared := g.Do(1, func() (string, error) { return "other", nil })
+		assert.ErrorIs(t, err, ErrPanicked)
+		assert.True(t, shared)
+		assert.Empty(t, v)
+	}()
+	// give the other call time to join the running one
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+
+	assert.Equal(t, "test", <-panicked)
+	<-done
+}
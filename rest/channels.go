@@ -29,6 +29,8 @@ type Channels interface {
 
 	GetMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (*discord.Message, error)
 	GetMessages(channelID snowflake.ID, around snowflake.ID, before snowflake.ID, after snowflake.ID, limit int, opts ...RequestOpt) ([]discord.Message, error)
+	// GetMessagesPage returns a Page of the discord.Message(s) in the channel starting at the given message.
+	GetMessagesPage(channelID snowflake.ID, startID snowflake.ID, opts ...PageConfigOpt) *Page[discord.Message]
 	CreateMessage(channelID snowflake.ID, messageCreate discord.MessageCreate, opts ...RequestOpt) (*discord.Message, error)
 	UpdateMessage(channelID snowflake.ID, messageID snowflake.ID, messageUpdate discord.MessageUpdate, opts ...RequestOpt) (*discord.Message, error)
 	DeleteMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) error
@@ -36,6 +38,8 @@ type Channels interface {
 	CrosspostMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (*discord.Message, error)
 
 	GetReactions(channelID snowflake.ID, messageID snowflake.ID, emoji string, opts ...RequestOpt) ([]discord.User, error)
+	// GetReactionsPage returns a Page of the discord.User(s) which reacted with the given emoji. It only supports PageDirectionAfter.
+	GetReactionsPage(channelID snowflake.ID, messageID snowflake.ID, emoji string, startID snowflake.ID, opts ...PageConfigOpt) *Page[discord.User]
 	AddReaction(channelID snowflake.ID, messageID snowflake.ID, emoji string, opts ...RequestOpt) error
 	RemoveOwnReaction(channelID snowflake.ID, messageID snowflake.ID, emoji string, opts ...RequestOpt) error
 	RemoveUserReaction(channelID snowflake.ID, messageID snowflake.ID, emoji string, userID snowflake.ID, opts ...RequestOpt) error
@@ -186,6 +190,23 @@ func (s *channelImpl) GetMessages(channelID snowflake.ID, around snowflake.ID, b
 	return
 }
 
+func (s *channelImpl) GetMessagesPage(channelID snowflake.ID, startID snowflake.ID, opts ...PageConfigOpt) *Page[discord.Message] {
+	return newIDPage(startID, 100, opts, pageDirectionsAll,
+		func(message discord.Message) snowflake.ID {
+			return message.ID
+		},
+		func(values route.QueryValues, opts []RequestOpt) (messages []discord.Message, err error) {
+			var compiledRoute *route.CompiledAPIRoute
+			compiledRoute, err = route.GetMessages.Compile(values, channelID)
+			if err != nil {
+				return
+			}
+			err = s.client.Do(compiledRoute, nil, &messages, opts...)
+			return
+		},
+	)
+}
+
 func (s *channelImpl) CreateMessage(channelID snowflake.ID, messageCreate discord.MessageCreate, opts ...RequestOpt) (message *discord.Message, err error) {
 	var compiledRoute *route.CompiledAPIRoute
 	compiledRoute, err = route.CreateMessage.Compile(nil, channelID)
@@ -250,6 +271,23 @@ func (s *channelImpl) GetReactions(channelID snowflake.ID, messageID snowflake.I
 	return
 }
 
+func (s *channelImpl) GetReactionsPage(channelID snowflake.ID, messageID snowflake.ID, emoji string, startID snowflake.ID, opts ...PageConfigOpt) *Page[discord.User] {
+	return newIDPage(startID, 100, opts, pageDirectionsAfter,
+		func(user discord.User) snowflake.ID {
+			return user.ID
+		},
+		func(values route.QueryValues, opts []RequestOpt) (users []discord.User, err error) {
+			var compiledRoute *route.CompiledAPIRoute
+			compiledRoute, err = route.GetReactions.Compile(values, channelID, messageID, emoji)
+			if err != nil {
+				return
+			}
+			err = s.client.Do(compiledRoute, nil, &users, opts...)
+			return
+		},
+	)
+}
+
 func (s *channelImpl) AddReaction(channelID snowflake.ID, messageID snowflake.ID, emoji string, opts ...RequestOpt) error {
 	compiledRoute, err := route.AddReaction.Compile(nil, channelID, messageID, emoji)
 	if err != nil {
@@ -20,6 +20,8 @@ type GuildScheduledEvents interface {
 	DeleteGuildScheduledEvent(guildID snowflake.ID, guildScheduledEventID snowflake.ID, opts ...RequestOpt) error
 
 	GetGuildScheduledEventUsers(guildID snowflake.ID, guildScheduledEventID snowflake.ID, limit int, withMember bool, before snowflake.ID, after snowflake.ID, opts ...RequestOpt) ([]discord.GuildScheduledEventUser, error)
+	// GetGuildScheduledEventUsersPage returns a Page of the discord.GuildScheduledEventUser(s) subscribed to the discord.GuildScheduledEvent starting at the given user.
+	GetGuildScheduledEventUsersPage(guildID snowflake.ID, guildScheduledEventID snowflake.ID, withMember bool, startID snowflake.ID, opts ...PageConfigOpt) *Page[discord.GuildScheduledEventUser]
 }
 
 type guildScheduledEventImpl struct {
@@ -88,7 +90,7 @@ func (s *guildScheduledEventImpl) GetGuildScheduledEventUsers(guildID snowflake.
 		queryValues["limit"] = limit
 	}
 	if withMember {
-		queryValues["withMember"] = true
+		queryValues["with_member"] = true
 	}
 	if before != 0 {
 		queryValues["before"] = before
@@ -98,10 +100,30 @@ func (s *guildScheduledEventImpl) GetGuildScheduledEventUsers(guildID snowflake.
 	}
 
 	var compiledRoute *route.CompiledAPIRoute
-	compiledRoute, err = route.GetGuildScheduledEventUsers.Compile(nil, guildID, guildScheduledEventID)
+	compiledRoute, err = route.GetGuildScheduledEventUsers.Compile(queryValues, guildID, guildScheduledEventID)
 	if err != nil {
 		return
 	}
 	err = s.client.Do(compiledRoute, nil, &guildScheduledEventUsers, opts...)
 	return
 }
+
+func (s *guildScheduledEventImpl) GetGuildScheduledEventUsersPage(guildID snowflake.ID, guildScheduledEventID snowflake.ID, withMember bool, startID snowflake.ID, opts ...PageConfigOpt) *Page[discord.GuildScheduledEventUser] {
+	return newIDPage(startID, 100, opts, pageDirectionsAll,
+		func(guildScheduledEventUser discord.GuildScheduledEventUser) snowflake.ID {
+			return guildScheduledEventUser.User.ID
+		},
+		func(values route.QueryValues, opts []RequestOpt) (guildScheduledEventUsers []discord.GuildScheduledEventUser, err error) {
+			if withMember {
+				values["with_member"] = true
+			}
+			var compiledRoute *route.CompiledAPIRoute
+			compiledRoute, err = route.GetGuildScheduledEventUsers.Compile(values, guildID, guildScheduledEventID)
+			if err != nil {
+				return
+			}
+			err = s.client.Do(compiledRoute, nil, &guildScheduledEventUsers, opts...)
+			return
+		},
+	)
+}
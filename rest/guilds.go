@@ -31,6 +31,8 @@ type Guilds interface {
 	DeleteRole(guildID snowflake.ID, roleID snowflake.ID, opts ...RequestOpt) error
 
 	GetBans(guildID snowflake.ID, before snowflake.ID, after snowflake.ID, limit int, opts ...RequestOpt) ([]discord.Ban, error)
+	// GetBansPage returns a Page of the discord.Ban(s) of the guild starting at the given user.
+	GetBansPage(guildID snowflake.ID, startID snowflake.ID, opts ...PageConfigOpt) *Page[discord.Ban]
 	GetBan(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) (*discord.Ban, error)
 	AddBan(guildID snowflake.ID, userID snowflake.ID, deleteMessageDays int, opts ...RequestOpt) error
 	DeleteBan(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) error
@@ -41,6 +43,9 @@ type Guilds interface {
 	GetAllWebhooks(guildID snowflake.ID, opts ...RequestOpt) ([]discord.Webhook, error)
 
 	GetAuditLog(guildID snowflake.ID, userID snowflake.ID, actionType discord.AuditLogEvent, before snowflake.ID, limit int, opts ...RequestOpt) (*discord.AuditLog, error)
+	// GetAuditLogPage returns a Page of the discord.AuditLogEntry(s) of the guild starting at the given entry.
+	// userID & actionType are optional filters. Use GetAuditLog if you need the users, webhooks etc. referenced by the entries.
+	GetAuditLogPage(guildID snowflake.ID, userID snowflake.ID, actionType discord.AuditLogEvent, startID snowflake.ID, opts ...PageConfigOpt) *Page[discord.AuditLogEntry]
 }
 
 type guildImpl struct {
@@ -209,6 +214,23 @@ func (s *guildImpl) GetBans(guildID snowflake.ID, before snowflake.ID, after sno
 	return
 }
 
+func (s *guildImpl) GetBansPage(guildID snowflake.ID, startID snowflake.ID, opts ...PageConfigOpt) *Page[discord.Ban] {
+	return newIDPage(startID, 1000, opts, pageDirectionsAll,
+		func(ban discord.Ban) snowflake.ID {
+			return ban.User.ID
+		},
+		func(values route.QueryValues, opts []RequestOpt) (bans []discord.Ban, err error) {
+			var compiledRoute *route.CompiledAPIRoute
+			compiledRoute, err = route.GetBans.Compile(values, guildID)
+			if err != nil {
+				return
+			}
+			err = s.client.Do(compiledRoute, nil, &bans, opts...)
+			return
+		},
+	)
+}
+
 func (s *guildImpl) GetBan(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) (ban *discord.Ban, err error) {
 	var compiledRoute *route.CompiledAPIRoute
 	compiledRoute, err = route.GetBan.Compile(nil, guildID, userID)
@@ -282,7 +304,7 @@ func (s *guildImpl) GetAuditLog(guildID snowflake.ID, userID snowflake.ID, actio
 		values["action_type"] = actionType
 	}
 	if before != 0 {
-		values["before"] = guildID
+		values["before"] = before
 	}
 	if limit != 0 {
 		values["limit"] = limit
@@ -295,3 +317,30 @@ func (s *guildImpl) GetAuditLog(guildID snowflake.ID, userID snowflake.ID, actio
 	err = s.client.Do(compiledRoute, nil, &auditLog, opts...)
 	return
 }
+
+func (s *guildImpl) GetAuditLogPage(guildID snowflake.ID, userID snowflake.ID, actionType discord.AuditLogEvent, startID snowflake.ID, opts ...PageConfigOpt) *Page[discord.AuditLogEntry] {
+	return newIDPage(startID, 100, opts, pageDirectionsAll,
+		func(entry discord.AuditLogEntry) snowflake.ID {
+			return entry.ID
+		},
+		func(values route.QueryValues, opts []RequestOpt) (entries []discord.AuditLogEntry, err error) {
+			if userID != 0 {
+				values["user_id"] = userID
+			}
+			if actionType != 0 {
+				values["action_type"] = actionType
+			}
+			var compiledRoute *route.CompiledAPIRoute
+			compiledRoute, err = route.GetAuditLogs.Compile(values, guildID)
+			if err != nil {
+				return
+			}
+			var auditLog discord.AuditLog
+			err = s.client.Do(compiledRoute, nil, &auditLog, opts...)
+			if err == nil {
+				entries = auditLog.Entries
+			}
+			return
+		},
+	)
+}
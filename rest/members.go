@@ -15,6 +15,8 @@ func NewMembers(client Client) Members {
 type Members interface {
 	GetMember(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) (*discord.Member, error)
 	GetMembers(guildID snowflake.ID, opts ...RequestOpt) ([]discord.Member, error)
+	// GetMembersPage returns a Page of the discord.Member(s) of the guild starting at the given user. It only supports PageDirectionAfter.
+	GetMembersPage(guildID snowflake.ID, startID snowflake.ID, opts ...PageConfigOpt) *Page[discord.Member]
 	SearchMembers(guildID snowflake.ID, query string, limit int, opts ...RequestOpt) ([]discord.Member, error)
 	AddMember(guildID snowflake.ID, userID snowflake.ID, memberAdd discord.MemberAdd, opts ...RequestOpt) (*discord.Member, error)
 	RemoveMember(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) error
@@ -61,6 +63,28 @@ func (s *memberImpl) GetMembers(guildID snowflake.ID, opts ...RequestOpt) (membe
 	return
 }
 
+func (s *memberImpl) GetMembersPage(guildID snowflake.ID, startID snowflake.ID, opts ...PageConfigOpt) *Page[discord.Member] {
+	return newIDPage(startID, 1000, opts, pageDirectionsAfter,
+		func(member discord.Member) snowflake.ID {
+			return member.User.ID
+		},
+		func(values route.QueryValues, opts []RequestOpt) (members []discord.Member, err error) {
+			var compiledRoute *route.CompiledAPIRoute
+			compiledRoute, err = route.GetMembers.Compile(values, guildID)
+			if err != nil {
+				return
+			}
+			err = s.client.Do(compiledRoute, nil, &members, opts...)
+			if err == nil {
+				for i := range members {
+					members[i].GuildID = guildID
+				}
+			}
+			return
+		},
+	)
+}
+
 func (s *memberImpl) SearchMembers(guildID snowflake.ID, query string, limit int, opts ...RequestOpt) (members []discord.Member, err error) {
 	values := route.QueryValues{}
 	if query != "" {
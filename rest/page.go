@@ -0,0 +1,254 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/disgoorg/disgo/rest/route"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// ErrPageDirectionNotSupported is returned by Page.Err if the endpoint can't be paginated in the configured PageDirection.
+var ErrPageDirectionNotSupported = errors.New("page direction is not supported by this endpoint")
+
+// PageDirection is the direction in which a Page walks through the items of an endpoint.
+type PageDirection int
+
+const (
+	// PageDirectionBefore walks from newer to older items by using the before query param.
+	PageDirectionBefore PageDirection = iota
+
+	// PageDirectionAfter walks from older to newer items by using the after query param.
+	PageDirectionAfter
+)
+
+var (
+	pageDirectionsAll    = []PageDirection{PageDirectionBefore, PageDirectionAfter}
+	pageDirectionsBefore = []PageDirection{PageDirectionBefore}
+	pageDirectionsAfter  = []PageDirection{PageDirectionAfter}
+)
+
+// PageConfig is the configuration of a Page.
+// The default Direction & Limit depend on the endpoint.
+type PageConfig struct {
+	// Ctx is used for all requests of the Page and stops the Page when it's done.
+	Ctx context.Context
+	// Direction is the PageDirection the Page walks in.
+	Direction PageDirection
+	// Limit is the number of items requested per page. 0 lets discord decide.
+	Limit int
+	// MaxItems is the total number of items after which the Page stops. 0 means no limit.
+	MaxItems int
+	// RequestOpts are applied to all requests of the Page.
+	RequestOpts []RequestOpt
+}
+
+// PageConfigOpt can be used to supply optional parameters to a Page.
+type PageConfigOpt func(config *PageConfig)
+
+// Apply applies the given PageConfigOpt(s) to the PageConfig.
+func (c *PageConfig) Apply(opts []PageConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.Ctx == nil {
+		c.Ctx = context.TODO()
+	}
+}
+
+// WithPageCtx applies a custom context to all requests of the Page.
+func WithPageCtx(ctx context.Context) PageConfigOpt {
+	return func(config *PageConfig) {
+		config.Ctx = ctx
+	}
+}
+
+// WithPageDirection sets the PageDirection the Page walks in.
+func WithPageDirection(direction PageDirection) PageConfigOpt {
+	return func(config *PageConfig) {
+		config.Direction = direction
+	}
+}
+
+// WithPageLimit sets the number of items requested per page.
+func WithPageLimit(limit int) PageConfigOpt {
+	return func(config *PageConfig) {
+		config.Limit = limit
+	}
+}
+
+// WithPageMaxItems sets the total number of items after which the Page stops.
+func WithPageMaxItems(maxItems int) PageConfigOpt {
+	return func(config *PageConfig) {
+		config.MaxItems = maxItems
+	}
+}
+
+// WithPageRequestOpts applies the given RequestOpt(s) to all requests of the Page.
+func WithPageRequestOpts(opts ...RequestOpt) PageConfigOpt {
+	return func(config *PageConfig) {
+		config.RequestOpts = append(config.RequestOpts, opts...)
+	}
+}
+
+type (
+	// pageFetchFunc requests the next page with the given query values and returns its items & whether there are more items.
+	pageFetchFunc[T any] func(values route.QueryValues, opts []RequestOpt) (items []T, more bool, err error)
+
+	// pageCursorFunc returns the cursor of the page following the given items.
+	pageCursorFunc[T any] func(items []T, direction PageDirection) any
+)
+
+// Page is an iterator over the items of a cursor paginated endpoint.
+// It requests one page at a time while walking in the configured PageDirection:
+//
+//	page := client.Rest().GetMessagesPage(channelID, 0, rest.WithPageMaxItems(500))
+//	for page.Next() {
+//		for _, message := range page.Items {
+//			...
+//		}
+//	}
+//	if page.Err != nil {
+//		...
+//	}
+type Page[T any] struct {
+	// Items are the items of the current page.
+	Items []T
+	// Err is the error which stopped the Page if any.
+	Err error
+
+	config     PageConfig
+	fetchFunc  pageFetchFunc[T]
+	cursorFunc pageCursorFunc[T]
+	directions []PageDirection
+	cursor     any
+	fetched    int
+	done       bool
+}
+
+func newPage[T any](config PageConfig, opts []PageConfigOpt, cursor any, directions []PageDirection, fetchFunc pageFetchFunc[T], cursorFunc pageCursorFunc[T]) *Page[T] {
+	config.Apply(opts)
+	return &Page[T]{
+		config:     config,
+		fetchFunc:  fetchFunc,
+		cursorFunc: cursorFunc,
+		directions: directions,
+		cursor:     cursor,
+	}
+}
+
+// newIDPage creates a Page for endpoints which use snowflake.ID(s) as cursor.
+// If startID is 0, the Page starts at the newest item for PageDirectionBefore & at the oldest item for PageDirectionAfter.
+func newIDPage[T any](startID snowflake.ID, maxLimit int, opts []PageConfigOpt, directions []PageDirection, getID func(item T) snowflake.ID, fetchFunc func(values route.QueryValues, opts []RequestOpt) ([]T, error)) *Page[T] {
+	page := newPage(PageConfig{Direction: directions[0], Limit: maxLimit}, opts, nil, directions,
+		func(values route.QueryValues, opts []RequestOpt) ([]T, bool, error) {
+			limit, _ := values["limit"].(int)
+			items, err := fetchFunc(values, opts)
+			return items, limit == 0 || len(items) >= limit, err
+		},
+		func(items []T, direction PageDirection) any {
+			cursor := getID(items[0])
+			for _, item := range items[1:] {
+				id := getID(item)
+				if direction == PageDirectionBefore && id < cursor || direction == PageDirectionAfter && id > cursor {
+					cursor = id
+				}
+			}
+			return cursor
+		},
+	)
+	if startID == 0 && page.config.Direction == PageDirectionBefore {
+		startID = snowflake.New(time.Now())
+	}
+	page.cursor = startID
+	return page
+}
+
+// Next requests the next page and stores its items in Page.Items.
+// It returns false if there are no more items, PageConfig.MaxItems is reached or an error occurred, which is stored in Page.Err.
+func (p *Page[T]) Next() bool {
+	p.Items = nil
+	if p.done {
+		return false
+	}
+	if !p.supportsDirection() {
+		return p.stop(ErrPageDirectionNotSupported)
+	}
+	if err := p.config.Ctx.Err(); err != nil {
+		return p.stop(err)
+	}
+
+	values := route.QueryValues{}
+	if p.cursor != nil {
+		if p.config.Direction == PageDirectionBefore {
+			values["before"] = p.cursor
+		} else {
+			values["after"] = p.cursor
+		}
+	}
+	limit := p.config.Limit
+	if p.config.MaxItems > 0 && (limit == 0 || p.config.MaxItems-p.fetched < limit) {
+		limit = p.config.MaxItems - p.fetched
+	}
+	if limit > 0 {
+		values["limit"] = limit
+	}
+
+	opts := make([]RequestOpt, 0, len(p.config.RequestOpts)+1)
+	opts = append(opts, p.config.RequestOpts...)
+	opts = append(opts, WithCtx(p.config.Ctx))
+	items, more, err := p.fetchFunc(values, opts)
+	if err != nil {
+		return p.stop(err)
+	}
+	if len(items) == 0 {
+		return p.stop(nil)
+	}
+	if p.config.MaxItems > 0 && len(items) > p.config.MaxItems-p.fetched {
+		items = items[:p.config.MaxItems-p.fetched]
+	}
+
+	p.Items = items
+	p.fetched += len(items)
+	p.cursor = p.cursorFunc(items, p.config.Direction)
+	p.done = !more || p.config.MaxItems > 0 && p.fetched >= p.config.MaxItems
+	return true
+}
+
+// ForEach calls the given function for each item of all pages until it returns false or there are no more items.
+func (p *Page[T]) ForEach(f func(item T) bool) error {
+	for p.Next() {
+		for _, item := range p.Items {
+			if !f(item) {
+				p.done = true
+				return nil
+			}
+		}
+	}
+	return p.Err
+}
+
+// All returns the items of all remaining pages.
+func (p *Page[T]) All() ([]T, error) {
+	var items []T
+	for p.Next() {
+		items = append(items, p.Items...)
+	}
+	return items, p.Err
+}
+
+func (p *Page[T]) supportsDirection() bool {
+	for _, direction := range p.directions {
+		if direction == p.config.Direction {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Page[T]) stop(err error) bool {
+	p.Err = err
+	p.done = true
+	return false
+}
@@ -0,0 +1,108 @@
+package rest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/disgoorg/disgo/rest/route"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// testIDPage returns a Page over the IDs 1 to count, which are returned newest first like discord does for messages.
+func testIDPage(count int, startID snowflake.ID, directions []PageDirection, opts ...PageConfigOpt) (*Page[snowflake.ID], *int) {
+	requests := 0
+	return newIDPage(startID, 100, opts, directions,
+		func(id snowflake.ID) snowflake.ID {
+			return id
+		},
+		func(values route.QueryValues, _ []RequestOpt) ([]snowflake.ID, error) {
+			requests++
+			limit := values["limit"].(int)
+			var ids []snowflake.ID
+			if before, ok := values["before"].(snowflake.ID); ok {
+				if before > snowflake.ID(count) {
+					before = snowflake.ID(count) + 1
+				}
+				for id := before - 1; id > 0 && len(ids) < limit; id-- {
+					ids = append(ids, id)
+				}
+			} else {
+				after := values["after"].(snowflake.ID)
+				for id := after + 1; id <= snowflake.ID(count) && len(ids) < limit; id++ {
+					ids = append(ids, id)
+				}
+				for i, j := 0, len(ids)-1; i < j; i, j = i+1, j-1 {
+					ids[i], ids[j] = ids[j], ids[i]
+				}
+			}
+			return ids, nil
+		},
+	), &requests
+}
+
+func TestPageBefore(t *testing.T) {
+	page, requests := testIDPage(250, 0, pageDirectionsAll)
+
+	var sizes []int
+	for page.Next() {
+		sizes = append(sizes, len(page.Items))
+	}
+	assert.NoError(t, page.Err)
+	assert.Equal(t, []int{100, 100, 50}, sizes)
+	assert.Equal(t, 3, *requests)
+}
+
+func TestPageAfter(t *testing.T) {
+	page, _ := testIDPage(250, 0, pageDirectionsAll, WithPageDirection(PageDirectionAfter))
+
+	items, err := page.All()
+	assert.NoError(t, err)
+	assert.Len(t, items, 250)
+	assert.Equal(t, snowflake.ID(100), items[0])
+	assert.Equal(t, snowflake.ID(201), items[len(items)-1])
+}
+
+func TestPageMaxItems(t *testing.T) {
+	page, requests := testIDPage(250, 200, pageDirectionsAll, WithPageMaxItems(120), WithPageLimit(50))
+
+	items, err := page.All()
+	assert.NoError(t, err)
+	assert.Len(t, items, 120)
+	assert.Equal(t, snowflake.ID(199), items[0])
+	assert.Equal(t, snowflake.ID(80), items[len(items)-1])
+	assert.Equal(t, 3, *requests)
+}
+
+func TestPageForEachStops(t *testing.T) {
+	page, requests := testIDPage(250, 0, pageDirectionsAll)
+
+	var count int
+	err := page.ForEach(func(id snowflake.ID) bool {
+		count++
+		return id != 140
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 111, count)
+	assert.Equal(t, 2, *requests)
+	assert.False(t, page.Next())
+}
+
+func TestPageUnsupportedDirection(t *testing.T) {
+	page, requests := testIDPage(250, 0, pageDirectionsAfter, WithPageDirection(PageDirectionBefore))
+
+	assert.False(t, page.Next())
+	assert.ErrorIs(t, page.Err, ErrPageDirectionNotSupported)
+	assert.Equal(t, 0, *requests)
+}
+
+func TestPageCtxDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	page, requests := testIDPage(250, 0, pageDirectionsAll, WithPageCtx(ctx))
+
+	assert.True(t, page.Next())
+	cancel()
+	assert.False(t, page.Next())
+	assert.ErrorIs(t, page.Err, context.Canceled)
+	assert.Equal(t, 1, *requests)
+}
@@ -18,10 +18,11 @@ func DefaultRequestConfig(rq *http.Request) *RequestConfig {
 
 // RequestConfig are additional options for the request
 type RequestConfig struct {
-	Request *http.Request
-	Ctx     context.Context
-	Checks  []Check
-	Delay   time.Duration
+	Request    *http.Request
+	Ctx        context.Context
+	Checks     []Check
+	Delay      time.Duration
+	Idempotent bool
 }
 
 // Check is a function which gets executed right before a request is made
@@ -61,6 +62,13 @@ func WithDelay(delay time.Duration) RequestOpt {
 	}
 }
 
+// WithIdempotent marks the request as safe to send multiple times, so the RetryPolicy may retry it even if its HTTP method is not idempotent
+func WithIdempotent() RequestOpt {
+	return func(config *RequestConfig) {
+		config.Idempotent = true
+	}
+}
+
 // WithReason adds a reason header to the request. Not all discord endpoints support this
 func WithReason(reason string) RequestOpt {
 	return func(config *RequestConfig) {
@@ -21,6 +21,7 @@ type Rest interface {
 	Emojis
 	Stickers
 	GuildScheduledEvents
+	AutoModeration
 }
 
 var _ Rest = (*restImpl)(nil)
@@ -46,6 +47,7 @@ func New(client Client) Rest {
 		Emojis:               NewEmojis(client),
 		Stickers:             NewStickers(client),
 		GuildScheduledEvents: NewGuildScheduledEvents(client),
+		AutoModeration:       NewAutoModeration(client),
 	}
 }
 
@@ -69,4 +71,5 @@ type restImpl struct {
 	Emojis
 	Stickers
 	GuildScheduledEvents
+	AutoModeration
 }
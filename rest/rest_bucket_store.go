@@ -0,0 +1,217 @@
+package rest
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/disgoorg/log"
+	"github.com/sasha-s/go-csync"
+)
+
+// BucketUpdate is the rate limit info of a response which is applied to a bucket when it's released.
+type BucketUpdate struct {
+	// ID is the bucket ID discord sent or empty if none was sent.
+	ID string `json:"id"`
+	// Limit is the number of requests which can be made until the bucket resets or -1 if unknown.
+	Limit int `json:"limit"`
+	// Remaining is the number of requests left until the bucket resets or -1 if unknown, in which case it's lowered by one.
+	Remaining int `json:"remaining"`
+	// Reset is when the bucket resets or zero if unknown.
+	Reset time.Time `json:"reset"`
+	// Global is when the global rate limit resets if it was hit, else zero.
+	Global time.Time `json:"global"`
+}
+
+// BucketStore stores the rate limit buckets & the global rate limit of the RateLimiter.
+// The default BucketStore keeps them in memory. Use NewHTTPBucketStore to share them between multiple processes.
+type BucketStore interface {
+	// Reserve waits until a request can be made in the bucket with the given key and reserves the bucket for it.
+	// Each bucket can only be reserved once at a time, until Release is called.
+	Reserve(ctx context.Context, key string) error
+
+	// Release releases the reservation of the bucket with the given key and applies the BucketUpdate of the response to it.
+	// If update is nil, the bucket is released without changes.
+	Release(key string, update *BucketUpdate) error
+
+	// Reset removes all buckets & the global rate limit.
+	Reset()
+
+	// Close waits until all buckets are released.
+	// If the context is done, Close returns immediately.
+	Close(ctx context.Context)
+}
+
+var _ BucketStore = (*memoryBucketStore)(nil)
+
+// NewMemoryBucketStore returns a new BucketStore which keeps the buckets in memory and removes them after they reset, checking every cleanupInterval.
+func NewMemoryBucketStore(logger log.Logger, cleanupInterval time.Duration) BucketStore {
+	store := &memoryBucketStore{
+		logger:  logger,
+		buckets: map[string]*bucket{},
+	}
+
+	go store.cleanup(cleanupInterval)
+
+	return store
+}
+
+type memoryBucketStore struct {
+	logger log.Logger
+
+	// unix nano time of the global rate limit reset
+	global int64
+
+	buckets   map[string]*bucket
+	bucketsMu sync.Mutex
+}
+
+func (s *memoryBucketStore) cleanup(cleanupInterval time.Duration) {
+	ticker := time.NewTicker(cleanupInterval)
+	for range ticker.C {
+		s.doCleanup()
+	}
+}
+
+func (s *memoryBucketStore) doCleanup() {
+	s.bucketsMu.Lock()
+	defer s.bucketsMu.Unlock()
+	before := len(s.buckets)
+	now := time.Now()
+	for key, b := range s.buckets {
+		if !b.mu.TryLock() {
+			continue
+		}
+		if b.Reset.Before(now) {
+			s.logger.Debugf("cleaning up bucket, Hash: %s, ID: %s, Reset: %s", key, b.ID, b.Reset)
+			delete(s.buckets, key)
+		}
+		b.mu.Unlock()
+	}
+	if before != len(s.buckets) {
+		s.logger.Debugf("cleaned up %d rate limit buckets", before-len(s.buckets))
+	}
+}
+
+func (s *memoryBucketStore) getBucket(key string, create bool) *bucket {
+	s.bucketsMu.Lock()
+	defer s.bucketsMu.Unlock()
+	b, ok := s.buckets[key]
+	if !ok {
+		if !create {
+			return nil
+		}
+
+		b = &bucket{
+			Remaining: 1,
+			// we don't know the limit yet
+			Limit: -1,
+		}
+		s.buckets[key] = b
+	}
+	return b
+}
+
+func (s *memoryBucketStore) Reserve(ctx context.Context, key string) error {
+	b := s.getBucket(key, true)
+	if err := b.mu.CLock(ctx); err != nil {
+		return err
+	}
+	// the bucket may only be read while holding its lock
+	s.logger.Tracef("locked rest bucket, ID: %s, Limit: %d, Remaining: %d, Reset: %s", b.ID, b.Limit, b.Remaining, b.Reset)
+
+	var until time.Time
+	now := time.Now()
+
+	if b.Remaining == 0 && b.Reset.After(now) {
+		until = b.Reset
+	} else {
+		until = time.Unix(0, atomic.LoadInt64(&s.global))
+	}
+
+	if until.After(now) {
+		if deadline, ok := ctx.Deadline(); ok && until.After(deadline) {
+			b.mu.Unlock()
+			return context.DeadlineExceeded
+		}
+
+		select {
+		case <-ctx.Done():
+			b.mu.Unlock()
+			return ctx.Err()
+		case <-time.After(until.Sub(now)):
+		}
+	}
+	return nil
+}
+
+func (s *memoryBucketStore) Release(key string, update *BucketUpdate) error {
+	b := s.getBucket(key, false)
+	if b == nil {
+		return nil
+	}
+	defer func() {
+		s.logger.Tracef("unlocking rest bucket, ID: %s, Limit: %d, Remaining: %d, Reset: %s", b.ID, b.Limit, b.Remaining, b.Reset)
+		b.mu.Unlock()
+	}()
+
+	if update == nil {
+		return nil
+	}
+	if update.ID != "" {
+		b.ID = update.ID
+	}
+	if !update.Global.IsZero() {
+		atomic.StoreInt64(&s.global, update.Global.UnixNano())
+	} else if !update.Reset.IsZero() {
+		b.Reset = update.Reset
+	}
+	if update.Limit >= 0 {
+		b.Limit = update.Limit
+	}
+	if update.Remaining >= 0 {
+		b.Remaining = update.Remaining
+	} else if b.Remaining > 0 {
+		// Lower remaining one just to be safe
+		b.Remaining--
+	}
+	return nil
+}
+
+func (s *memoryBucketStore) Reset() {
+	s.bucketsMu.Lock()
+	defer s.bucketsMu.Unlock()
+	s.buckets = map[string]*bucket{}
+	atomic.StoreInt64(&s.global, 0)
+}
+
+func (s *memoryBucketStore) Close(ctx context.Context) {
+	s.bucketsMu.Lock()
+	buckets := make([]*bucket, 0, len(s.buckets))
+	for _, b := range s.buckets {
+		buckets = append(buckets, b)
+	}
+	s.bucketsMu.Unlock()
+
+	var wg sync.WaitGroup
+	for i := range buckets {
+		wg.Add(1)
+		b := buckets[i]
+		go func() {
+			defer wg.Done()
+			if err := b.mu.CLock(ctx); err == nil {
+				b.mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+type bucket struct {
+	mu        csync.Mutex
+	ID        string
+	Reset     time.Time
+	Remaining int
+	Limit     int
+}
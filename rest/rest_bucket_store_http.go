@@ -0,0 +1,288 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/internal/insecurerandstr"
+	"github.com/disgoorg/disgo/json"
+	"github.com/sasha-s/go-csync"
+)
+
+var _ BucketStore = (*httpBucketStore)(nil)
+
+// httpBucketStoreReleaseTimeout is the max time releasing a bucket may take, as Release has no context.
+const httpBucketStoreReleaseTimeout = 10 * time.Second
+
+// NewHTTPBucketStore returns a BucketStore which keeps the buckets in the BucketStoreServer at the given URL.
+// All processes using the same BucketStoreServer share one view of discord's rate limits. If httpClient is nil, a http.Client without timeout is used.
+// Reset & Close only affect this process, as the buckets are shared.
+func NewHTTPBucketStore(url string, httpClient *http.Client) BucketStore {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	return &httpBucketStore{
+		url:          strings.TrimSuffix(url, "/"),
+		httpClient:   httpClient,
+		reservations: map[string]*httpReservation{},
+	}
+}
+
+type httpBucketStore struct {
+	url        string
+	httpClient *http.Client
+
+	// key -> reservation of this process
+	reservations   map[string]*httpReservation
+	reservationsMu sync.Mutex
+}
+
+// httpReservation serializes the reservations of one bucket within this process like the memory BucketStore does.
+// Release only gets the key, so this is what ties it to the ID of the reservation it releases.
+type httpReservation struct {
+	mu csync.Mutex
+	// id & users are guarded by httpBucketStore.reservationsMu
+	id    string
+	users int
+}
+
+func (s *httpBucketStore) Reserve(ctx context.Context, key string) error {
+	s.reservationsMu.Lock()
+	reservation, ok := s.reservations[key]
+	if !ok {
+		reservation = &httpReservation{}
+		s.reservations[key] = reservation
+	}
+	reservation.users++
+	s.reservationsMu.Unlock()
+
+	if err := reservation.mu.CLock(ctx); err != nil {
+		s.removeUser(key, reservation)
+		return err
+	}
+
+	id := insecurerandstr.RandStr(32)
+	if _, err := s.do(ctx, "/reserve", url.Values{"key": {key}, "id": {id}}, nil); err != nil {
+		// the server may have granted the reservation right before ctx was done, so give it back
+		_ = s.release(key, id, nil)
+		reservation.mu.Unlock()
+		s.removeUser(key, reservation)
+		return err
+	}
+
+	s.reservationsMu.Lock()
+	reservation.id = id
+	s.reservationsMu.Unlock()
+	return nil
+}
+
+func (s *httpBucketStore) Release(key string, update *BucketUpdate) error {
+	s.reservationsMu.Lock()
+	reservation, ok := s.reservations[key]
+	if !ok || reservation.id == "" {
+		s.reservationsMu.Unlock()
+		return nil
+	}
+	id := reservation.id
+	reservation.id = ""
+	s.reservationsMu.Unlock()
+
+	err := s.release(key, id, update)
+	reservation.mu.Unlock()
+	s.removeUser(key, reservation)
+	return err
+}
+
+// removeUser forgets the reservation once nobody in this process holds or waits for it anymore.
+func (s *httpBucketStore) removeUser(key string, reservation *httpReservation) {
+	s.reservationsMu.Lock()
+	defer s.reservationsMu.Unlock()
+	reservation.users--
+	if reservation.users == 0 {
+		delete(s.reservations, key)
+	}
+}
+
+func (s *httpBucketStore) release(key string, id string, update *BucketUpdate) error {
+	var body []byte
+	if update != nil {
+		var err error
+		if body, err = json.Marshal(update); err != nil {
+			return fmt.Errorf("failed to marshal bucket update: %w", err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), httpBucketStoreReleaseTimeout)
+	defer cancel()
+	_, err := s.do(ctx, "/release", url.Values{"key": {key}, "id": {id}}, body)
+	return err
+}
+
+// Reset does nothing, as the buckets are shared and the reservations of this process are still held by their requests.
+func (s *httpBucketStore) Reset() {}
+
+func (s *httpBucketStore) Close(_ context.Context) {
+	s.httpClient.CloseIdleConnections()
+}
+
+func (s *httpBucketStore) do(ctx context.Context, path string, values url.Values, body []byte) ([]byte, error) {
+	rq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url+path+"?"+values.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	rs, err := s.httpClient.Do(rq)
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Body.Close()
+	rawRs, err := io.ReadAll(rs.Body)
+	if err != nil {
+		return nil, err
+	}
+	if rs.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bucket store responded with %s: %s", rs.Status, strings.TrimSpace(string(rawRs)))
+	}
+	return rawRs, nil
+}
+
+// NewBucketStoreServer returns an http.Handler which shares the given BucketStore with all BucketStore(s) created by NewHTTPBucketStore for its URL.
+// Reservations which are not released within the lease are released without changes, so processes which die while holding one don't block the bucket.
+// The lease should be longer than the timeout of the http.Client used for the requests to discord.
+func NewBucketStoreServer(store BucketStore, lease time.Duration) http.Handler {
+	return &bucketStoreServer{
+		store:  store,
+		lease:  lease,
+		leases: map[string]*bucketLease{},
+	}
+}
+
+type bucketStoreServer struct {
+	store BucketStore
+	lease time.Duration
+
+	// reservation ID -> lease
+	leases   map[string]*bucketLease
+	leasesMu sync.Mutex
+}
+
+// bucketLease is a reservation requested by a client. It is added before the bucket is reserved, so releasing it while the client still waits for the bucket is not lost.
+type bucketLease struct {
+	key string
+	// reserved is true once the bucket was reserved for the lease, released is true if the client released the lease before that
+	reserved bool
+	released bool
+	timer    *time.Timer
+}
+
+func (s *bucketStoreServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		http.Error(w, "missing key", http.StatusBadRequest)
+		return
+	}
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "missing id", http.StatusBadRequest)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/reserve":
+		s.reserve(w, r, key, id)
+
+	case "/release":
+		s.release(w, r, key, id)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *bucketStoreServer) reserve(w http.ResponseWriter, r *http.Request, key string, id string) {
+	lease := &bucketLease{key: key}
+	s.leasesMu.Lock()
+	if _, ok := s.leases[id]; ok {
+		s.leasesMu.Unlock()
+		http.Error(w, "reservation id already in use", http.StatusConflict)
+		return
+	}
+	s.leases[id] = lease
+	s.leasesMu.Unlock()
+
+	err := s.store.Reserve(r.Context(), key)
+
+	s.leasesMu.Lock()
+	if err != nil {
+		delete(s.leases, id)
+		s.leasesMu.Unlock()
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	// the client gave up while waiting for the bucket, so nobody would release it
+	if lease.released || r.Context().Err() != nil {
+		delete(s.leases, id)
+		s.leasesMu.Unlock()
+		_ = s.store.Release(key, nil)
+		http.Error(w, "reservation released while waiting for the bucket", http.StatusConflict)
+		return
+	}
+	lease.reserved = true
+	lease.timer = time.AfterFunc(s.lease, func() {
+		_ = s.releaseLease(key, id, nil)
+	})
+	s.leasesMu.Unlock()
+
+	// if the client is gone, it releases the reservation itself or the lease runs out
+	_, _ = w.Write([]byte("{}"))
+}
+
+func (s *bucketStoreServer) release(w http.ResponseWriter, r *http.Request, key string, id string) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var update *BucketUpdate
+	if len(body) > 0 {
+		if err = json.Unmarshal(body, &update); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if err = s.releaseLease(key, id, update); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, _ = w.Write([]byte("{}"))
+}
+
+// releaseLease releases the bucket if the lease with the given ID still holds it.
+// Leases which already ran out are ignored, as the request they were made for went through anyway.
+// Leases which still wait for the bucket release it as soon as they get it.
+func (s *bucketStoreServer) releaseLease(key string, id string, update *BucketUpdate) error {
+	s.leasesMu.Lock()
+	lease, ok := s.leases[id]
+	if !ok || lease.key != key {
+		s.leasesMu.Unlock()
+		return nil
+	}
+	if !lease.reserved {
+		lease.released = true
+		s.leasesMu.Unlock()
+		return nil
+	}
+	delete(s.leases, id)
+	lease.timer.Stop()
+	s.leasesMu.Unlock()
+	return s.store.Release(key, update)
+}
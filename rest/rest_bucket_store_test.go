@@ -0,0 +1,124 @@
+package rest
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryBucketStoreWaitsForReset(t *testing.T) {
+	store := NewMemoryBucketStore(log.Default(), time.Minute)
+
+	assert.NoError(t, store.Reserve(context.Background(), "bucket"))
+	reset := time.Now().Add(200 * time.Millisecond)
+	assert.NoError(t, store.Release("bucket", &BucketUpdate{Limit: 1, Remaining: 0, Reset: reset}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, store.Reserve(ctx, "bucket"), context.DeadlineExceeded)
+
+	assert.NoError(t, store.Reserve(context.Background(), "bucket"))
+	assert.False(t, time.Now().Before(reset))
+	assert.NoError(t, store.Release("bucket", nil))
+}
+
+func TestMemoryBucketStoreGlobal(t *testing.T) {
+	store := NewMemoryBucketStore(log.Default(), time.Minute)
+
+	assert.NoError(t, store.Reserve(context.Background(), "bucket1"))
+	global := time.Now().Add(200 * time.Millisecond)
+	assert.NoError(t, store.Release("bucket1", &BucketUpdate{Limit: -1, Remaining: -1, Global: global}))
+
+	assert.NoError(t, store.Reserve(context.Background(), "bucket2"))
+	assert.False(t, time.Now().Before(global))
+	assert.NoError(t, store.Release("bucket2", nil))
+}
+
+func TestHTTPBucketStoreSharesBuckets(t *testing.T) {
+	server := httptest.NewServer(NewBucketStoreServer(NewMemoryBucketStore(log.Default(), time.Minute), time.Minute))
+	defer server.Close()
+
+	store1 := NewHTTPBucketStore(server.URL, nil)
+	store2 := NewHTTPBucketStore(server.URL, nil)
+
+	assert.NoError(t, store1.Reserve(context.Background(), "bucket"))
+
+	// the bucket is reserved by the other process
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.Error(t, store2.Reserve(ctx, "bucket"))
+
+	reset := time.Now().Add(200 * time.Millisecond)
+	assert.NoError(t, store1.Release("bucket", &BucketUpdate{Limit: 1, Remaining: 0, Reset: reset}))
+
+	assert.NoError(t, store2.Reserve(context.Background(), "bucket"))
+	assert.False(t, time.Now().Before(reset))
+	assert.NoError(t, store2.Release("bucket", nil))
+}
+
+func TestBucketStoreServerLeaseRunsOut(t *testing.T) {
+	server := httptest.NewServer(NewBucketStoreServer(NewMemoryBucketStore(log.Default(), time.Minute), 100*time.Millisecond))
+	defer server.Close()
+
+	store1 := NewHTTPBucketStore(server.URL, nil)
+	store2 := NewHTTPBucketStore(server.URL, nil)
+
+	// store1 never releases the bucket
+	assert.NoError(t, store1.Reserve(context.Background(), "bucket"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, store2.Reserve(ctx, "bucket"))
+	assert.NoError(t, store2.Release("bucket", nil))
+
+	// releasing the lease which ran out is ignored
+	assert.NoError(t, store1.Release("bucket", nil))
+}
+
+func TestBucketStoreServerLateReleaseKeepsOtherReservation(t *testing.T) {
+	server := httptest.NewServer(NewBucketStoreServer(NewMemoryBucketStore(log.Default(), time.Minute), 100*time.Millisecond))
+	defer server.Close()
+
+	store1 := NewHTTPBucketStore(server.URL, nil)
+	store2 := NewHTTPBucketStore(server.URL, nil)
+	store3 := NewHTTPBucketStore(server.URL, nil)
+
+	// the lease of store1 runs out while store2 waits for the bucket
+	assert.NoError(t, store1.Reserve(context.Background(), "bucket"))
+	assert.NoError(t, store2.Reserve(context.Background(), "bucket"))
+
+	// the late release of store1 must not release the reservation of store2
+	assert.NoError(t, store1.Release("bucket", nil))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.Error(t, store3.Reserve(ctx, "bucket"))
+
+	assert.NoError(t, store2.Release("bucket", nil))
+}
+
+func TestHTTPBucketStoreCancelledReserveReleases(t *testing.T) {
+	server := httptest.NewServer(NewBucketStoreServer(NewMemoryBucketStore(log.Default(), time.Minute), time.Minute))
+	defer server.Close()
+
+	store1 := NewHTTPBucketStore(server.URL, nil)
+	store2 := NewHTTPBucketStore(server.URL, nil)
+	store3 := NewHTTPBucketStore(server.URL, nil)
+
+	assert.NoError(t, store1.Reserve(context.Background(), "bucket"))
+
+	// store2 gives up while the server still waits for the bucket
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.Error(t, store2.Reserve(ctx, "bucket"))
+	assert.NoError(t, store1.Release("bucket", nil))
+
+	// the bucket the server got for store2 is released instead of being held until the lease runs out
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, store3.Reserve(ctx, "bucket"))
+	assert.NoError(t, store3.Release("bucket", nil))
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/disgoorg/disgo/json"
@@ -23,7 +24,9 @@ func NewClient(botToken string, opts ...ConfigOpt) Client {
 
 	config.RateLimiter.Reset()
 
-	return &clientImpl{botToken: botToken, config: *config}
+	client := &clientImpl{botToken: botToken, config: *config}
+	client.roundTrip = chainInterceptors(client.doRoundTrip, config.Interceptors)
+	return client
 }
 
 // Client allows doing requests to different endpoints
@@ -45,8 +48,9 @@ type Client interface {
 }
 
 type clientImpl struct {
-	botToken string
-	config   Config
+	botToken  string
+	config    Config
+	roundTrip RoundTrip
 }
 
 func (c *clientImpl) Close(ctx context.Context) {
@@ -66,13 +70,17 @@ func (c *clientImpl) RateLimiter() RateLimiter {
 	return c.config.RateLimiter
 }
 
-func (c *clientImpl) retry(cRoute *route.CompiledAPIRoute, rqBody any, rsBody any, tries int, opts []RequestOpt) error {
+// retry sends the request. tries counts all tries of the request, while policyTries only counts the tries made because of the RetryPolicy.
+func (c *clientImpl) retry(cRoute *route.CompiledAPIRoute, rqBody any, rsBody any, tries int, policyTries int, opts []RequestOpt) error {
 	var (
 		rqURL       = cRoute.URL()
 		rawRqBody   []byte
 		err         error
 		contentType string
 	)
+	if c.config.URL != "" && c.config.URL != route.API && strings.HasPrefix(rqURL, route.API) {
+		rqURL = c.config.URL + strings.TrimPrefix(rqURL, route.API)
+	}
 
 	if rqBody != nil {
 		switch v := rqBody.(type) {
@@ -126,7 +134,8 @@ func (c *clientImpl) retry(cRoute *route.CompiledAPIRoute, rqBody any, rsBody an
 	if err != nil {
 		return fmt.Errorf("error locking bucket in rest client: %w", err)
 	}
-	rq = rq.WithContext(config.Ctx)
+	config.Request = config.Request.WithContext(config.Ctx)
+	rq = config.Request
 
 	for _, check := range config.Checks {
 		if !check() {
@@ -135,28 +144,33 @@ func (c *clientImpl) retry(cRoute *route.CompiledAPIRoute, rqBody any, rsBody an
 		}
 	}
 
-	rs, err := c.HTTPClient().Do(config.Request)
+	rs, err := c.roundTrip(&InterceptedRequest{
+		Route:   cRoute,
+		Request: config.Request,
+		Body:    rawRqBody,
+		Try:     tries,
+	})
 	if err != nil {
 		_ = c.RateLimiter().UnlockBucket(cRoute, nil)
+		if c.waitRetry(config, RetryAttempt{Try: policyTries, Method: rq.Method, Err: err, Idempotent: isIdempotentMethod(rq.Method) || config.Idempotent}) {
+			return c.retry(cRoute, rqBody, rsBody, tries+1, policyTries+1, opts)
+		}
 		return fmt.Errorf("error doing request in rest client: %w", err)
 	}
+	if rs == nil || rs.Response == nil {
+		_ = c.RateLimiter().UnlockBucket(cRoute, nil)
+		return ErrNoInterceptedResponse
+	}
 
-	if err = c.RateLimiter().UnlockBucket(cRoute, rs.Header); err != nil {
+	if err = c.RateLimiter().UnlockBucket(cRoute, rs.Response.Header); err != nil {
 		// TODO: should we maybe retry here?
 		return fmt.Errorf("error unlocking bucket in rest client: %w", err)
 	}
 
-	var rawRsBody []byte
-	if rs.Body != nil {
-		if rawRsBody, err = io.ReadAll(rs.Body); err != nil {
-			return fmt.Errorf("error reading response body in rest client: %w", err)
-		}
-		c.Logger().Tracef("response from %s, code %d, body: %s", rqURL, rs.StatusCode, string(rawRsBody))
-	}
-
-	switch rs.StatusCode {
+	rawRsBody := rs.Body
+	switch rs.StatusCode() {
 	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
-		if rsBody != nil && rs.Body != nil {
+		if rsBody != nil && len(rawRsBody) > 0 {
 			if err = json.Unmarshal(rawRsBody, rsBody); err != nil {
 				wErr := fmt.Errorf("error unmarshalling response body: %w", err)
 				c.Logger().Error(wErr)
@@ -167,15 +181,75 @@ func (c *clientImpl) retry(cRoute *route.CompiledAPIRoute, rqBody any, rsBody an
 
 	case http.StatusTooManyRequests:
 		if tries >= c.RateLimiter().MaxRetries() {
-			return NewError(rq, rawRqBody, rs, rawRsBody)
+			return NewError(rq, rawRqBody, rs.Response, rawRsBody)
 		}
-		return c.retry(cRoute, rqBody, rsBody, tries+1, opts)
+		return c.retry(cRoute, rqBody, rsBody, tries+1, policyTries, opts)
 
 	default:
-		return NewError(rq, rawRqBody, rs, rawRsBody)
+		if c.waitRetry(config, RetryAttempt{Try: policyTries, Method: rq.Method, Response: rs.Response, Idempotent: isIdempotentMethod(rq.Method) || config.Idempotent}) {
+			return c.retry(cRoute, rqBody, rsBody, tries+1, policyTries+1, opts)
+		}
+		return NewError(rq, rawRqBody, rs.Response, rawRsBody)
+	}
+}
+
+// doRoundTrip sends the InterceptedRequest with the http.Client and reads the response body.
+func (c *clientImpl) doRoundTrip(rq *InterceptedRequest) (*InterceptedResponse, error) {
+	if len(rq.Body) > 0 {
+		rq.Request.Body = io.NopCloser(bytes.NewReader(rq.Body))
+		rq.Request.ContentLength = int64(len(rq.Body))
+	} else {
+		rq.Request.Body = http.NoBody
+		rq.Request.ContentLength = 0
+	}
+
+	start := time.Now()
+	rs, err := c.HTTPClient().Do(rq.Request)
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Body.Close()
+
+	rawRsBody, err := io.ReadAll(rs.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+	c.Logger().Tracef("response from %s, code %d, body: %s", rq.Request.URL, rs.StatusCode, string(rawRsBody))
+
+	return &InterceptedResponse{
+		Response: rs,
+		Body:     rawRsBody,
+		Latency:  time.Since(start),
+	}, nil
+}
+
+// waitRetry asks the RetryPolicy whether the failed RetryAttempt should be retried and waits for the returned delay.
+// It returns false if the request context is done before.
+func (c *clientImpl) waitRetry(config *RequestConfig, attempt RetryAttempt) bool {
+	if c.config.RetryPolicy == nil || config.Ctx.Err() != nil {
+		return false
+	}
+	delay, ok := c.config.RetryPolicy.Retry(attempt)
+	if !ok {
+		return false
+	}
+	c.Logger().Debugf("retrying %s request to %s in %s, try %d", attempt.Method, config.Request.URL, delay, attempt.Try)
+	if delay <= 0 {
+		return true
+	}
+	if deadline, ok := config.Ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+		return false
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-config.Ctx.Done():
+		return false
+	case <-timer.C:
+		return true
 	}
 }
 
 func (c *clientImpl) Do(cRoute *route.CompiledAPIRoute, rqBody any, rsBody any, opts ...RequestOpt) error {
-	return c.retry(cRoute, rqBody, rsBody, 1, opts)
+	return c.retry(cRoute, rqBody, rsBody, 1, 1, opts)
 }
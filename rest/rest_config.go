@@ -2,16 +2,20 @@ package rest
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/disgoorg/disgo/rest/route"
 	"github.com/disgoorg/log"
 )
 
 // DefaultConfig is the configuration which is used by default
 func DefaultConfig() *Config {
 	return &Config{
-		Logger:     log.Default(),
-		HTTPClient: &http.Client{Timeout: 20 * time.Second},
+		Logger:      log.Default(),
+		HTTPClient:  &http.Client{Timeout: 20 * time.Second},
+		URL:         route.API,
+		RetryPolicy: NewExponentialBackoffRetryPolicy(time.Second, 30*time.Second, 3),
 	}
 }
 
@@ -22,6 +26,9 @@ type Config struct {
 	RateLimiter               RateLimiter
 	RateRateLimiterConfigOpts []RateLimiterConfigOpt
 	UserAgent                 string
+	URL                       string
+	RetryPolicy               RetryPolicy
+	Interceptors              []Interceptor
 }
 
 // ConfigOpt can be used to supply optional parameters to NewClient
@@ -71,3 +78,31 @@ func WithUserAgent(userAgent string) ConfigOpt {
 		config.UserAgent = userAgent
 	}
 }
+
+// WithRetryPolicy sets the RetryPolicy which decides whether requests which failed with a network error or server error are retried.
+// Use NewNoRetryPolicy to disable retries.
+func WithRetryPolicy(retryPolicy RetryPolicy) ConfigOpt {
+	return func(config *Config) {
+		config.RetryPolicy = retryPolicy
+	}
+}
+
+// WithInterceptors adds Interceptor(s) which wrap each request to discord. They are called in the order they were added.
+func WithInterceptors(interceptors ...Interceptor) ConfigOpt {
+	return func(config *Config) {
+		config.Interceptors = append(config.Interceptors, interceptors...)
+	}
+}
+
+// WithURL sets the base URL requests to route.API are sent to instead, e.g. for testing.
+func WithURL(url string) ConfigOpt {
+	return func(config *Config) {
+		config.URL = url
+	}
+}
+
+// WithProxy sends all requests to route.API through the restproxy running at the given URL.
+// The proxy does the rate limiting for all of its clients together.
+func WithProxy(proxyURL string) ConfigOpt {
+	return WithURL(strings.TrimSuffix(proxyURL, "/") + "/api/v" + route.APIVersion)
+}
@@ -0,0 +1,66 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/disgoorg/disgo/rest/route"
+)
+
+// ErrNoInterceptedResponse is returned by the Client if an Interceptor returned neither an error nor an InterceptedResponse with a Response.
+var ErrNoInterceptedResponse = errors.New("interceptor returned no response")
+
+// InterceptedRequest is a try of a request to discord as seen by an Interceptor.
+type InterceptedRequest struct {
+	// Route is the route.CompiledAPIRoute the request is made to.
+	Route *route.CompiledAPIRoute
+
+	// Request is the http.Request which is sent. Its body is set from Body when it's sent.
+	Request *http.Request
+
+	// Body is the raw request body.
+	Body []byte
+
+	// Try is the number of the try, starting at 1. It's increased for each retry after a rate limit or by the RetryPolicy.
+	Try int
+}
+
+// InterceptedResponse is the response to an InterceptedRequest as seen by an Interceptor.
+type InterceptedResponse struct {
+	// Response is the http.Response discord sent. Its body was already read into Body & closed.
+	Response *http.Response
+
+	// Body is the raw response body.
+	Body []byte
+
+	// Latency is how long it took from sending the request until the response body was read.
+	Latency time.Duration
+}
+
+// StatusCode returns the status code of the Response.
+func (r *InterceptedResponse) StatusCode() int {
+	return r.Response.StatusCode
+}
+
+// RoundTrip sends the InterceptedRequest & returns the InterceptedResponse or an error if no response was received.
+type RoundTrip func(rq *InterceptedRequest) (*InterceptedResponse, error)
+
+// Interceptor wraps each try of a request after the RateLimiter allowed it, so it sees the request, the response, the status & the latency.
+// It can change the request before calling next & change the response or error next returned.
+// It can also return a response or error without calling next, in which case nothing is sent to discord.
+// A returned response is handled as if discord sent it, so its rate limit headers & status code are used by the Client.
+// If it's nil or its Response is nil, the request fails with ErrNoInterceptedResponse.
+type Interceptor func(rq *InterceptedRequest, next RoundTrip) (*InterceptedResponse, error)
+
+// chainInterceptors returns a RoundTrip which calls the given Interceptor(s) in order before the given RoundTrip.
+func chainInterceptors(roundTrip RoundTrip, interceptors []Interceptor) RoundTrip {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor := interceptors[i]
+		next := roundTrip
+		roundTrip = func(rq *InterceptedRequest) (*InterceptedResponse, error) {
+			return interceptor(rq, next)
+		}
+	}
+	return roundTrip
+}
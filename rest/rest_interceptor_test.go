@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/disgoorg/disgo/rest/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientInterceptors(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "signed", r.Header.Get("X-Signature"))
+		_, _ = w.Write([]byte(`{"url":"wss://gateway.discord.gg"}`))
+	}))
+	defer server.Close()
+
+	var (
+		order    []string
+		status   int
+		rsBody   []byte
+		rqRoute  *route.CompiledAPIRoute
+		errCheck = errors.New("short circuit")
+	)
+	client := NewClient("token", WithURL(server.URL), WithInterceptors(
+		func(rq *InterceptedRequest, next RoundTrip) (*InterceptedResponse, error) {
+			order = append(order, "first")
+			rqRoute = rq.Route
+			rs, err := next(rq)
+			if err == nil {
+				status = rs.StatusCode()
+				rsBody = rs.Body
+			}
+			return rs, err
+		},
+		func(rq *InterceptedRequest, next RoundTrip) (*InterceptedResponse, error) {
+			order = append(order, "second")
+			if rq.Request.URL.Query().Get("short") != "" {
+				return nil, errCheck
+			}
+			rq.Request.Header.Set("X-Signature", "signed")
+			return next(rq)
+		},
+	))
+	defer client.Close(context.Background())
+
+	compiledRoute, err := route.GetGateway.Compile(nil)
+	assert.NoError(t, err)
+
+	var gateway struct {
+		URL string `json:"url"`
+	}
+	assert.NoError(t, client.Do(compiledRoute, nil, &gateway))
+	assert.Equal(t, "wss://gateway.discord.gg", gateway.URL)
+	assert.Equal(t, []string{"first", "second"}, order)
+	assert.Same(t, compiledRoute, rqRoute)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"url":"wss://gateway.discord.gg"}`, string(rsBody))
+	assert.Equal(t, 1, requests)
+
+	err = client.Do(compiledRoute, nil, nil, WithQueryParam("short", true))
+	assert.ErrorIs(t, err, errCheck)
+	assert.Equal(t, 1, requests)
+}
+
+func TestClientInterceptorWithoutResponse(t *testing.T) {
+	for _, rs := range []*InterceptedResponse{nil, {}} {
+		rs := rs
+		client := NewClient("token", WithURL("http://localhost"), WithInterceptors(func(rq *InterceptedRequest, next RoundTrip) (*InterceptedResponse, error) {
+			return rs, nil
+		}))
+
+		compiledRoute, err := route.GetGateway.Compile(nil)
+		assert.NoError(t, err)
+		assert.ErrorIs(t, client.Do(compiledRoute, nil, nil), ErrNoInterceptedResponse)
+		client.Close(context.Background())
+	}
+}
@@ -20,6 +20,7 @@ type RateLimiterConfig struct {
 	Logger          log.Logger
 	MaxRetries      int
 	CleanupInterval time.Duration
+	BucketStore     BucketStore
 }
 
 // RateLimiterConfigOpt can be used to supply optional parameters to NewRateLimiter.
@@ -52,3 +53,11 @@ func WithCleanupInterval(cleanupInterval time.Duration) RateLimiterConfigOpt {
 		config.CleanupInterval = cleanupInterval
 	}
 }
+
+// WithBucketStore sets the BucketStore the rest rate limiter keeps its buckets in.
+// The CleanupInterval is only used by the default in memory BucketStore.
+func WithBucketStore(bucketStore BucketStore) RateLimiterConfigOpt {
+	return func(config *RateLimiterConfig) {
+		config.BucketStore = bucketStore
+	}
+}
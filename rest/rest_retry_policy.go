@@ -0,0 +1,129 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy decides whether the Client retries a request which failed with a network error or server error and how long it waits before.
+// Requests which hit a rate limit are retried by the Client with the RateLimiter instead.
+type RetryPolicy interface {
+	// Retry returns how long to wait before retrying the failed RetryAttempt and whether it should be retried at all.
+	Retry(attempt RetryAttempt) (time.Duration, bool)
+}
+
+// RetryAttempt is a failed try of a request.
+type RetryAttempt struct {
+	// Try is the number of the failed try, starting at 1.
+	// Tries repeated after a rate limit are not counted, so it only increases with each retry made because of the RetryPolicy.
+	Try int
+
+	// Method is the HTTP method of the request.
+	Method string
+
+	// Response is the response of the failed try, its body was already read.
+	// It's nil if the request failed with Err before a response was received.
+	Response *http.Response
+
+	// Err is the error the request failed with if no response was received.
+	Err error
+
+	// Idempotent is whether the request can be sent again safely.
+	// This is the case for idempotent HTTP methods and for requests made with WithIdempotent.
+	Idempotent bool
+}
+
+var _ RetryPolicy = (*exponentialBackoffRetryPolicy)(nil)
+
+// NewExponentialBackoffRetryPolicy returns a RetryPolicy which retries idempotent requests which failed with a network error or with a 500, 502, 503 or 504 status.
+// It waits as long as the Retry-After header of the response says, else a random duration between 0 and baseDelay * 2^(try-1), capped at maxDelay.
+// It gives up after maxRetries retries.
+func NewExponentialBackoffRetryPolicy(baseDelay time.Duration, maxDelay time.Duration, maxRetries int) RetryPolicy {
+	return &exponentialBackoffRetryPolicy{
+		baseDelay:  baseDelay,
+		maxDelay:   maxDelay,
+		maxRetries: maxRetries,
+	}
+}
+
+// NewNoRetryPolicy returns a RetryPolicy which never retries requests.
+func NewNoRetryPolicy() RetryPolicy {
+	return NewExponentialBackoffRetryPolicy(0, 0, 0)
+}
+
+type exponentialBackoffRetryPolicy struct {
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+	maxRetries int
+}
+
+func (p *exponentialBackoffRetryPolicy) Retry(attempt RetryAttempt) (time.Duration, bool) {
+	if attempt.Try > p.maxRetries || !attempt.Idempotent {
+		return 0, false
+	}
+
+	if attempt.Response != nil {
+		switch attempt.Response.StatusCode {
+		case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		default:
+			return 0, false
+		}
+		if retryAfter, ok := parseRetryAfter(attempt.Response.Header.Get("Retry-After")); ok {
+			return retryAfter, true
+		}
+	} else if !isTransientError(attempt.Err) {
+		return 0, false
+	}
+
+	delay := p.maxDelay
+	// avoid overflowing the shift for high tries, the delay is capped at maxDelay anyway
+	if try := attempt.Try - 1; try < 32 {
+		if backoff := p.baseDelay << try; backoff > 0 && backoff < p.maxDelay {
+			delay = backoff
+		}
+	}
+	if delay <= 0 {
+		return 0, true
+	}
+	return time.Duration(rand.Int63n(int64(delay) + 1)), true
+}
+
+// isIdempotentMethod returns whether requests with the given HTTP method can be sent multiple times with the same effect.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isTransientError returns whether the error is a network error, including timeouts of the http.Client, which may not happen again.
+// The Client doesn't ask the RetryPolicy if the request context is done.
+func isTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// parseRetryAfter parses the Retry-After header, which is either in seconds or an HTTP date.
+func parseRetryAfter(retryAfter string) (time.Duration, bool) {
+	if retryAfter == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseFloat(retryAfter, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+	if at, err := http.ParseTime(retryAfter); err == nil {
+		return time.Until(at), true
+	}
+	return 0, false
+}
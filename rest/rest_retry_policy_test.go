@@ -0,0 +1,116 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/rest/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponentialBackoffRetryPolicy(t *testing.T) {
+	policy := NewExponentialBackoffRetryPolicy(time.Second, 4*time.Second, 3)
+
+	response := func(status int, retryAfter string) *http.Response {
+		rs := &http.Response{StatusCode: status, Header: http.Header{}}
+		if retryAfter != "" {
+			rs.Header.Set("Retry-After", retryAfter)
+		}
+		return rs
+	}
+
+	data := []struct {
+		name    string
+		attempt RetryAttempt
+		retry   bool
+	}{
+		{"bad gateway", RetryAttempt{Try: 1, Method: http.MethodGet, Response: response(http.StatusBadGateway, ""), Idempotent: true}, true},
+		{"network error", RetryAttempt{Try: 1, Method: http.MethodGet, Err: io.ErrUnexpectedEOF, Idempotent: true}, true},
+		{"not idempotent", RetryAttempt{Try: 1, Method: http.MethodPost, Response: response(http.StatusBadGateway, ""), Idempotent: false}, false},
+		{"bad request", RetryAttempt{Try: 1, Method: http.MethodGet, Response: response(http.StatusBadRequest, ""), Idempotent: true}, false},
+		{"canceled", RetryAttempt{Try: 1, Method: http.MethodGet, Err: context.Canceled, Idempotent: true}, false},
+		{"other error", RetryAttempt{Try: 1, Method: http.MethodGet, Err: errors.New("test"), Idempotent: true}, false},
+		{"max retries", RetryAttempt{Try: 4, Method: http.MethodGet, Response: response(http.StatusBadGateway, ""), Idempotent: true}, false},
+	}
+	for _, d := range data {
+		delay, ok := policy.Retry(d.attempt)
+		assert.Equal(t, d.retry, ok, d.name)
+		assert.LessOrEqual(t, delay, 4*time.Second, d.name)
+	}
+
+	delay, ok := policy.Retry(RetryAttempt{Try: 1, Method: http.MethodGet, Response: response(http.StatusServiceUnavailable, "7"), Idempotent: true})
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, delay)
+
+	_, ok = NewNoRetryPolicy().Retry(RetryAttempt{Try: 1, Method: http.MethodGet, Err: io.EOF, Idempotent: true})
+	assert.False(t, ok)
+}
+
+func TestClientRetriesTransientErrors(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient("token", WithURL(server.URL), WithRetryPolicy(NewExponentialBackoffRetryPolicy(time.Millisecond, 10*time.Millisecond, 3)))
+	defer client.Close(context.Background())
+
+	compiledRoute, err := route.GetGateway.Compile(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, client.Do(compiledRoute, nil, nil))
+	assert.Equal(t, 2, requests)
+
+	requests = 0
+	compiledRoute, err = route.CreateMessage.Compile(nil, 123)
+	assert.NoError(t, err)
+	assert.Error(t, client.Do(compiledRoute, nil, nil))
+	assert.Equal(t, 1, requests)
+
+	requests = 0
+	assert.NoError(t, client.Do(compiledRoute, nil, nil, WithIdempotent()))
+	assert.Equal(t, 2, requests)
+}
+
+type retryPolicyFunc func(attempt RetryAttempt) (time.Duration, bool)
+
+func (f retryPolicyFunc) Retry(attempt RetryAttempt) (time.Duration, bool) {
+	return f(attempt)
+}
+
+func TestClientRetryAttemptIgnoresRateLimits(t *testing.T) {
+	statuses := []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusTooManyRequests, http.StatusBadGateway, http.StatusNoContent}
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[requests]
+		requests++
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	var tries []int
+	client := NewClient("token", WithURL(server.URL), WithRetryPolicy(retryPolicyFunc(func(attempt RetryAttempt) (time.Duration, bool) {
+		tries = append(tries, attempt.Try)
+		return 0, true
+	})))
+	defer client.Close(context.Background())
+
+	compiledRoute, err := route.GetGateway.Compile(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, client.Do(compiledRoute, nil, nil))
+	assert.Equal(t, len(statuses), requests)
+	assert.Equal(t, []int{1, 2}, tries)
+}
@@ -55,7 +55,7 @@ var (
 	DeleteBan = NewAPIRoute(DELETE, "/guilds/{guild.id}/bans/{user.id}")
 
 	GetMember        = NewAPIRoute(GET, "/guilds/{guild.id}/members/{user.id}")
-	GetMembers       = NewAPIRoute(GET, "/guilds/{guild.id}/members")
+	GetMembers       = NewAPIRoute(GET, "/guilds/{guild.id}/members", "limit", "after")
 	SearchMembers    = NewAPIRoute(GET, "/guilds/{guild.id}/members/search", "query", "limit")
 	AddMember        = NewAPIRoute(PUT, "/guilds/{guild.id}/members/{user.id}")
 	UpdateMember     = NewAPIRoute(PATCH, "/guilds/{guild.id}/members/{user.id}")
@@ -70,7 +70,7 @@ var (
 
 	GetGuildWebhooks = NewAPIRoute(GET, "/guilds/{guild.id}/webhooks")
 
-	GetAuditLogs = NewAPIRoute(GET, "/guilds/{guild.id}/audit-logs", "user_id", "action_type", "before", "limit")
+	GetAuditLogs = NewAPIRoute(GET, "/guilds/{guild.id}/audit-logs", "user_id", "action_type", "before", "after", "limit")
 
 	GetGuildVoiceRegions = NewAPIRoute(GET, "/guilds/{guild.id}/regions")
 
@@ -109,6 +109,15 @@ var (
 	GetGuildScheduledEventUsers = NewAPIRoute(GET, "/guilds/{guild.id}/scheduled-events/{guild_scheduled_event.id}/users", "limit", "with_member", "before", "after")
 )
 
+// AutoModeration
+var (
+	GetAutoModerationRules   = NewAPIRoute(GET, "/guilds/{guild.id}/auto-moderation/rules")
+	GetAutoModerationRule    = NewAPIRoute(GET, "/guilds/{guild.id}/auto-moderation/rules/{auto_moderation_rule.id}")
+	CreateAutoModerationRule = NewAPIRoute(POST, "/guilds/{guild.id}/auto-moderation/rules")
+	UpdateAutoModerationRule = NewAPIRoute(PATCH, "/guilds/{guild.id}/auto-moderation/rules/{auto_moderation_rule.id}")
+	DeleteAutoModerationRule = NewAPIRoute(DELETE, "/guilds/{guild.id}/auto-moderation/rules/{auto_moderation_rule.id}")
+)
+
 // StageInstance
 var (
 	GetStageInstance    = NewAPIRoute(GET, "/stage-instances/{channel.id}")
@@ -163,7 +172,7 @@ var (
 
 // Messages
 var (
-	GetMessages        = NewAPIRoute(GET, "/channels/{channel.id}/messages")
+	GetMessages        = NewAPIRoute(GET, "/channels/{channel.id}/messages", "around", "before", "after", "limit")
 	GetMessage         = NewAPIRoute(GET, "/channels/{channel.id}/messages/{message.id}")
 	CreateMessage      = NewAPIRoute(POST, "/channels/{channel.id}/messages")
 	UpdateMessage      = NewAPIRoute(PATCH, "/channels/{channel.id}/messages/{message.id}")
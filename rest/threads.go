@@ -17,6 +17,8 @@ func NewThreads(client Client) Threads {
 type Threads interface {
 	CreateThreadWithMessage(channelID snowflake.ID, messageID snowflake.ID, threadCreateWithMessage discord.ThreadCreateWithMessage, opts ...RequestOpt) (thread discord.GuildThread, err error)
 	CreateThread(channelID snowflake.ID, threadCreate discord.ThreadCreate, opts ...RequestOpt) (thread discord.GuildThread, err error)
+	// CreateForumThread creates a post in a discord.GuildForumChannel, which is a discord.GuildThread with a starter discord.Message.
+	CreateForumThread(channelID snowflake.ID, forumThreadCreate discord.ForumThreadCreate, opts ...RequestOpt) (thread *discord.ForumThread, err error)
 	JoinThread(threadID snowflake.ID, opts ...RequestOpt) error
 	LeaveThread(threadID snowflake.ID, opts ...RequestOpt) error
 	AddThreadMember(threadID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) error
@@ -27,6 +29,16 @@ type Threads interface {
 	GetPublicArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error)
 	GetPrivateArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error)
 	GetJoinedPrivateArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error)
+
+	// GetPublicArchivedThreadsPage returns a Page of the public archived discord.GuildThread(s) in the channel archived before the given time.
+	// Archived threads can only be paginated in PageDirectionBefore.
+	GetPublicArchivedThreadsPage(channelID snowflake.ID, before time.Time, opts ...PageConfigOpt) *Page[discord.GuildThread]
+	// GetPrivateArchivedThreadsPage returns a Page of the private archived discord.GuildThread(s) in the channel archived before the given time.
+	// Archived threads can only be paginated in PageDirectionBefore.
+	GetPrivateArchivedThreadsPage(channelID snowflake.ID, before time.Time, opts ...PageConfigOpt) *Page[discord.GuildThread]
+	// GetJoinedPrivateArchivedThreadsPage returns a Page of the private archived discord.GuildThread(s) the current user joined in the channel archived before the given time.
+	// Archived threads can only be paginated in PageDirectionBefore.
+	GetJoinedPrivateArchivedThreadsPage(channelID snowflake.ID, before time.Time, opts ...PageConfigOpt) *Page[discord.GuildThread]
 }
 
 type threadImpl struct {
@@ -61,6 +73,20 @@ func (s *threadImpl) CreateThread(channelID snowflake.ID, threadCreate discord.T
 	return
 }
 
+func (s *threadImpl) CreateForumThread(channelID snowflake.ID, forumThreadCreate discord.ForumThreadCreate, opts ...RequestOpt) (thread *discord.ForumThread, err error) {
+	var compiledRoute *route.CompiledAPIRoute
+	compiledRoute, err = route.CreateThread.Compile(nil, channelID)
+	if err != nil {
+		return
+	}
+	body, err := forumThreadCreate.ToBody()
+	if err != nil {
+		return
+	}
+	err = s.client.Do(compiledRoute, body, &thread, opts...)
+	return
+}
+
 func (s *threadImpl) JoinThread(threadID snowflake.ID, opts ...RequestOpt) error {
 	compiledRoute, err := route.JoinThread.Compile(nil, threadID)
 	if err != nil {
@@ -163,3 +189,45 @@ func (s *threadImpl) GetJoinedPrivateArchivedThreads(channelID snowflake.ID, bef
 	err = s.client.Do(compiledRoute, nil, &threads, opts...)
 	return
 }
+
+func (s *threadImpl) GetPublicArchivedThreadsPage(channelID snowflake.ID, before time.Time, opts ...PageConfigOpt) *Page[discord.GuildThread] {
+	return s.archivedThreadsPage(route.GetArchivedPublicThreads, channelID, before, opts)
+}
+
+func (s *threadImpl) GetPrivateArchivedThreadsPage(channelID snowflake.ID, before time.Time, opts ...PageConfigOpt) *Page[discord.GuildThread] {
+	return s.archivedThreadsPage(route.GetArchivedPrivateThreads, channelID, before, opts)
+}
+
+func (s *threadImpl) GetJoinedPrivateArchivedThreadsPage(channelID snowflake.ID, before time.Time, opts ...PageConfigOpt) *Page[discord.GuildThread] {
+	return s.archivedThreadsPage(route.GetJoinedAchievedPrivateThreads, channelID, before, opts)
+}
+
+// archivedThreadsPage returns a Page which uses the archive timestamp of the threads as cursor.
+func (s *threadImpl) archivedThreadsPage(apiRoute *route.APIRoute, channelID snowflake.ID, before time.Time, opts []PageConfigOpt) *Page[discord.GuildThread] {
+	var cursor any
+	if !before.IsZero() {
+		cursor = before.Format(time.RFC3339Nano)
+	}
+	return newPage(PageConfig{Direction: PageDirectionBefore}, opts, cursor, pageDirectionsBefore,
+		func(values route.QueryValues, opts []RequestOpt) ([]discord.GuildThread, bool, error) {
+			compiledRoute, err := apiRoute.Compile(values, channelID)
+			if err != nil {
+				return nil, false, err
+			}
+			var threads discord.GetThreads
+			if err = s.client.Do(compiledRoute, nil, &threads, opts...); err != nil {
+				return nil, false, err
+			}
+			return threads.Threads, threads.HasMore, nil
+		},
+		func(threads []discord.GuildThread, _ PageDirection) any {
+			oldest := threads[0].ThreadMetadata.ArchiveTimestamp
+			for _, thread := range threads[1:] {
+				if thread.ThreadMetadata.ArchiveTimestamp.Before(oldest) {
+					oldest = thread.ThreadMetadata.ArchiveTimestamp
+				}
+			}
+			return oldest.Format(time.RFC3339Nano)
+		},
+	)
+}
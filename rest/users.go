@@ -16,6 +16,8 @@ type Users interface {
 	GetUser(userID snowflake.ID, opts ...RequestOpt) (*discord.User, error)
 	UpdateSelfUser(selfUserUpdate discord.SelfUserUpdate, opts ...RequestOpt) (*discord.OAuth2User, error)
 	GetGuilds(before int, after int, limit int, opts ...RequestOpt) ([]discord.OAuth2Guild, error)
+	// GetGuildsPage returns a Page of the discord.OAuth2Guild(s) the current user is in starting at the given guild.
+	GetGuildsPage(startID snowflake.ID, opts ...PageConfigOpt) *Page[discord.OAuth2Guild]
 	LeaveGuild(guildID snowflake.ID, opts ...RequestOpt) error
 	GetDMChannels(opts ...RequestOpt) ([]discord.Channel, error)
 	CreateDMChannel(userID snowflake.ID, opts ...RequestOpt) (*discord.DMChannel, error)
@@ -67,6 +69,23 @@ func (s *userImpl) GetGuilds(before int, after int, limit int, opts ...RequestOp
 	return
 }
 
+func (s *userImpl) GetGuildsPage(startID snowflake.ID, opts ...PageConfigOpt) *Page[discord.OAuth2Guild] {
+	return newIDPage(startID, 200, opts, pageDirectionsAll,
+		func(guild discord.OAuth2Guild) snowflake.ID {
+			return guild.ID
+		},
+		func(values route.QueryValues, opts []RequestOpt) (guilds []discord.OAuth2Guild, err error) {
+			var compiledRoute *route.CompiledAPIRoute
+			compiledRoute, err = route.GetCurrentUserGuilds.Compile(values)
+			if err != nil {
+				return
+			}
+			err = s.client.Do(compiledRoute, nil, &guilds, opts...)
+			return
+		},
+	)
+}
+
 func (s *userImpl) LeaveGuild(guildID snowflake.ID, opts ...RequestOpt) error {
 	compiledRoute, err := route.LeaveGuild.Compile(nil, guildID)
 	if err != nil {
@@ -0,0 +1,60 @@
+package restproxy
+
+import (
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/log"
+)
+
+// DefaultConfig returns a Config with sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Logger: log.Default(),
+		URL:    "https://discord.com",
+	}
+}
+
+// Config lets you configure your Proxy instance.
+type Config struct {
+	Logger         log.Logger
+	RestClient     rest.Client
+	RestClientOpts []rest.ConfigOpt
+	URL            string
+}
+
+// ConfigOpt is a type alias for a function that takes a Config and is used to configure your Proxy.
+type ConfigOpt func(config *Config)
+
+// Apply applies the given ConfigOpt(s) to the Config
+func (c *Config) Apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithLogger sets the Logger of the Config.
+func WithLogger(logger log.Logger) ConfigOpt {
+	return func(config *Config) {
+		config.Logger = logger
+	}
+}
+
+// WithRestClient sets the rest.Client whose rest.RateLimiter & http.Client are used to forward the requests.
+func WithRestClient(restClient rest.Client) ConfigOpt {
+	return func(config *Config) {
+		config.RestClient = restClient
+	}
+}
+
+// WithRestClientOpts applies the given rest.ConfigOpt(s) to the default rest.Client.
+func WithRestClientOpts(opts ...rest.ConfigOpt) ConfigOpt {
+	return func(config *Config) {
+		config.RestClientOpts = append(config.RestClientOpts, opts...)
+	}
+}
+
+// WithURL sets the URL of discord the requests are forwarded to. The path of the requests including the api version is appended to it.
+func WithURL(url string) ConfigOpt {
+	return func(config *Config) {
+		config.URL = url
+	}
+}
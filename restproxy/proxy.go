@@ -0,0 +1,45 @@
+// Package restproxy provides an http.Handler which forwards discord api requests of multiple services through one rest.RateLimiter.
+// Point a rest.Client at it with rest.WithProxy. The proxy adds the bot token to all requests, so it must only be reachable by your own services.
+package restproxy
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgo/rest/route"
+	"github.com/disgoorg/log"
+)
+
+var _ Proxy = (*proxyImpl)(nil)
+
+// New creates a new Proxy which forwards requests with the given bot token.
+func New(token string, opts ...ConfigOpt) Proxy {
+	config := DefaultConfig()
+	config.Apply(opts)
+	if config.RestClient == nil {
+		config.RestClient = rest.NewClient(token, config.RestClientOpts...)
+	}
+
+	return &proxyImpl{
+		token:  token,
+		config: *config,
+		routes: routes{routes: map[string]*route.APIRoute{}},
+	}
+}
+
+// Proxy is an http.Handler which accepts requests shaped like the discord api, e.g. POST /api/v10/channels/{channel.id}/messages.
+// It forwards them to discord with the bot token, while waiting for the rate limit buckets of the rest.Client, and streams the responses back.
+// Requests with an OAuth2 Bearer token keep their token.
+type Proxy interface {
+	http.Handler
+
+	// Logger returns the logger used by the Proxy.
+	Logger() log.Logger
+
+	// RestClient returns the rest.Client used by the Proxy.
+	RestClient() rest.Client
+
+	// Close closes the rest.Client used by the Proxy.
+	Close(ctx context.Context)
+}
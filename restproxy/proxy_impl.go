@@ -0,0 +1,141 @@
+package restproxy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgo/rest/route"
+	"github.com/disgoorg/log"
+)
+
+// hopHeaders are not forwarded, as they only apply to a single connection.
+var hopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+type proxyImpl struct {
+	token  string
+	config Config
+	routes routes
+}
+
+func (p *proxyImpl) Logger() log.Logger {
+	return p.config.Logger
+}
+
+func (p *proxyImpl) RestClient() rest.Client {
+	return p.config.RestClient
+}
+
+func (p *proxyImpl) Close(ctx context.Context) {
+	p.config.RestClient.Close(ctx)
+}
+
+func (p *proxyImpl) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.EscapedPath()
+	prefix := apiVersionPrefix.FindString(path)
+	if prefix == "" {
+		http.Error(w, "path must start with /api", http.StatusNotFound)
+		return
+	}
+
+	compiledRoute, err := p.routes.compile(r.Method, strings.TrimPrefix(path, prefix))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rqURL := strings.TrimSuffix(p.config.URL, "/") + path
+	if r.URL.RawQuery != "" {
+		rqURL += "?" + r.URL.RawQuery
+	}
+
+	rs, err := p.do(r, compiledRoute, rqURL, body, 1)
+	if err != nil {
+		p.Logger().Errorf("error forwarding request to %s: %s", rqURL, err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer rs.Body.Close()
+
+	copyHeader(w.Header(), rs.Header)
+	w.WriteHeader(rs.StatusCode)
+	if _, err = io.Copy(flushWriter{w}, rs.Body); err != nil {
+		p.Logger().Errorf("error streaming response from %s: %s", rqURL, err)
+	}
+}
+
+// do forwards the request and retries it on 429 the same way the rest.Client does.
+func (p *proxyImpl) do(r *http.Request, compiledRoute *route.CompiledAPIRoute, rqURL string, body []byte, tries int) (*http.Response, error) {
+	rq, err := http.NewRequestWithContext(r.Context(), r.Method, rqURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	copyHeader(rq.Header, r.Header)
+	if !strings.HasPrefix(rq.Header.Get("Authorization"), discord.TokenTypeBearer.String()) {
+		rq.Header.Set("Authorization", discord.TokenTypeBot.Apply(p.token))
+	}
+
+	rateLimiter := p.config.RestClient.RateLimiter()
+	if err = rateLimiter.WaitBucket(r.Context(), compiledRoute); err != nil {
+		return nil, fmt.Errorf("error locking bucket: %w", err)
+	}
+
+	rs, err := p.config.RestClient.HTTPClient().Do(rq)
+	if err != nil {
+		_ = rateLimiter.UnlockBucket(compiledRoute, nil)
+		return nil, err
+	}
+	if err = rateLimiter.UnlockBucket(compiledRoute, rs.Header); err != nil {
+		_ = rs.Body.Close()
+		return nil, fmt.Errorf("error unlocking bucket: %w", err)
+	}
+
+	if rs.StatusCode == http.StatusTooManyRequests && tries < rateLimiter.MaxRetries() {
+		_, _ = io.Copy(io.Discard, rs.Body)
+		_ = rs.Body.Close()
+		return p.do(r, compiledRoute, rqURL, body, tries+1)
+	}
+	return rs, nil
+}
+
+func copyHeader(dst http.Header, src http.Header) {
+	for key, values := range src {
+		dst[key] = append([]string(nil), values...)
+	}
+	for _, key := range hopHeaders {
+		dst.Del(key)
+	}
+}
+
+// flushWriter flushes after each write, so responses are streamed to the client as they arrive.
+type flushWriter struct {
+	w http.ResponseWriter
+}
+
+func (w flushWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	if flusher, ok := w.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return n, err
+}
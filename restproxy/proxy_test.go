@@ -0,0 +1,81 @@
+package restproxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgo/rest/route"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoutesCompile(t *testing.T) {
+	data := []struct {
+		method      string
+		path        string
+		routePath   string
+		majorParams string
+	}{
+		{"GET", "/channels/123/messages/456", "/channels/{channel.id}/messages/{snowflake}", "channel.id=123"},
+		{"PUT", "/channels/123/messages/456/reactions/%F0%9F%91%8D/@me", "/channels/{channel.id}/messages/{snowflake}/reactions/{emoji}/@me", "channel.id=123"},
+		{"PATCH", "/guilds/123/members/456", "/guilds/{guild.id}/members/{snowflake}", "guild.id=123"},
+		{"GET", "/guilds/templates/abc", "/guilds/templates/{template.code}", ""},
+		{"PATCH", "/guilds/123/templates/abc", "/guilds/{guild.id}/templates/{template.code}", "guild.id=123"},
+		{"GET", "/guilds/123/templates", "/guilds/{guild.id}/templates", "guild.id=123"},
+		{"DELETE", "/invites/abc", "/invites/{code}", ""},
+		{"POST", "/webhooks/123/token/messages/@original", "/webhooks/{webhook.id}/{webhook.token}/messages/@original", "webhook.id=123"},
+		{"POST", "/interactions/123/token/callback", "/interactions/{interaction.id}/{interaction.token}/callback", "interaction.token=token"},
+		{"GET", "/users/@me", "/users/@me", ""},
+	}
+
+	r := routes{routes: map[string]*route.APIRoute{}}
+	for _, d := range data {
+		compiledRoute, err := r.compile(d.method, d.path)
+		assert.NoError(t, err)
+		assert.Equal(t, d.routePath, compiledRoute.APIRoute.Path(), d.path)
+		assert.Equal(t, d.majorParams, compiledRoute.MajorParams(), d.path)
+	}
+
+	compiledRoute1, _ := r.compile("GET", "/channels/1/messages/2")
+	compiledRoute2, _ := r.compile("GET", "/channels/3/messages/4")
+	assert.Same(t, compiledRoute1.APIRoute, compiledRoute2.APIRoute)
+
+	compiledRoute1, _ = r.compile("GET", "/invites/abc")
+	compiledRoute2, _ = r.compile("GET", "/invites/def")
+	assert.Same(t, compiledRoute1.APIRoute, compiledRoute2.APIRoute)
+}
+
+func TestProxyForwardsRequests(t *testing.T) {
+	var requests int
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/api/v"+route.APIVersion+"/users/123", r.URL.Path)
+		assert.Equal(t, "Bot proxy-token", r.Header.Get("Authorization"))
+		if requests == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "4")
+		_, _ = w.Write([]byte(`{"id":"123","username":"test"}`))
+	}))
+	defer upstream.Close()
+
+	proxy := New("proxy-token", WithURL(upstream.URL))
+	defer proxy.Close(context.Background())
+	proxyServer := httptest.NewServer(proxy)
+	defer proxyServer.Close()
+
+	client := rest.NewClient("client-token", rest.WithProxy(proxyServer.URL))
+	defer client.Close(context.Background())
+
+	user, err := rest.NewUsers(client).GetUser(123)
+	assert.NoError(t, err)
+	assert.Equal(t, snowflake.ID(123), user.ID)
+	assert.Equal(t, "test", user.Username)
+	assert.Equal(t, 2, requests)
+}
@@ -0,0 +1,94 @@
+package restproxy
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/disgoorg/disgo/rest/route"
+)
+
+var (
+	apiVersionPrefix = regexp.MustCompile(`^/api(/v\d+)?`)
+
+	// idParams are the names of the path params following these path segments.
+	// The major params are named like in route.MajorParameters, so they get their own rate limit buckets.
+	idParams = map[string]string{
+		"channels":     "channel.id",
+		"guilds":       "guild.id",
+		"webhooks":     "webhook.id",
+		"interactions": "interaction.id",
+		"reactions":    "emoji",
+	}
+
+	// codeParams are the names of the path params following these path segments, which are not ids but still differ per request.
+	// Without them each invite or template would add a new route.
+	codeParams = map[string]string{
+		"invites":   "code",
+		"templates": "template.code",
+	}
+
+	// tokenParams are the names of the path params following the id of these path segments.
+	tokenParams = map[string]string{
+		"webhooks":     "webhook.token",
+		"interactions": "interaction.token",
+	}
+)
+
+// routes keeps one route.APIRoute per method & path template, as the rest.RateLimiter identifies routes by their pointer.
+type routes struct {
+	mu     sync.Mutex
+	routes map[string]*route.APIRoute
+}
+
+// compile turns a request path into a route.CompiledAPIRoute by replacing all ids, codes & tokens with path params.
+// The path must not include the api prefix.
+func (r *routes) compile(method string, path string) (*route.CompiledAPIRoute, error) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	template := make([]string, len(segments))
+	var params []any
+	for i, segment := range segments {
+		var param string
+		if i > 0 {
+			previous := segments[i-1]
+			if name, ok := idParams[previous]; ok && (isID(segment) || previous == "reactions") {
+				param = name
+			} else if name, ok = codeParams[previous]; ok {
+				param = name
+			} else if i > 1 && tokenParams[segments[i-2]] != "" && isID(previous) {
+				param = tokenParams[segments[i-2]]
+			}
+		}
+		if param == "" && isID(segment) {
+			param = "snowflake"
+		}
+		if param == "" {
+			template[i] = segment
+			continue
+		}
+		template[i] = "{" + param + "}"
+		params = append(params, segment)
+	}
+
+	key := method + " /" + strings.Join(template, "/")
+	r.mu.Lock()
+	apiRoute, ok := r.routes[key]
+	if !ok {
+		apiRoute = route.NewAPIRoute(route.Method(method), "/"+strings.Join(template, "/"))
+		r.routes[key] = apiRoute
+	}
+	r.mu.Unlock()
+	return apiRoute.Compile(nil, params...)
+}
+
+func isID(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	for _, c := range segment {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
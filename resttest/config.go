@@ -0,0 +1,83 @@
+package resttest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest/route"
+	"github.com/disgoorg/log"
+)
+
+// DefaultConfig returns a Config with sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Logger: log.Default(),
+		SelfUser: discord.User{
+			ID:            1,
+			Username:      "resttest",
+			Discriminator: "0000",
+			Bot:           true,
+		},
+		RateLimit:      50,
+		RateLimitReset: time.Second,
+		UpstreamURL:    route.API,
+		HTTPClient:     &http.Client{Timeout: 20 * time.Second},
+	}
+}
+
+// Config lets you configure your Server, Recorder & replaying http.Handler.
+type Config struct {
+	Logger         log.Logger
+	SelfUser       discord.User
+	RateLimit      int
+	RateLimitReset time.Duration
+	UpstreamURL    string
+	HTTPClient     *http.Client
+}
+
+// ConfigOpt is a type alias for a function that takes a Config and is used to configure your Server.
+type ConfigOpt func(config *Config)
+
+// Apply applies the given ConfigOpt(s) to the Config
+func (c *Config) Apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithLogger sets the Logger of the Config.
+func WithLogger(logger log.Logger) ConfigOpt {
+	return func(config *Config) {
+		config.Logger = logger
+	}
+}
+
+// WithSelfUser sets the discord.User the Server authors messages & creates guilds as.
+func WithSelfUser(user discord.User) ConfigOpt {
+	return func(config *Config) {
+		config.SelfUser = user
+	}
+}
+
+// WithRateLimit sets how many requests the Server allows per rate limit bucket until it resets after resetAfter.
+func WithRateLimit(limit int, resetAfter time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.RateLimit = limit
+		config.RateLimitReset = resetAfter
+	}
+}
+
+// WithUpstreamURL sets the URL of discord including the api version the Recorder forwards requests to.
+func WithUpstreamURL(url string) ConfigOpt {
+	return func(config *Config) {
+		config.UpstreamURL = url
+	}
+}
+
+// WithHTTPClient sets the http.Client the Recorder forwards requests with.
+func WithHTTPClient(httpClient *http.Client) ConfigOpt {
+	return func(config *Config) {
+		config.HTTPClient = httpClient
+	}
+}
@@ -0,0 +1,269 @@
+package resttest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/log"
+)
+
+// fixtureHeaders are the response headers which are saved in fixtures. Other headers like cookies are dropped.
+var fixtureHeaders = []string{
+	"Content-Type",
+	"Retry-After",
+	"X-RateLimit-Limit",
+	"X-RateLimit-Remaining",
+	"X-RateLimit-Reset",
+	"X-RateLimit-Reset-After",
+	"X-RateLimit-Bucket",
+	"X-RateLimit-Global",
+	"X-RateLimit-Scope",
+}
+
+// Fixture is a recorded request & its response.
+type Fixture struct {
+	Request  FixtureRequest  `json:"request"`
+	Response FixtureResponse `json:"response"`
+}
+
+// FixtureRequest is a recorded request. Its headers are not saved, so the token doesn't end up in fixture files.
+type FixtureRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Query  string          `json:"query,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// FixtureResponse is a recorded response.
+type FixtureResponse struct {
+	Status  int             `json:"status"`
+	Headers http.Header     `json:"headers,omitempty"`
+	Body    json.RawMessage `json:"body,omitempty"`
+}
+
+// LoadFixtures reads the Fixture(s) from the fixture file at the given path.
+func LoadFixtures(path string) ([]Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fixtures []Fixture
+	if err = json.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal fixtures: %w", err)
+	}
+	return fixtures, nil
+}
+
+// SaveFixtures writes the Fixture(s) to the fixture file at the given path.
+func SaveFixtures(path string, fixtures []Fixture) error {
+	data, err := json.MarshalIndent(fixtures, "", "\t")
+	if err != nil {
+		return fmt.Errorf("failed to marshal fixtures: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// fixtureBody returns the body as is if it's json, else as json string.
+func fixtureBody(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	var raw json.RawMessage
+	if err := json.Unmarshal(body, &raw); err == nil {
+		return body
+	}
+	data, _ := json.Marshal(string(body))
+	return data
+}
+
+// fixtureKey identifies the Fixture(s) which are replayed for a request.
+func fixtureKey(method string, path string, query string) string {
+	return method + " " + path + "?" + query
+}
+
+var _ Recorder = (*recorderImpl)(nil)
+
+// NewRecorder creates a new Recorder which forwards requests to Config.UpstreamURL & records them, so they can be saved to the fixture file at the given path.
+// It forwards the Authorization header of the requests, so point a rest.Client with a real token at it.
+func NewRecorder(fixturePath string, opts ...ConfigOpt) Recorder {
+	config := DefaultConfig()
+	config.Apply(opts)
+
+	return &recorderImpl{
+		config:      *config,
+		fixturePath: fixturePath,
+	}
+}
+
+// Recorder is an http.Handler which records the requests to the discord api & their responses as Fixture(s).
+type Recorder interface {
+	http.Handler
+
+	// Logger returns the logger used by the Recorder.
+	Logger() log.Logger
+
+	// Fixtures returns the Fixture(s) recorded so far.
+	Fixtures() []Fixture
+
+	// Save writes the Fixture(s) recorded so far to the fixture file.
+	Save() error
+}
+
+type recorderImpl struct {
+	config      Config
+	fixturePath string
+
+	fixtures   []Fixture
+	fixturesMu sync.Mutex
+}
+
+func (r *recorderImpl) Logger() log.Logger {
+	return r.config.Logger
+}
+
+func (r *recorderImpl) Fixtures() []Fixture {
+	r.fixturesMu.Lock()
+	defer r.fixturesMu.Unlock()
+	return append([]Fixture(nil), r.fixtures...)
+}
+
+func (r *recorderImpl) Save() error {
+	return SaveFixtures(r.fixturePath, r.Fixtures())
+}
+
+func (r *recorderImpl) ServeHTTP(w http.ResponseWriter, rq *http.Request) {
+	path := trimAPIPrefix(rq.URL.EscapedPath())
+	body, err := io.ReadAll(rq.Body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, apiError{Message: err.Error()})
+		return
+	}
+
+	rqURL := strings.TrimSuffix(r.config.UpstreamURL, "/") + path
+	if rq.URL.RawQuery != "" {
+		rqURL += "?" + rq.URL.RawQuery
+	}
+	upstreamRq, err := http.NewRequestWithContext(rq.Context(), rq.Method, rqURL, bytes.NewReader(body))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, apiError{Message: err.Error()})
+		return
+	}
+	for _, key := range []string{"Authorization", "Content-Type", "User-Agent", "X-Audit-Log-Reason", "X-Discord-Locale"} {
+		if value := rq.Header.Get(key); value != "" {
+			upstreamRq.Header.Set(key, value)
+		}
+	}
+
+	rs, err := r.config.HTTPClient.Do(upstreamRq)
+	if err != nil {
+		r.Logger().Errorf("error forwarding request to %s: %s", rqURL, err)
+		writeJSON(w, http.StatusBadGateway, apiError{Message: err.Error()})
+		return
+	}
+	defer rs.Body.Close()
+	rsBody, err := io.ReadAll(rs.Body)
+	if err != nil {
+		r.Logger().Errorf("error reading response from %s: %s", rqURL, err)
+		writeJSON(w, http.StatusBadGateway, apiError{Message: err.Error()})
+		return
+	}
+
+	headers := http.Header{}
+	for _, key := range fixtureHeaders {
+		if value := rs.Header.Get(key); value != "" {
+			headers.Set(key, value)
+		}
+	}
+
+	r.fixturesMu.Lock()
+	r.fixtures = append(r.fixtures, Fixture{
+		Request: FixtureRequest{
+			Method: rq.Method,
+			Path:   path,
+			Query:  rq.URL.RawQuery,
+			Body:   fixtureBody(body),
+		},
+		Response: FixtureResponse{
+			Status:  rs.StatusCode,
+			Headers: headers,
+			Body:    fixtureBody(rsBody),
+		},
+	})
+	r.fixturesMu.Unlock()
+
+	for key, values := range headers {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(rs.StatusCode)
+	_, _ = w.Write(rsBody)
+}
+
+// NewReplayer creates a new http.Handler which replays the Fixture(s) of the fixture file at the given path.
+// Requests are matched by method, path & query. Fixtures of the same request are replayed in the recorded order, repeating the last one.
+// Requests without a Fixture respond with 404.
+func NewReplayer(fixturePath string, opts ...ConfigOpt) (http.Handler, error) {
+	config := DefaultConfig()
+	config.Apply(opts)
+
+	fixtures, err := LoadFixtures(fixturePath)
+	if err != nil {
+		return nil, err
+	}
+
+	replayer := &replayer{
+		logger:   config.Logger,
+		fixtures: map[string][]Fixture{},
+	}
+	for _, fixture := range fixtures {
+		key := fixtureKey(fixture.Request.Method, fixture.Request.Path, fixture.Request.Query)
+		replayer.fixtures[key] = append(replayer.fixtures[key], fixture)
+	}
+	return replayer, nil
+}
+
+type replayer struct {
+	logger log.Logger
+
+	// key -> fixtures not replayed yet
+	fixtures   map[string][]Fixture
+	fixturesMu sync.Mutex
+}
+
+func (r *replayer) ServeHTTP(w http.ResponseWriter, rq *http.Request) {
+	path := trimAPIPrefix(rq.URL.EscapedPath())
+	key := fixtureKey(rq.Method, path, rq.URL.RawQuery)
+
+	r.fixturesMu.Lock()
+	fixtures := r.fixtures[key]
+	if len(fixtures) == 0 {
+		r.fixturesMu.Unlock()
+		r.logger.Debugf("no fixture for %s", key)
+		writeJSON(w, http.StatusNotFound, apiError{Message: "no fixture for " + key})
+		return
+	}
+	fixture := fixtures[0]
+	if len(fixtures) > 1 {
+		r.fixtures[key] = fixtures[1:]
+	}
+	r.fixturesMu.Unlock()
+
+	for key, values := range fixture.Response.Headers {
+		w.Header()[key] = values
+	}
+	body := []byte(fixture.Response.Body)
+	if !strings.HasPrefix(fixture.Response.Headers.Get("Content-Type"), "application/json") {
+		// non json bodies are saved as json string
+		var str string
+		if err := json.Unmarshal(body, &str); err == nil {
+			body = []byte(str)
+		}
+	}
+	w.WriteHeader(fixture.Response.Status)
+	_, _ = w.Write(body)
+}
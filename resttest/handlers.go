@@ -0,0 +1,595 @@
+package resttest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/disgoorg/disgo/rest/route"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// discord json error codes
+const (
+	codeUnknownChannel  = 10003
+	codeUnknownGuild    = 10004
+	codeUnknownMember   = 10007
+	codeUnknownMessage  = 10008
+	codeUnknownUser     = 10013
+	codeInvalidFormBody = 50035
+)
+
+func (s *serverImpl) registerHandlers() {
+	s.handle(route.GetGateway, s.getGateway)
+	s.handle(route.GetGatewayBot, s.getGatewayBot)
+
+	s.handle(route.GetCurrentUser, s.getCurrentUser)
+	s.handle(route.GetCurrentUserGuilds, s.getCurrentUserGuilds)
+	s.handle(route.GetUser, s.getUser)
+
+	s.handle(route.CreateGuild, s.createGuild)
+	s.handle(route.GetGuild, s.getGuild)
+	s.handle(route.UpdateGuild, s.updateGuild)
+	s.handle(route.DeleteGuild, s.deleteGuild)
+	s.handle(route.GetGuildChannels, s.getGuildChannels)
+	s.handle(route.CreateGuildChannel, s.createGuildChannel)
+	s.handle(route.GetRoles, s.getRoles)
+
+	s.handle(route.GetMembers, s.getMembers)
+	s.handle(route.GetMember, s.getMember)
+	s.handle(route.AddMember, s.addMember)
+	s.handle(route.UpdateMember, s.updateMember)
+	s.handle(route.RemoveMember, s.removeMember)
+
+	s.handle(route.GetChannel, s.getChannel)
+	s.handle(route.UpdateChannel, s.updateChannel)
+	s.handle(route.DeleteChannel, s.deleteChannel)
+
+	s.handle(route.BulkDeleteMessages, s.bulkDeleteMessages)
+	s.handle(route.GetMessages, s.getMessages)
+	s.handle(route.GetMessage, s.getMessage)
+	s.handle(route.CreateMessage, s.createMessage)
+	s.handle(route.UpdateMessage, s.updateMessage)
+	s.handle(route.DeleteMessage, s.deleteMessage)
+}
+
+func notFound(code int, name string) (int, any) {
+	return http.StatusNotFound, apiError{Message: "Unknown " + name, Code: code}
+}
+
+func invalidBody(err error) (int, any) {
+	return http.StatusBadRequest, apiError{Message: "Invalid Form Body: " + err.Error(), Code: codeInvalidFormBody}
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
+
+// pageIDs returns up to limit of the ascending ids which are before & after the given ids if they are not 0.
+// If fromEnd is true, the ids closest to before are returned, else the ids closest to after.
+func pageIDs(ids []snowflake.ID, before snowflake.ID, after snowflake.ID, limit int, fromEnd bool) []snowflake.ID {
+	filtered := make([]snowflake.ID, 0, len(ids))
+	for _, id := range ids {
+		if (before == 0 || id < before) && (after == 0 || id > after) {
+			filtered = append(filtered, id)
+		}
+	}
+	if len(filtered) <= limit {
+		return filtered
+	}
+	if fromEnd {
+		return filtered[len(filtered)-limit:]
+	}
+	return filtered[:limit]
+}
+
+// clampLimit returns the limit query param or def, capped between 1 & maxLimit.
+func clampLimit(rq *request, def int, maxLimit int) int {
+	limit := rq.queryInt("limit", def)
+	if limit < 1 {
+		return 1
+	}
+	if limit > maxLimit {
+		return maxLimit
+	}
+	return limit
+}
+
+func (s *serverImpl) getGateway(_ *request) (int, any) {
+	return http.StatusOK, object{"url": "wss://gateway.discord.gg"}
+}
+
+func (s *serverImpl) getGatewayBot(_ *request) (int, any) {
+	return http.StatusOK, object{
+		"url":    "wss://gateway.discord.gg",
+		"shards": 1,
+		"session_start_limit": object{
+			"total":           1000,
+			"remaining":       1000,
+			"reset_after":     0,
+			"max_concurrency": 1,
+		},
+	}
+}
+
+func (s *serverImpl) getCurrentUser(_ *request) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return http.StatusOK, s.users[s.config.SelfUser.ID].clone()
+}
+
+func (s *serverImpl) getCurrentUserGuilds(rq *request) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before, after := rq.queryID("before"), rq.queryID("after")
+	ids := pageIDs(sortedIDs(s.guilds), before, after, clampLimit(rq, 200, 200), before != 0 && after == 0)
+	guilds := make([]object, 0, len(ids))
+	for _, id := range ids {
+		guild := s.guilds[id]
+		guilds = append(guilds, object{
+			"id":          guild["id"],
+			"name":        guild["name"],
+			"icon":        guild["icon"],
+			"owner":       guild.id("owner_id") == s.config.SelfUser.ID,
+			"permissions": "0",
+			"features":    guild["features"],
+		})
+	}
+	return http.StatusOK, guilds
+}
+
+func (s *serverImpl) getUser(rq *request) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user, ok := s.users[rq.id("user.id")]
+	if !ok {
+		return notFound(codeUnknownUser, "User")
+	}
+	return http.StatusOK, user.clone()
+}
+
+func (s *serverImpl) createGuild(rq *request) (int, any) {
+	body, err := rq.object()
+	if err != nil {
+		return invalidBody(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.newID()
+	guild := object{
+		"id":                            id.String(),
+		"name":                          "",
+		"icon":                          nil,
+		"owner_id":                      s.config.SelfUser.ID.String(),
+		"afk_timeout":                   300,
+		"verification_level":            0,
+		"default_message_notifications": 0,
+		"explicit_content_filter":       0,
+		"features":                      []any{},
+		"mfa_level":                     0,
+		"system_channel_flags":          0,
+		"preferred_locale":              "en-US",
+		"roles":                         []any{everyoneRole(id)},
+		"emojis":                        []any{},
+		"stickers":                      []any{},
+	}
+	guild.merge(body, "name", "verification_level", "afk_timeout", "system_channel_flags")
+	s.guilds[id] = guild
+
+	s.members[id] = map[snowflake.ID]object{
+		s.config.SelfUser.ID: s.newMember(s.users[s.config.SelfUser.ID]),
+	}
+
+	if channels, ok := body["channels"].([]any); ok {
+		for _, c := range channels {
+			if channel, ok := c.(map[string]any); ok {
+				delete(channel, "parent_id")
+				s.newChannel(id, channel)
+			}
+		}
+	}
+	return http.StatusCreated, guild.clone()
+}
+
+func (s *serverImpl) getGuild(rq *request) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	guild, ok := s.guilds[rq.id("guild.id")]
+	if !ok {
+		return notFound(codeUnknownGuild, "Guild")
+	}
+	return http.StatusOK, guild.clone()
+}
+
+func (s *serverImpl) updateGuild(rq *request) (int, any) {
+	body, err := rq.object()
+	if err != nil {
+		return invalidBody(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	guild, ok := s.guilds[rq.id("guild.id")]
+	if !ok {
+		return notFound(codeUnknownGuild, "Guild")
+	}
+	guild.merge(body)
+	return http.StatusOK, guild.clone()
+}
+
+func (s *serverImpl) deleteGuild(rq *request) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	guildID := rq.id("guild.id")
+	if _, ok := s.guilds[guildID]; !ok {
+		return notFound(codeUnknownGuild, "Guild")
+	}
+	delete(s.guilds, guildID)
+	delete(s.members, guildID)
+	for id, channel := range s.channels {
+		if channel.id("guild_id") == guildID {
+			delete(s.channels, id)
+			delete(s.messages, id)
+		}
+	}
+	return http.StatusNoContent, nil
+}
+
+func (s *serverImpl) getGuildChannels(rq *request) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	guildID := rq.id("guild.id")
+	if _, ok := s.guilds[guildID]; !ok {
+		return notFound(codeUnknownGuild, "Guild")
+	}
+	channels := []object{}
+	for _, id := range sortedIDs(s.channels) {
+		if channel := s.channels[id]; channel.id("guild_id") == guildID {
+			channels = append(channels, channel.clone())
+		}
+	}
+	return http.StatusOK, channels
+}
+
+func (s *serverImpl) createGuildChannel(rq *request) (int, any) {
+	body, err := rq.object()
+	if err != nil {
+		return invalidBody(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	guildID := rq.id("guild.id")
+	if _, ok := s.guilds[guildID]; !ok {
+		return notFound(codeUnknownGuild, "Guild")
+	}
+	return http.StatusCreated, s.newChannel(guildID, body).clone()
+}
+
+// newChannel creates a guild channel from the request body. The lock must be held.
+func (s *serverImpl) newChannel(guildID snowflake.ID, body object) object {
+	id := s.newID()
+	channel := object{
+		"id":                    id.String(),
+		"guild_id":              guildID.String(),
+		"type":                  0,
+		"name":                  "",
+		"position":              0,
+		"permission_overwrites": []any{},
+		"nsfw":                  false,
+		"parent_id":             nil,
+	}
+	channel.merge(body)
+	s.channels[id] = channel
+	s.messages[id] = map[snowflake.ID]object{}
+	return channel
+}
+
+func (s *serverImpl) getRoles(rq *request) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	guild, ok := s.guilds[rq.id("guild.id")]
+	if !ok {
+		return notFound(codeUnknownGuild, "Guild")
+	}
+	return http.StatusOK, guild["roles"]
+}
+
+func (s *serverImpl) getMembers(rq *request) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	members, ok := s.members[rq.id("guild.id")]
+	if !ok {
+		return notFound(codeUnknownGuild, "Guild")
+	}
+	ids := pageIDs(sortedIDs(members), 0, rq.queryID("after"), clampLimit(rq, 1, 1000), false)
+	page := make([]object, 0, len(ids))
+	for _, id := range ids {
+		page = append(page, members[id].clone())
+	}
+	return http.StatusOK, page
+}
+
+func (s *serverImpl) getMember(rq *request) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	members, ok := s.members[rq.id("guild.id")]
+	if !ok {
+		return notFound(codeUnknownGuild, "Guild")
+	}
+	member, ok := members[rq.id("user.id")]
+	if !ok {
+		return notFound(codeUnknownMember, "Member")
+	}
+	return http.StatusOK, member.clone()
+}
+
+func (s *serverImpl) addMember(rq *request) (int, any) {
+	body, err := rq.object()
+	if err != nil {
+		return invalidBody(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	members, ok := s.members[rq.id("guild.id")]
+	if !ok {
+		return notFound(codeUnknownGuild, "Guild")
+	}
+	userID := rq.id("user.id")
+	if _, ok = members[userID]; ok {
+		return http.StatusNoContent, nil
+	}
+	user, ok := s.users[userID]
+	if !ok {
+		user = object{
+			"id":            userID.String(),
+			"username":      "user-" + userID.String(),
+			"discriminator": "0000",
+			"avatar":        nil,
+		}
+		s.users[userID] = user
+	}
+	member := s.newMember(user)
+	member.merge(body, "nick", "roles", "mute", "deaf")
+	members[userID] = member
+	return http.StatusCreated, member.clone()
+}
+
+// newMember creates a member of the given user which joined now.
+func (s *serverImpl) newMember(user object) object {
+	return object{
+		"user":                         user.clone(),
+		"nick":                         nil,
+		"avatar":                       nil,
+		"roles":                        []any{},
+		"joined_at":                    now(),
+		"deaf":                         false,
+		"mute":                         false,
+		"pending":                      false,
+		"communication_disabled_until": nil,
+	}
+}
+
+func (s *serverImpl) updateMember(rq *request) (int, any) {
+	body, err := rq.object()
+	if err != nil {
+		return invalidBody(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	members, ok := s.members[rq.id("guild.id")]
+	if !ok {
+		return notFound(codeUnknownGuild, "Guild")
+	}
+	member, ok := members[rq.id("user.id")]
+	if !ok {
+		return notFound(codeUnknownMember, "Member")
+	}
+	member.merge(body, "nick", "roles", "mute", "deaf", "communication_disabled_until")
+	return http.StatusOK, member.clone()
+}
+
+func (s *serverImpl) removeMember(rq *request) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	members, ok := s.members[rq.id("guild.id")]
+	if !ok {
+		return notFound(codeUnknownGuild, "Guild")
+	}
+	userID := rq.id("user.id")
+	if _, ok = members[userID]; !ok {
+		return notFound(codeUnknownMember, "Member")
+	}
+	delete(members, userID)
+	return http.StatusNoContent, nil
+}
+
+func (s *serverImpl) getChannel(rq *request) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	channel, ok := s.channels[rq.id("channel.id")]
+	if !ok {
+		return notFound(codeUnknownChannel, "Channel")
+	}
+	return http.StatusOK, channel.clone()
+}
+
+func (s *serverImpl) updateChannel(rq *request) (int, any) {
+	body, err := rq.object()
+	if err != nil {
+		return invalidBody(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	channel, ok := s.channels[rq.id("channel.id")]
+	if !ok {
+		return notFound(codeUnknownChannel, "Channel")
+	}
+	delete(body, "guild_id")
+	channel.merge(body)
+	return http.StatusOK, channel.clone()
+}
+
+func (s *serverImpl) deleteChannel(rq *request) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	channelID := rq.id("channel.id")
+	channel, ok := s.channels[channelID]
+	if !ok {
+		return notFound(codeUnknownChannel, "Channel")
+	}
+	delete(s.channels, channelID)
+	delete(s.messages, channelID)
+	return http.StatusOK, channel.clone()
+}
+
+func (s *serverImpl) getMessages(rq *request) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	messages, ok := s.messages[rq.id("channel.id")]
+	if !ok {
+		return notFound(codeUnknownChannel, "Channel")
+	}
+
+	var (
+		ids    = sortedIDs(messages)
+		limit  = clampLimit(rq, 50, 100)
+		around = rq.queryID("around")
+		after  = rq.queryID("after")
+		page   []snowflake.ID
+	)
+	switch {
+	case around != 0:
+		page = pageIDs(ids, around, 0, limit/2, true)
+		page = append(page, pageIDs(ids, 0, around-1, limit-len(page), false)...)
+	case after != 0:
+		page = pageIDs(ids, 0, after, limit, false)
+	default:
+		page = pageIDs(ids, rq.queryID("before"), 0, limit, true)
+	}
+
+	// discord returns the newest message first
+	rs := make([]object, len(page))
+	for i, id := range page {
+		rs[len(page)-1-i] = messages[id].clone()
+	}
+	return http.StatusOK, rs
+}
+
+func (s *serverImpl) getMessage(rq *request) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	messages, ok := s.messages[rq.id("channel.id")]
+	if !ok {
+		return notFound(codeUnknownChannel, "Channel")
+	}
+	message, ok := messages[rq.id("message.id")]
+	if !ok {
+		return notFound(codeUnknownMessage, "Message")
+	}
+	return http.StatusOK, message.clone()
+}
+
+func (s *serverImpl) createMessage(rq *request) (int, any) {
+	body, err := rq.object()
+	if err != nil {
+		return invalidBody(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	channelID := rq.id("channel.id")
+	channel, ok := s.channels[channelID]
+	if !ok {
+		return notFound(codeUnknownChannel, "Channel")
+	}
+
+	id := s.newID()
+	message := object{
+		"id":               id.String(),
+		"channel_id":       channelID.String(),
+		"author":           s.users[s.config.SelfUser.ID].clone(),
+		"content":          "",
+		"timestamp":        now(),
+		"edited_timestamp": nil,
+		"tts":              false,
+		"mention_everyone": false,
+		"mentions":         []any{},
+		"mention_roles":    []any{},
+		"attachments":      []any{},
+		"embeds":           []any{},
+		"pinned":           false,
+		"type":             0,
+		"flags":            0,
+	}
+	if guildID, ok := channel["guild_id"]; ok {
+		message["guild_id"] = guildID
+	}
+	message.merge(body, "content", "embeds", "components", "tts", "flags", "message_reference")
+	s.messages[channelID][id] = message
+	channel["last_message_id"] = id.String()
+	return http.StatusOK, message.clone()
+}
+
+func (s *serverImpl) updateMessage(rq *request) (int, any) {
+	body, err := rq.object()
+	if err != nil {
+		return invalidBody(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	messages, ok := s.messages[rq.id("channel.id")]
+	if !ok {
+		return notFound(codeUnknownChannel, "Channel")
+	}
+	message, ok := messages[rq.id("message.id")]
+	if !ok {
+		return notFound(codeUnknownMessage, "Message")
+	}
+	message.merge(body, "content", "embeds", "components", "flags")
+	message["edited_timestamp"] = now()
+	return http.StatusOK, message.clone()
+}
+
+func (s *serverImpl) deleteMessage(rq *request) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	messages, ok := s.messages[rq.id("channel.id")]
+	if !ok {
+		return notFound(codeUnknownChannel, "Channel")
+	}
+	messageID := rq.id("message.id")
+	if _, ok = messages[messageID]; !ok {
+		return notFound(codeUnknownMessage, "Message")
+	}
+	delete(messages, messageID)
+	return http.StatusNoContent, nil
+}
+
+func (s *serverImpl) bulkDeleteMessages(rq *request) (int, any) {
+	body, err := rq.object()
+	if err != nil {
+		return invalidBody(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	messages, ok := s.messages[rq.id("channel.id")]
+	if !ok {
+		return notFound(codeUnknownChannel, "Channel")
+	}
+	ids, _ := body["messages"].([]any)
+	for _, id := range ids {
+		if str, ok := id.(string); ok {
+			messageID, _ := snowflake.Parse(str)
+			delete(messages, messageID)
+		}
+	}
+	return http.StatusNoContent, nil
+}
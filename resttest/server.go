@@ -0,0 +1,298 @@
+// Package resttest provides a fake of the discord rest api for testing code built on rest.Rest without mocking its services.
+// The Server keeps guilds, channels, messages & members in memory and sends rate limit headers like discord does, so the rest.RateLimiter is exercised.
+// Point a rest.Client at it with rest.WithURL:
+//
+//	server := httptest.NewServer(resttest.New())
+//	client := rest.New(rest.NewClient("token", rest.WithURL(server.URL)))
+//
+// NewRecorder & NewReplayer record the requests to the real discord api into fixture files & replay them, for routes the Server doesn't implement.
+package resttest
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/disgo/rest/route"
+	"github.com/disgoorg/log"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+var (
+	_ Server = (*serverImpl)(nil)
+
+	apiVersionPrefix = regexp.MustCompile(`^/api(/v\d+)?`)
+)
+
+// New creates a new Server with the given ConfigOpt(s).
+func New(opts ...ConfigOpt) Server {
+	config := DefaultConfig()
+	config.Apply(opts)
+
+	s := &serverImpl{
+		config:   *config,
+		buckets:  map[string]*bucket{},
+		users:    map[snowflake.ID]object{},
+		guilds:   map[snowflake.ID]object{},
+		channels: map[snowflake.ID]object{},
+		messages: map[snowflake.ID]map[snowflake.ID]object{},
+		members:  map[snowflake.ID]map[snowflake.ID]object{},
+	}
+	s.AddUser(config.SelfUser)
+	s.registerHandlers()
+	return s
+}
+
+// Server is an http.Handler which implements the common routes of the discord api in memory.
+// It accepts paths with or without the api prefix, e.g. /api/v10/channels/{channel.id} & /channels/{channel.id}.
+// Unknown routes respond with 404.
+type Server interface {
+	http.Handler
+
+	// Logger returns the logger used by the Server.
+	Logger() log.Logger
+
+	// AddUser adds or replaces the discord.User.
+	AddUser(user discord.User)
+
+	// AddGuild adds or replaces the discord.Guild.
+	AddGuild(guild discord.Guild)
+
+	// AddChannel adds or replaces the discord.Channel.
+	AddChannel(channel discord.Channel)
+
+	// AddMember adds or replaces the discord.Member of the guild & adds its discord.User.
+	AddMember(guildID snowflake.ID, member discord.Member)
+
+	// AddMessage adds or replaces the discord.Message in its channel.
+	AddMessage(message discord.Message)
+}
+
+type (
+	// handlerFunc handles a request to a route & returns the status code & the body which is sent as json.
+	handlerFunc func(rq *request) (int, any)
+
+	handler struct {
+		route    *route.APIRoute
+		segments []string
+		handle   handlerFunc
+	}
+
+	request struct {
+		*http.Request
+		params map[string]string
+		body   []byte
+	}
+
+	bucket struct {
+		id        string
+		remaining int
+		reset     time.Time
+	}
+
+	apiError struct {
+		Message string `json:"message"`
+		Code    int    `json:"code"`
+	}
+
+	rateLimitError struct {
+		Message    string  `json:"message"`
+		RetryAfter float64 `json:"retry_after"`
+		Global     bool    `json:"global"`
+	}
+)
+
+type serverImpl struct {
+	config   Config
+	handlers []handler
+
+	buckets   map[string]*bucket
+	bucketsMu sync.Mutex
+
+	mu       sync.Mutex
+	lastID   snowflake.ID
+	users    map[snowflake.ID]object
+	guilds   map[snowflake.ID]object
+	channels map[snowflake.ID]object
+	// channel id -> message id -> message
+	messages map[snowflake.ID]map[snowflake.ID]object
+	// guild id -> user id -> member
+	members map[snowflake.ID]map[snowflake.ID]object
+}
+
+func (s *serverImpl) Logger() log.Logger {
+	return s.config.Logger
+}
+
+// handle registers the handlerFunc for the route.APIRoute. Routes registered first win if multiple match.
+func (s *serverImpl) handle(apiRoute *route.APIRoute, handle handlerFunc) {
+	s.handlers = append(s.handlers, handler{
+		route:    apiRoute,
+		segments: strings.Split(strings.Trim(apiRoute.Path(), "/"), "/"),
+		handle:   handle,
+	})
+}
+
+func (s *serverImpl) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := trimAPIPrefix(r.URL.Path)
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	var (
+		h      *handler
+		params map[string]string
+	)
+	for i := range s.handlers {
+		if s.handlers[i].route.Method().String() != r.Method {
+			continue
+		}
+		if p, ok := matchSegments(s.handlers[i].segments, segments); ok {
+			h = &s.handlers[i]
+			params = p
+			break
+		}
+	}
+	if h == nil {
+		s.Logger().Debugf("no handler for %s %s", r.Method, path)
+		writeJSON(w, http.StatusNotFound, apiError{Message: "404: Not Found", Code: 0})
+		return
+	}
+
+	if ok := s.rateLimit(w, h, params); !ok {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, apiError{Message: err.Error(), Code: 50109})
+		return
+	}
+
+	status, rsBody := h.handle(&request{Request: r, params: params, body: body})
+	writeJSON(w, status, rsBody)
+}
+
+// rateLimit sets the rate limit headers of the bucket of the request & responds with 429 if the bucket is exhausted.
+func (s *serverImpl) rateLimit(w http.ResponseWriter, h *handler, params map[string]string) bool {
+	key := h.route.Method().String() + "+" + h.route.Path()
+	for _, param := range []string{"guild.id", "channel.id", "webhook.id"} {
+		if value, ok := params[param]; ok {
+			key += "+" + param + "=" + value
+		}
+	}
+
+	s.bucketsMu.Lock()
+	defer s.bucketsMu.Unlock()
+
+	now := time.Now()
+	b, ok := s.buckets[key]
+	if !ok {
+		hash := fnv.New64a()
+		_, _ = hash.Write([]byte(h.route.Method().String() + "+" + h.route.Path()))
+		b = &bucket{id: strconv.FormatUint(hash.Sum64(), 16)}
+		s.buckets[key] = b
+	}
+	if !now.Before(b.reset) {
+		b.remaining = s.config.RateLimit
+		// round up to whole milliseconds, so the reset header is exact
+		b.reset = time.UnixMilli(int64(math.Ceil(float64(now.Add(s.config.RateLimitReset).UnixNano()) / float64(time.Millisecond))))
+	}
+
+	resetAfter := b.reset.Sub(now).Seconds()
+	header := w.Header()
+	header.Set("X-RateLimit-Limit", strconv.Itoa(s.config.RateLimit))
+	header.Set("X-RateLimit-Reset", fmt.Sprintf("%.3f", float64(b.reset.UnixMilli())/1000))
+	header.Set("X-RateLimit-Reset-After", fmt.Sprintf("%.3f", resetAfter))
+	header.Set("X-RateLimit-Bucket", b.id)
+
+	if b.remaining == 0 {
+		header.Set("X-RateLimit-Remaining", "0")
+		header.Set("X-RateLimit-Scope", "user")
+		header.Set("Retry-After", strconv.Itoa(int(math.Ceil(resetAfter))))
+		writeJSON(w, http.StatusTooManyRequests, rateLimitError{
+			Message:    "You are being rate limited.",
+			RetryAfter: resetAfter,
+		})
+		return false
+	}
+	b.remaining--
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(b.remaining))
+	return true
+}
+
+// matchSegments returns the values of the path params if the path segments match the route segments.
+func matchSegments(routeSegments []string, segments []string) (map[string]string, bool) {
+	if len(routeSegments) != len(segments) {
+		return nil, false
+	}
+	params := map[string]string{}
+	for i, routeSegment := range routeSegments {
+		if strings.HasPrefix(routeSegment, "{") && strings.HasSuffix(routeSegment, "}") {
+			params[routeSegment[1:len(routeSegment)-1]] = segments[i]
+			continue
+		}
+		if routeSegment != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// trimAPIPrefix removes the /api/vN prefix from the path if it has one.
+func trimAPIPrefix(path string) string {
+	return strings.TrimPrefix(path, apiVersionPrefix.FindString(path))
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	if status == http.StatusNoContent || body == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// id returns the path param with the given name as snowflake.ID or 0 if it's not a valid one.
+func (r *request) id(name string) snowflake.ID {
+	id, _ := snowflake.Parse(r.params[name])
+	return id
+}
+
+// queryID returns the query param with the given name as snowflake.ID or 0 if it's not set.
+func (r *request) queryID(name string) snowflake.ID {
+	id, _ := snowflake.Parse(r.URL.Query().Get(name))
+	return id
+}
+
+// queryInt returns the query param with the given name as int or def if it's not set.
+func (r *request) queryInt(name string, def int) int {
+	if i, err := strconv.Atoi(r.URL.Query().Get(name)); err == nil {
+		return i
+	}
+	return def
+}
+
+// object returns the json body of the request. For multipart requests, the payload_json field is used.
+func (r *request) object() (object, error) {
+	body := r.body
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		r.Body = io.NopCloser(strings.NewReader(string(r.body)))
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			return nil, err
+		}
+		body = []byte(r.FormValue("payload_json"))
+	}
+	if len(body) == 0 {
+		return object{}, nil
+	}
+	return decodeObject(body)
+}
@@ -0,0 +1,123 @@
+package resttest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRest(t *testing.T, handler http.Handler) rest.Rest {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := rest.NewClient("token", rest.WithURL(server.URL))
+	t.Cleanup(func() {
+		client.Close(context.Background())
+	})
+	return rest.New(client)
+}
+
+func TestServer(t *testing.T) {
+	client := newTestRest(t, New())
+
+	guild, err := client.CreateGuild(discord.GuildCreate{Name: "test"})
+	assert.NoError(t, err)
+	assert.Equal(t, "test", guild.Name)
+	assert.Len(t, guild.Roles, 1)
+
+	channel, err := client.CreateGuildChannel(guild.ID, discord.GuildTextChannelCreate{Name: "general"})
+	assert.NoError(t, err)
+	assert.Equal(t, discord.ChannelTypeGuildText, channel.Type())
+	assert.Equal(t, guild.ID, channel.GuildID())
+
+	for _, content := range []string{"1", "2", "3"} {
+		_, err = client.CreateMessage(channel.ID(), discord.MessageCreate{Content: content})
+		assert.NoError(t, err)
+	}
+	messages, err := client.GetMessages(channel.ID(), 0, 0, 0, 2)
+	assert.NoError(t, err)
+	if assert.Len(t, messages, 2) {
+		assert.Equal(t, "3", messages[0].Content)
+		assert.Equal(t, "2", messages[1].Content)
+	}
+
+	message, err := client.UpdateMessage(channel.ID(), messages[0].ID, discord.MessageUpdate{Content: json.NewPtr("edited")})
+	assert.NoError(t, err)
+	assert.Equal(t, "edited", message.Content)
+	assert.NotNil(t, message.EditedTimestamp)
+
+	assert.NoError(t, client.DeleteMessage(channel.ID(), message.ID))
+	_, err = client.GetMessage(channel.ID(), message.ID)
+	var restErr *rest.Error
+	if assert.True(t, errors.As(err, &restErr)) {
+		assert.Equal(t, http.StatusNotFound, restErr.Response.StatusCode)
+	}
+
+	member, err := client.AddMember(guild.ID, 123, discord.MemberAdd{Nick: "nick"})
+	assert.NoError(t, err)
+	assert.Equal(t, "nick", *member.Nick)
+
+	member, err = client.UpdateMember(guild.ID, 123, discord.MemberUpdate{Nick: json.NewPtr("new nick")})
+	assert.NoError(t, err)
+	assert.Equal(t, "new nick", *member.Nick)
+
+	members, err := client.GetMembersPage(guild.ID, 0, rest.WithPageLimit(1)).All()
+	assert.NoError(t, err)
+	assert.Len(t, members, 2)
+}
+
+func TestServerRateLimit(t *testing.T) {
+	server := New(WithRateLimit(2, 100*time.Millisecond))
+	server.AddGuild(discord.Guild{ID: 1, Name: "test"})
+	server.AddMember(1, discord.Member{User: discord.User{ID: 2, Username: "test"}})
+	client := newTestRest(t, server)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		member, err := client.GetMember(1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, "test", member.User.Username)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
+
+func TestRecordReplay(t *testing.T) {
+	upstream := httptest.NewServer(New())
+	defer upstream.Close()
+
+	fixturePath := filepath.Join(t.TempDir(), "fixtures.json")
+	recorder := NewRecorder(fixturePath, WithUpstreamURL(upstream.URL))
+	client := newTestRest(t, recorder)
+
+	guild, err := client.CreateGuild(discord.GuildCreate{Name: "recorded"})
+	assert.NoError(t, err)
+	_, err = client.GetGuild(guild.ID, false)
+	assert.NoError(t, err)
+	assert.Len(t, recorder.Fixtures(), 2)
+	assert.NoError(t, recorder.Save())
+
+	data, err := os.ReadFile(fixturePath)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "Bot token")
+
+	replayer, err := NewReplayer(fixturePath)
+	assert.NoError(t, err)
+	client = newTestRest(t, replayer)
+
+	replayed, err := client.GetGuild(guild.ID, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "recorded", replayed.Name)
+
+	_, err = client.GetGuild(guild.ID+1, false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,161 @@
+package resttest
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/snowflake/v2"
+	"golang.org/x/exp/slices"
+)
+
+// object is a stored entity in its json form, so any field sent by a client is kept & returned like discord does.
+type object map[string]any
+
+// decodeObject decodes a json object keeping numbers as json.Number, so large numbers are not rounded.
+func decodeObject(data []byte) (object, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var o object
+	if err := decoder.Decode(&o); err != nil {
+		return nil, err
+	}
+	if o == nil {
+		o = object{}
+	}
+	return o, nil
+}
+
+// toObject returns the json form of the given value.
+func toObject(v any) object {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic("resttest: failed to marshal " + err.Error())
+	}
+	o, err := decodeObject(data)
+	if err != nil {
+		panic("resttest: failed to unmarshal " + err.Error())
+	}
+	return o
+}
+
+// clone returns a shallow copy of the object, which can be sent after the lock is released.
+func (o object) clone() object {
+	c := make(object, len(o))
+	for k, v := range o {
+		c[k] = v
+	}
+	return c
+}
+
+// merge sets the given keys of src in the object. If no keys are given, all keys except the id are set.
+func (o object) merge(src object, keys ...string) {
+	if len(keys) == 0 {
+		for k, v := range src {
+			if k != "id" {
+				o[k] = v
+			}
+		}
+		return
+	}
+	for _, k := range keys {
+		if v, ok := src[k]; ok {
+			o[k] = v
+		}
+	}
+}
+
+// id returns the snowflake.ID stored under the key or 0.
+func (o object) id(key string) snowflake.ID {
+	s, _ := o[key].(string)
+	id, _ := snowflake.Parse(s)
+	return id
+}
+
+// sortedIDs returns the keys of the map in ascending order.
+func sortedIDs(objects map[snowflake.ID]object) []snowflake.ID {
+	ids := make([]snowflake.ID, 0, len(objects))
+	for id := range objects {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// newID returns a new unique snowflake.ID for the current time. The lock must be held.
+func (s *serverImpl) newID() snowflake.ID {
+	id := snowflake.New(time.Now())
+	if id <= s.lastID {
+		id = s.lastID + 1
+	}
+	s.lastID = id
+	return id
+}
+
+func (s *serverImpl) AddUser(user discord.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[user.ID] = toObject(user)
+}
+
+func (s *serverImpl) AddGuild(guild discord.Guild) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := toObject(guild)
+	if _, ok := o["roles"]; !ok {
+		o["roles"] = []any{everyoneRole(guild.ID)}
+	}
+	s.guilds[guild.ID] = o
+	if _, ok := s.members[guild.ID]; !ok {
+		s.members[guild.ID] = map[snowflake.ID]object{}
+	}
+}
+
+func (s *serverImpl) AddChannel(channel discord.Channel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.channels[channel.ID()] = toObject(channel)
+	if _, ok := s.messages[channel.ID()]; !ok {
+		s.messages[channel.ID()] = map[snowflake.ID]object{}
+	}
+}
+
+func (s *serverImpl) AddMember(guildID snowflake.ID, member discord.Member) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[member.User.ID] = toObject(member.User)
+	members, ok := s.members[guildID]
+	if !ok {
+		members = map[snowflake.ID]object{}
+		s.members[guildID] = members
+	}
+	o := toObject(member)
+	delete(o, "guild_id")
+	members[member.User.ID] = o
+}
+
+func (s *serverImpl) AddMessage(message discord.Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	messages, ok := s.messages[message.ChannelID]
+	if !ok {
+		messages = map[snowflake.ID]object{}
+		s.messages[message.ChannelID] = messages
+	}
+	messages[message.ID] = toObject(message)
+}
+
+// everyoneRole returns the @everyone role of a guild, which has the same ID as the guild.
+func everyoneRole(guildID snowflake.ID) object {
+	return object{
+		"id":          guildID.String(),
+		"name":        "@everyone",
+		"color":       0,
+		"hoist":       false,
+		"position":    0,
+		"permissions": "0",
+		"managed":     false,
+		"mentionable": false,
+	}
+}
@@ -0,0 +1,136 @@
+package sharding
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/disgoorg/log"
+)
+
+var (
+	// ErrNodeAlreadyJoined is returned by Coordinator.Join when a node with the same ID is already part of the cluster.
+	ErrNodeAlreadyJoined = errors.New("node already joined the cluster")
+
+	// ErrNodeLeft is returned by ClusterNode.WaitBucket when the node left the cluster.
+	ErrNodeLeft = errors.New("node left the cluster")
+
+	// ErrCoordinatorClosed is returned by Coordinator.Join when the Coordinator is closed.
+	ErrCoordinatorClosed = errors.New("coordinator closed")
+)
+
+// Coordinator coordinates multiple ShardManager(s), usually running in different processes, as one cluster.
+// It assigns each node a range of shards, serializes identifies per max_concurrency bucket across all nodes
+// and reassigns the shards of a node once it leaves or dies.
+//
+// Shards are only reassigned once the cluster settled, see WithCoordinatorExpectedNodes & WithCoordinatorSettleDelay,
+// and as few shards as possible are moved. A shard is only assigned to its new node after its old node confirmed
+// via ClusterNode.Applied that it closed the shard, so no shard runs on two nodes at once.
+type Coordinator interface {
+	// Logger returns the logger used by the Coordinator.
+	Logger() log.Logger
+
+	// Join adds a node with the given ID to the cluster. The shards are reassigned to all nodes once the cluster settled.
+	Join(ctx context.Context, nodeID string) (ClusterNode, error)
+
+	// Close closes the Coordinator and removes all nodes.
+	Close(ctx context.Context)
+}
+
+// ClusterNode is a node which joined a cluster via Coordinator.Join.
+// It is the RateLimiter of its ShardManager, so identifies are serialized across the whole cluster.
+// Closing it leaves the cluster.
+type ClusterNode interface {
+	RateLimiter
+
+	// ID returns the ID of the node.
+	ID() string
+
+	// Assignments receives the ShardAssignment of the node whenever it changes. Only the latest ShardAssignment is kept.
+	// The channel is closed once the node left the cluster.
+	Assignments() <-chan ShardAssignment
+
+	// Applied confirms that the node closed all shards which are not part of the given ShardAssignment received from Assignments.
+	// The node must not open the shards of the ShardAssignment before calling Applied,
+	// as shards taken from one node are only assigned to another node once the first one confirmed it closed them.
+	Applied(assignment ShardAssignment)
+}
+
+// ShardAssignment are the shards a ClusterNode should run.
+type ShardAssignment struct {
+	ShardCount int   `json:"shard_count"`
+	ShardIDs   []int `json:"shard_ids"`
+}
+
+// assignShards distributes the shards evenly over the nodes while moving as few shards as possible.
+// Nodes keep the shards of their previous assignment up to their fair share, the remaining shards are handed out in the order of the node IDs.
+// Without a previous assignment each node gets a range of consecutive shards.
+func assignShards(previous map[string][]int, nodeIDs []string, shardCount int) map[string][]int {
+	assignments := make(map[string][]int, len(nodeIDs))
+	if len(nodeIDs) == 0 {
+		return assignments
+	}
+
+	sorted := make([]string, len(nodeIDs))
+	copy(sorted, nodeIDs)
+	sort.Strings(sorted)
+
+	// the nodes which run the most shards get the bigger shares, so they have to give up less
+	byShards := make([]string, len(sorted))
+	copy(byShards, sorted)
+	sort.SliceStable(byShards, func(i, j int) bool {
+		return len(previous[byShards[i]]) > len(previous[byShards[j]])
+	})
+	shares := make(map[string]int, len(sorted))
+	for i, nodeID := range byShards {
+		shares[nodeID] = shardCount / len(sorted)
+		if i < shardCount%len(sorted) {
+			shares[nodeID]++
+		}
+	}
+
+	assigned := make(map[int]struct{}, shardCount)
+	for _, nodeID := range sorted {
+		shardIDs := make([]int, 0, shares[nodeID])
+		for _, shardID := range previous[nodeID] {
+			if len(shardIDs) == shares[nodeID] {
+				break
+			}
+			if _, ok := assigned[shardID]; ok || shardID < 0 || shardID >= shardCount {
+				continue
+			}
+			assigned[shardID] = struct{}{}
+			shardIDs = append(shardIDs, shardID)
+		}
+		assignments[nodeID] = shardIDs
+	}
+
+	shardID := 0
+	for _, nodeID := range sorted {
+		for len(assignments[nodeID]) < shares[nodeID] {
+			if _, ok := assigned[shardID]; !ok {
+				assignments[nodeID] = append(assignments[nodeID], shardID)
+			}
+			shardID++
+		}
+		sort.Ints(assignments[nodeID])
+	}
+	return assignments
+}
+
+// sameShards reports whether both lists contain the same shard IDs.
+func sameShards(a []int, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	shardIDs := make(map[int]struct{}, len(a))
+	for _, shardID := range a {
+		shardIDs[shardID] = struct{}{}
+	}
+	for _, shardID := range b {
+		if _, ok := shardIDs[shardID]; !ok {
+			return false
+		}
+	}
+	return true
+}
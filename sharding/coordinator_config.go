@@ -0,0 +1,77 @@
+package sharding
+
+import (
+	"net"
+	"time"
+
+	"github.com/disgoorg/log"
+)
+
+// DefaultCoordinatorConfig returns a CoordinatorConfig with sensible defaults.
+func DefaultCoordinatorConfig() *CoordinatorConfig {
+	return &CoordinatorConfig{
+		Logger:         log.Default(),
+		MaxConcurrency: 1,
+		SettleDelay:    5 * time.Second,
+		Dialer:         &net.Dialer{Timeout: 10 * time.Second, KeepAlive: 15 * time.Second},
+	}
+}
+
+// CoordinatorConfig lets you configure your Coordinator instance.
+type CoordinatorConfig struct {
+	Logger         log.Logger
+	MaxConcurrency int
+	ExpectedNodes  int
+	SettleDelay    time.Duration
+	Dialer         *net.Dialer
+}
+
+// CoordinatorConfigOpt is a type alias for a function that takes a CoordinatorConfig and is used to configure your Coordinator.
+type CoordinatorConfigOpt func(config *CoordinatorConfig)
+
+// Apply applies the given CoordinatorConfigOpt(s) to the CoordinatorConfig
+func (c *CoordinatorConfig) Apply(opts []CoordinatorConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithCoordinatorLogger sets the logger of the Coordinator.
+func WithCoordinatorLogger(logger log.Logger) CoordinatorConfigOpt {
+	return func(config *CoordinatorConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithCoordinatorMaxConcurrency sets the max_concurrency of the bot, which determines the identify buckets shared by all nodes.
+// This is only used by the Coordinator which holds the state of the cluster.
+func WithCoordinatorMaxConcurrency(maxConcurrency int) CoordinatorConfigOpt {
+	return func(config *CoordinatorConfig) {
+		config.MaxConcurrency = maxConcurrency
+	}
+}
+
+// WithCoordinatorExpectedNodes sets the number of nodes the cluster is expected to have.
+// The first shards are assigned as soon as this many nodes joined, instead of after the settle delay. 0 disables waiting for nodes, which is the default.
+// This is only used by the Coordinator which holds the state of the cluster.
+func WithCoordinatorExpectedNodes(expectedNodes int) CoordinatorConfigOpt {
+	return func(config *CoordinatorConfig) {
+		config.ExpectedNodes = expectedNodes
+	}
+}
+
+// WithCoordinatorSettleDelay sets how long no node has to join or leave the cluster before the shards are reassigned.
+// This keeps nodes which join one after another, like during a rolling restart, from moving the shards around for each node. 0 reassigns immediately.
+// This is only used by the Coordinator which holds the state of the cluster.
+func WithCoordinatorSettleDelay(settleDelay time.Duration) CoordinatorConfigOpt {
+	return func(config *CoordinatorConfig) {
+		config.SettleDelay = settleDelay
+	}
+}
+
+// WithCoordinatorDialer sets the net.Dialer the TCP Coordinator uses to connect to the TCPCoordinatorServer.
+func WithCoordinatorDialer(dialer *net.Dialer) CoordinatorConfigOpt {
+	return func(config *CoordinatorConfig) {
+		config.Dialer = dialer
+	}
+}
@@ -0,0 +1,281 @@
+package sharding
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/log"
+)
+
+var (
+	_ Coordinator = (*memoryCoordinator)(nil)
+	_ ClusterNode = (*memoryNode)(nil)
+)
+
+// NewMemoryCoordinator creates a new Coordinator which holds the state of the cluster in memory.
+// All nodes have to run in the same process. Use NewTCPCoordinatorServer to let nodes of other processes join it.
+// A node leaves the cluster when it is closed.
+func NewMemoryCoordinator(shardCount int, opts ...CoordinatorConfigOpt) Coordinator {
+	config := DefaultCoordinatorConfig()
+	config.Apply(opts)
+
+	return &memoryCoordinator{
+		config:     *config,
+		shardCount: shardCount,
+		rateLimiter: NewRateLimiter(
+			WithRateLimiterLogger(config.Logger),
+			WithMaxConcurrency(config.MaxConcurrency),
+		),
+		nodes: map[string]*memoryNode{},
+	}
+}
+
+type memoryCoordinator struct {
+	config      CoordinatorConfig
+	shardCount  int
+	rateLimiter RateLimiter
+
+	mu    sync.Mutex
+	nodes map[string]*memoryNode
+	// targets are the shards each node should run once the other nodes released them
+	targets     map[string][]int
+	assigned    bool
+	settleTimer *time.Timer
+	closed      bool
+}
+
+func (c *memoryCoordinator) Logger() log.Logger {
+	return c.config.Logger
+}
+
+func (c *memoryCoordinator) Join(_ context.Context, nodeID string) (ClusterNode, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrCoordinatorClosed
+	}
+	if _, ok := c.nodes[nodeID]; ok {
+		return nil, ErrNodeAlreadyJoined
+	}
+
+	node := &memoryNode{
+		coordinator: c,
+		id:          nodeID,
+		assignments: make(chan ShardAssignment, 1),
+		claimed:     map[int]struct{}{},
+		buckets:     map[int]int{},
+	}
+	c.nodes[nodeID] = node
+	c.Logger().Debugf("node %s joined the cluster", nodeID)
+	c.nodesChanged()
+	return node, nil
+}
+
+func (c *memoryCoordinator) Close(_ context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	if c.settleTimer != nil {
+		c.settleTimer.Stop()
+	}
+	for _, node := range c.nodes {
+		c.remove(node)
+	}
+}
+
+// leave removes the node from the cluster and reassigns its shards.
+func (c *memoryCoordinator) leave(node *memoryNode) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.nodes[node.id] != node {
+		return
+	}
+	c.remove(node)
+	c.Logger().Debugf("node %s left the cluster", node.id)
+	c.nodesChanged()
+}
+
+// applied releases the shards the node closed and assigns them to their new nodes.
+func (c *memoryCoordinator) applied(node *memoryNode, assignment ShardAssignment) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.nodes[node.id] != node {
+		return
+	}
+	// the node could still open the shards of a newer ShardAssignment, so only the latest one releases shards
+	if !sameShards(assignment.ShardIDs, node.sent) {
+		return
+	}
+	node.claimed = make(map[int]struct{}, len(assignment.ShardIDs))
+	for _, shardID := range assignment.ShardIDs {
+		node.claimed[shardID] = struct{}{}
+	}
+	c.dispatch()
+}
+
+// nodesChanged reassigns the shards once the cluster settled. c.mu must be held.
+func (c *memoryCoordinator) nodesChanged() {
+	// the shards of a node which left are free now
+	defer c.dispatch()
+
+	if !c.assigned && c.config.ExpectedNodes > 0 {
+		if len(c.nodes) >= c.config.ExpectedNodes {
+			c.reassign()
+		}
+		return
+	}
+	if c.config.SettleDelay <= 0 {
+		c.reassign()
+		return
+	}
+	if c.settleTimer != nil {
+		c.settleTimer.Stop()
+	}
+	c.settleTimer = time.AfterFunc(c.config.SettleDelay, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.closed {
+			return
+		}
+		c.reassign()
+		c.dispatch()
+	})
+}
+
+// remove removes the node and releases the identify buckets it holds. c.mu must be held.
+func (c *memoryCoordinator) remove(node *memoryNode) {
+	delete(c.nodes, node.id)
+
+	node.mu.Lock()
+	defer node.mu.Unlock()
+	node.left = true
+	for shardID, count := range node.buckets {
+		for ; count > 0; count-- {
+			c.rateLimiter.UnlockBucket(shardID)
+		}
+	}
+	node.buckets = nil
+	close(node.assignments)
+}
+
+// reassign distributes the shards over the current nodes. c.mu must be held.
+func (c *memoryCoordinator) reassign() {
+	nodeIDs := make([]string, 0, len(c.nodes))
+	for nodeID := range c.nodes {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	c.targets = assignShards(c.targets, nodeIDs, c.shardCount)
+	c.assigned = true
+	c.Logger().Debugf("reassigned %d shards to %d nodes", c.shardCount, len(nodeIDs))
+}
+
+// dispatch sends each node the shards of its target which no other node claims anymore. c.mu must be held.
+func (c *memoryCoordinator) dispatch() {
+	for nodeID, node := range c.nodes {
+		target, ok := c.targets[nodeID]
+		if !ok {
+			continue
+		}
+		shardIDs := make([]int, 0, len(target))
+		for _, shardID := range target {
+			if !c.claimedByOther(node, shardID) {
+				shardIDs = append(shardIDs, shardID)
+			}
+		}
+		if node.sent != nil && sameShards(shardIDs, node.sent) {
+			continue
+		}
+		node.sent = shardIDs
+		for _, shardID := range shardIDs {
+			node.claimed[shardID] = struct{}{}
+		}
+		node.assign(ShardAssignment{
+			ShardCount: c.shardCount,
+			ShardIDs:   shardIDs,
+		})
+	}
+}
+
+// claimedByOther reports whether a node other than the given one could still run the shard. c.mu must be held.
+func (c *memoryCoordinator) claimedByOther(node *memoryNode, shardID int) bool {
+	for _, other := range c.nodes {
+		if other == node {
+			continue
+		}
+		if _, ok := other.claimed[shardID]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+type memoryNode struct {
+	coordinator *memoryCoordinator
+	id          string
+	assignments chan ShardAssignment
+
+	// sent is the latest ShardAssignment sent to the node & claimed are the shards the node could run.
+	// Both are guarded by coordinator.mu
+	sent    []int
+	claimed map[int]struct{}
+
+	mu sync.Mutex
+	// buckets counts the identify buckets the node holds by shard ID
+	buckets map[int]int
+	left    bool
+}
+
+func (n *memoryNode) Logger() log.Logger {
+	return n.coordinator.Logger()
+}
+
+func (n *memoryNode) ID() string {
+	return n.id
+}
+
+func (n *memoryNode) Assignments() <-chan ShardAssignment {
+	return n.assignments
+}
+
+// assign replaces the pending ShardAssignment of the node. n.coordinator.mu must be held.
+func (n *memoryNode) assign(assignment ShardAssignment) {
+	select {
+	case <-n.assignments:
+	default:
+	}
+	n.assignments <- assignment
+}
+
+func (n *memoryNode) Applied(assignment ShardAssignment) {
+	n.coordinator.applied(n, assignment)
+}
+
+func (n *memoryNode) WaitBucket(ctx context.Context, shardID int) error {
+	if err := n.coordinator.rateLimiter.WaitBucket(ctx, shardID); err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.left {
+		n.coordinator.rateLimiter.UnlockBucket(shardID)
+		return ErrNodeLeft
+	}
+	n.buckets[shardID]++
+	return nil
+}
+
+func (n *memoryNode) UnlockBucket(shardID int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.buckets[shardID] == 0 {
+		return
+	}
+	n.buckets[shardID]--
+	n.coordinator.rateLimiter.UnlockBucket(shardID)
+}
+
+func (n *memoryNode) Close(_ context.Context) {
+	n.coordinator.leave(n)
+}
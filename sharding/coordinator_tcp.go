@@ -0,0 +1,469 @@
+package sharding
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/log"
+)
+
+type coordinatorOp string
+
+const (
+	coordinatorOpJoin           coordinatorOp = "join"
+	coordinatorOpJoined         coordinatorOp = "joined"
+	coordinatorOpAssignment     coordinatorOp = "assignment"
+	coordinatorOpApplied        coordinatorOp = "applied"
+	coordinatorOpWaitBucket     coordinatorOp = "wait_bucket"
+	coordinatorOpBucketAcquired coordinatorOp = "bucket_acquired"
+	coordinatorOpUnlockBucket   coordinatorOp = "unlock_bucket"
+	coordinatorOpCancel         coordinatorOp = "cancel"
+	coordinatorOpError          coordinatorOp = "error"
+)
+
+// coordinatorMessage is a message of the TCP Coordinator protocol. Messages are sent as lines of JSON.
+type coordinatorMessage struct {
+	Op         coordinatorOp    `json:"op"`
+	Nonce      int              `json:"nonce,omitempty"`
+	NodeID     string           `json:"node_id,omitempty"`
+	ShardID    int              `json:"shard_id,omitempty"`
+	Assignment *ShardAssignment `json:"assignment,omitempty"`
+	Error      string           `json:"error,omitempty"`
+}
+
+// coordinatorError turns the error of a coordinatorMessage back into the known error values.
+func coordinatorError(message string) error {
+	for _, err := range []error{ErrNodeAlreadyJoined, ErrNodeLeft, ErrCoordinatorClosed, context.Canceled, context.DeadlineExceeded} {
+		if err.Error() == message {
+			return err
+		}
+	}
+	return errors.New(message)
+}
+
+// TCPCoordinatorServer serves a Coordinator over TCP, so nodes of other processes can join it via NewTCPCoordinator.
+// A node leaves the cluster when its connection is closed, so the shards of dead processes are reassigned.
+type TCPCoordinatorServer interface {
+	// Logger returns the logger used by the TCPCoordinatorServer.
+	Logger() log.Logger
+
+	// Addr returns the address the TCPCoordinatorServer listens on.
+	Addr() net.Addr
+
+	// Close closes the listener and all connections. The Coordinator is not closed.
+	Close(ctx context.Context)
+}
+
+var _ TCPCoordinatorServer = (*tcpCoordinatorServer)(nil)
+
+// NewTCPCoordinatorServer creates a new TCPCoordinatorServer which accepts nodes on the given net.Listener and lets them join the given Coordinator.
+func NewTCPCoordinatorServer(listener net.Listener, coordinator Coordinator, opts ...CoordinatorConfigOpt) TCPCoordinatorServer {
+	config := DefaultCoordinatorConfig()
+	config.Apply(opts)
+
+	s := &tcpCoordinatorServer{
+		config:      *config,
+		listener:    listener,
+		coordinator: coordinator,
+		conns:       map[net.Conn]struct{}{},
+	}
+	go s.accept()
+	return s
+}
+
+type tcpCoordinatorServer struct {
+	config      CoordinatorConfig
+	listener    net.Listener
+	coordinator Coordinator
+
+	mu     sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+}
+
+func (s *tcpCoordinatorServer) Logger() log.Logger {
+	return s.config.Logger
+}
+
+func (s *tcpCoordinatorServer) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+func (s *tcpCoordinatorServer) Close(_ context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	_ = s.listener.Close()
+	for conn := range s.conns {
+		_ = conn.Close()
+		delete(s.conns, conn)
+	}
+}
+
+func (s *tcpCoordinatorServer) accept() {
+	defer s.Logger().Debug("exiting coordinator accept goroutine...")
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			s.Logger().Error("failed to accept coordinator node. error: ", err)
+			continue
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			_ = conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+		go s.serve(conn)
+	}
+}
+
+func (s *tcpCoordinatorServer) serve(conn net.Conn) {
+	defer func() {
+		_ = conn.Close()
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+	}()
+
+	var writeMu sync.Mutex
+	encoder := json.NewEncoder(conn)
+	write := func(message coordinatorMessage) {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		if err := encoder.Encode(message); err != nil {
+			s.Logger().Debugf("failed to write to coordinator node %s. error: %s", conn.RemoteAddr(), err)
+		}
+	}
+	decoder := json.NewDecoder(conn)
+
+	var message coordinatorMessage
+	if err := decoder.Decode(&message); err != nil || message.Op != coordinatorOpJoin {
+		s.Logger().Debugf("coordinator node %s did not join", conn.RemoteAddr())
+		return
+	}
+	node, err := s.coordinator.Join(context.TODO(), message.NodeID)
+	if err != nil {
+		write(coordinatorMessage{Op: coordinatorOpError, Error: err.Error()})
+		return
+	}
+	defer node.Close(context.TODO())
+	write(coordinatorMessage{Op: coordinatorOpJoined})
+
+	go func() {
+		for assignment := range node.Assignments() {
+			assignment := assignment
+			write(coordinatorMessage{Op: coordinatorOpAssignment, Assignment: &assignment})
+		}
+		// the node left the cluster, for example because the Coordinator was closed
+		_ = conn.Close()
+	}()
+
+	var (
+		waitsMu sync.Mutex
+		waits   = map[int]context.CancelFunc{}
+	)
+	defer func() {
+		waitsMu.Lock()
+		defer waitsMu.Unlock()
+		for _, cancel := range waits {
+			cancel()
+		}
+	}()
+
+	for {
+		message = coordinatorMessage{}
+		if err = decoder.Decode(&message); err != nil {
+			s.Logger().Debugf("coordinator node %s disconnected. error: %s", node.ID(), err)
+			return
+		}
+
+		switch message.Op {
+		case coordinatorOpWaitBucket:
+			ctx, cancel := context.WithCancel(context.Background())
+			waitsMu.Lock()
+			waits[message.Nonce] = cancel
+			waitsMu.Unlock()
+
+			go func(message coordinatorMessage) {
+				err := node.WaitBucket(ctx, message.ShardID)
+				waitsMu.Lock()
+				delete(waits, message.Nonce)
+				waitsMu.Unlock()
+				cancel()
+
+				if err != nil {
+					write(coordinatorMessage{Op: coordinatorOpError, Nonce: message.Nonce, ShardID: message.ShardID, Error: err.Error()})
+					return
+				}
+				write(coordinatorMessage{Op: coordinatorOpBucketAcquired, Nonce: message.Nonce, ShardID: message.ShardID})
+			}(message)
+
+		case coordinatorOpUnlockBucket:
+			node.UnlockBucket(message.ShardID)
+
+		case coordinatorOpApplied:
+			if message.Assignment != nil {
+				node.Applied(*message.Assignment)
+			}
+
+		case coordinatorOpCancel:
+			waitsMu.Lock()
+			if cancel, ok := waits[message.Nonce]; ok {
+				cancel()
+			}
+			waitsMu.Unlock()
+
+		default:
+			s.Logger().Debugf("unknown coordinator op from node %s: %s", node.ID(), message.Op)
+		}
+	}
+}
+
+var (
+	_ Coordinator = (*tcpCoordinator)(nil)
+	_ ClusterNode = (*tcpNode)(nil)
+)
+
+// NewTCPCoordinator creates a new Coordinator which joins the cluster of the TCPCoordinatorServer at the given address.
+// Each node keeps its own connection, the node leaves the cluster once it is closed or the connection is lost.
+func NewTCPCoordinator(address string, opts ...CoordinatorConfigOpt) Coordinator {
+	config := DefaultCoordinatorConfig()
+	config.Apply(opts)
+
+	return &tcpCoordinator{
+		config:  *config,
+		address: address,
+		nodes:   map[*tcpNode]struct{}{},
+	}
+}
+
+type tcpCoordinator struct {
+	config  CoordinatorConfig
+	address string
+
+	mu    sync.Mutex
+	nodes map[*tcpNode]struct{}
+}
+
+func (c *tcpCoordinator) Logger() log.Logger {
+	return c.config.Logger
+}
+
+func (c *tcpCoordinator) Join(ctx context.Context, nodeID string) (ClusterNode, error) {
+	conn, err := c.config.Dialer.DialContext(ctx, "tcp", c.address)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	encoder := json.NewEncoder(conn)
+	decoder := json.NewDecoder(conn)
+	if err = encoder.Encode(coordinatorMessage{Op: coordinatorOpJoin, NodeID: nodeID}); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	var message coordinatorMessage
+	if err = decoder.Decode(&message); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if message.Op != coordinatorOpJoined {
+		_ = conn.Close()
+		return nil, coordinatorError(message.Error)
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	node := &tcpNode{
+		coordinator: c,
+		id:          nodeID,
+		conn:        conn,
+		encoder:     encoder,
+		assignments: make(chan ShardAssignment, 1),
+		waits:       map[int]chan error{},
+	}
+	c.mu.Lock()
+	c.nodes[node] = struct{}{}
+	c.mu.Unlock()
+
+	go node.read(decoder)
+	return node, nil
+}
+
+func (c *tcpCoordinator) Close(ctx context.Context) {
+	c.mu.Lock()
+	nodes := make([]*tcpNode, 0, len(c.nodes))
+	for node := range c.nodes {
+		nodes = append(nodes, node)
+	}
+	c.mu.Unlock()
+
+	for _, node := range nodes {
+		node.Close(ctx)
+	}
+}
+
+type tcpNode struct {
+	coordinator *tcpCoordinator
+	id          string
+	conn        net.Conn
+	assignments chan ShardAssignment
+
+	writeMu sync.Mutex
+	encoder interface{ Encode(v any) error }
+
+	mu    sync.Mutex
+	nonce int
+	waits map[int]chan error
+	left  bool
+}
+
+func (n *tcpNode) Logger() log.Logger {
+	return n.coordinator.Logger()
+}
+
+func (n *tcpNode) ID() string {
+	return n.id
+}
+
+func (n *tcpNode) Assignments() <-chan ShardAssignment {
+	return n.assignments
+}
+
+func (n *tcpNode) write(message coordinatorMessage) error {
+	n.writeMu.Lock()
+	defer n.writeMu.Unlock()
+	return n.encoder.Encode(message)
+}
+
+func (n *tcpNode) read(decoder interface{ Decode(v any) error }) {
+	defer n.Logger().Debugf("exiting coordinator node %s read goroutine...", n.id)
+	defer n.leave()
+
+	for {
+		var message coordinatorMessage
+		if err := decoder.Decode(&message); err != nil {
+			n.mu.Lock()
+			left := n.left
+			n.mu.Unlock()
+			if !left {
+				n.Logger().Errorf("lost connection to coordinator, node %s left the cluster. error: %s", n.id, err)
+			}
+			return
+		}
+
+		switch message.Op {
+		case coordinatorOpAssignment:
+			if message.Assignment == nil {
+				continue
+			}
+			select {
+			case <-n.assignments:
+			default:
+			}
+			n.assignments <- *message.Assignment
+
+		case coordinatorOpBucketAcquired, coordinatorOpError:
+			var err error
+			if message.Op == coordinatorOpError {
+				err = coordinatorError(message.Error)
+			}
+			n.mu.Lock()
+			wait, ok := n.waits[message.Nonce]
+			delete(n.waits, message.Nonce)
+			n.mu.Unlock()
+			if ok {
+				wait <- err
+			} else if err == nil {
+				// WaitBucket gave up already
+				n.UnlockBucket(message.ShardID)
+			}
+		}
+	}
+}
+
+// leave fails all pending WaitBucket calls and closes the Assignments channel.
+func (n *tcpNode) leave() {
+	n.mu.Lock()
+	n.left = true
+	for nonce, wait := range n.waits {
+		wait <- ErrNodeLeft
+		delete(n.waits, nonce)
+	}
+	n.mu.Unlock()
+
+	_ = n.conn.Close()
+	close(n.assignments)
+
+	n.coordinator.mu.Lock()
+	delete(n.coordinator.nodes, n)
+	n.coordinator.mu.Unlock()
+}
+
+func (n *tcpNode) Applied(assignment ShardAssignment) {
+	if err := n.write(coordinatorMessage{Op: coordinatorOpApplied, Assignment: &assignment}); err != nil {
+		n.Logger().Debugf("failed to confirm assignment of node %s. error: %s", n.id, err)
+	}
+}
+
+func (n *tcpNode) WaitBucket(ctx context.Context, shardID int) error {
+	n.mu.Lock()
+	if n.left {
+		n.mu.Unlock()
+		return ErrNodeLeft
+	}
+	n.nonce++
+	nonce := n.nonce
+	wait := make(chan error, 1)
+	n.waits[nonce] = wait
+	n.mu.Unlock()
+
+	if err := n.write(coordinatorMessage{Op: coordinatorOpWaitBucket, Nonce: nonce, ShardID: shardID}); err != nil {
+		n.mu.Lock()
+		delete(n.waits, nonce)
+		n.mu.Unlock()
+		return err
+	}
+
+	select {
+	case err := <-wait:
+		return err
+	case <-ctx.Done():
+		n.mu.Lock()
+		_, pending := n.waits[nonce]
+		delete(n.waits, nonce)
+		n.mu.Unlock()
+		if pending {
+			_ = n.write(coordinatorMessage{Op: coordinatorOpCancel, Nonce: nonce})
+		} else if err := <-wait; err == nil {
+			// the bucket was acquired right before the context was done
+			n.UnlockBucket(shardID)
+		}
+		return ctx.Err()
+	}
+}
+
+func (n *tcpNode) UnlockBucket(shardID int) {
+	if err := n.write(coordinatorMessage{Op: coordinatorOpUnlockBucket, ShardID: shardID}); err != nil {
+		n.Logger().Debugf("failed to unlock bucket of shard %d. error: %s", shardID, err)
+	}
+}
+
+func (n *tcpNode) Close(_ context.Context) {
+	n.mu.Lock()
+	n.left = true
+	n.mu.Unlock()
+	_ = n.conn.Close()
+}
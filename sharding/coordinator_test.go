@@ -0,0 +1,145 @@
+package sharding
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func nextAssignment(t *testing.T, node ClusterNode) ShardAssignment {
+	select {
+	case assignment, ok := <-node.Assignments():
+		require.True(t, ok, "node left the cluster")
+		return assignment
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for assignment")
+		return ShardAssignment{}
+	}
+}
+
+func TestAssignShards(t *testing.T) {
+	assignments := assignShards(nil, []string{"c", "a", "b"}, 8)
+	assert.Equal(t, map[string][]int{
+		"a": {0, 1, 2},
+		"b": {3, 4, 5},
+		"c": {6, 7},
+	}, assignments)
+
+	// a new node only takes shards from the nodes with more than their share
+	assert.Equal(t, map[string][]int{
+		"a": {0, 1},
+		"b": {3, 4},
+		"c": {6, 7},
+		"d": {2, 5},
+	}, assignShards(assignments, []string{"a", "b", "c", "d"}, 8))
+
+	// the shards of a node which left are spread over the remaining nodes
+	assert.Equal(t, map[string][]int{
+		"a": {0, 1, 2, 6},
+		"b": {3, 4, 5, 7},
+	}, assignShards(assignments, []string{"a", "b"}, 8))
+}
+
+func TestMemoryCoordinator(t *testing.T) {
+	coordinator := NewMemoryCoordinator(4, WithCoordinatorSettleDelay(0))
+	defer coordinator.Close(context.Background())
+
+	a, err := coordinator.Join(context.Background(), "a")
+	require.NoError(t, err)
+	assignment := nextAssignment(t, a)
+	assert.Equal(t, ShardAssignment{ShardCount: 4, ShardIDs: []int{0, 1, 2, 3}}, assignment)
+	a.Applied(assignment)
+
+	_, err = coordinator.Join(context.Background(), "a")
+	assert.ErrorIs(t, err, ErrNodeAlreadyJoined)
+
+	// b only gets its shards once a closed them
+	b, err := coordinator.Join(context.Background(), "b")
+	require.NoError(t, err)
+	assert.Empty(t, nextAssignment(t, b).ShardIDs)
+	assignment = nextAssignment(t, a)
+	assert.Equal(t, []int{0, 1}, assignment.ShardIDs)
+	a.Applied(assignment)
+	assert.Equal(t, []int{2, 3}, nextAssignment(t, b).ShardIDs)
+
+	b.Close(context.Background())
+	assert.Equal(t, []int{0, 1, 2, 3}, nextAssignment(t, a).ShardIDs)
+	_, ok := <-b.Assignments()
+	assert.False(t, ok)
+}
+
+func TestMemoryCoordinatorSettles(t *testing.T) {
+	coordinator := NewMemoryCoordinator(4, WithCoordinatorExpectedNodes(2), WithCoordinatorSettleDelay(100*time.Millisecond))
+	defer coordinator.Close(context.Background())
+
+	// nothing is assigned until the expected nodes joined
+	a, err := coordinator.Join(context.Background(), "a")
+	require.NoError(t, err)
+	select {
+	case <-a.Assignments():
+		t.Fatal("assigned shards before the expected nodes joined")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	b, err := coordinator.Join(context.Background(), "b")
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, nextAssignment(t, a).ShardIDs)
+	assert.Equal(t, []int{2, 3}, nextAssignment(t, b).ShardIDs)
+
+	// nodes which leave & join within the settle delay don't move any shards
+	b.Close(context.Background())
+	b, err = coordinator.Join(context.Background(), "b")
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, nextAssignment(t, b).ShardIDs)
+	select {
+	case assignment := <-a.Assignments():
+		t.Fatalf("reassigned shards of a to %v", assignment.ShardIDs)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestTCPCoordinator(t *testing.T) {
+	logger := log.New(log.LstdFlags)
+	logger.SetLevel(log.LevelPanic)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := NewTCPCoordinatorServer(listener, NewMemoryCoordinator(4, WithCoordinatorLogger(logger), WithCoordinatorSettleDelay(0)), WithCoordinatorLogger(logger))
+	defer server.Close(context.Background())
+
+	coordinator := NewTCPCoordinator(server.Addr().String(), WithCoordinatorLogger(logger))
+	a, err := coordinator.Join(context.Background(), "a")
+	require.NoError(t, err)
+	assignment := nextAssignment(t, a)
+	assert.Equal(t, []int{0, 1, 2, 3}, assignment.ShardIDs)
+	a.Applied(assignment)
+
+	_, err = coordinator.Join(context.Background(), "a")
+	assert.ErrorIs(t, err, ErrNodeAlreadyJoined)
+
+	b, err := coordinator.Join(context.Background(), "b")
+	require.NoError(t, err)
+	assert.Empty(t, nextAssignment(t, b).ShardIDs)
+	assignment = nextAssignment(t, a)
+	assert.Equal(t, []int{0, 1}, assignment.ShardIDs)
+	a.Applied(assignment)
+	assert.Equal(t, []int{2, 3}, nextAssignment(t, b).ShardIDs)
+
+	// identifies of the same bucket are serialized across nodes
+	require.NoError(t, a.WaitBucket(context.Background(), 0))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, b.WaitBucket(ctx, 2), context.DeadlineExceeded)
+
+	// a dead node releases its bucket and its shards are reassigned
+	a.Close(context.Background())
+	assert.Equal(t, []int{0, 1, 2, 3}, nextAssignment(t, b).ShardIDs)
+	require.NoError(t, b.WaitBucket(context.Background(), 2))
+	b.UnlockBucket(2)
+	coordinator.Close(context.Background())
+}
@@ -0,0 +1,246 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// maxStatusHistory is the number of StatusChange(s) kept per shard.
+const maxStatusHistory = 16
+
+// HealthChangeHandlerFunc is called when the gateway.Status or health of a shard changes and when a shard is restarted.
+type HealthChangeHandlerFunc func(oldHealth ShardHealth, newHealth ShardHealth)
+
+// StatusChange is a change of the gateway.Status of a shard.
+type StatusChange struct {
+	Status gateway.Status
+	Time   time.Time
+}
+
+// ShardHealth is a snapshot of the health of a shard.
+type ShardHealth struct {
+	ShardID int
+	Status  gateway.Status
+	// StatusHistory holds the last status changes of the shard, the newest last.
+	StatusHistory []StatusChange
+	Latency       time.Duration
+	// LastDispatch is the time the shard received its last dispatch. It is zero if the shard received none yet.
+	LastDispatch time.Time
+	// Reconnects is the number of reconnect tries of the shard.
+	Reconnects int
+	// Restarts is the number of times the ShardManager restarted the shard because it was stuck or silent.
+	Restarts int
+	// Healthy is true if the shard is gateway.StatusReady and did not exceed the configured silence timeout.
+	Healthy bool
+}
+
+// Health is a snapshot of the health of all shards of a ShardManager.
+type Health struct {
+	Shards          map[int]ShardHealth
+	HealthyShards   int
+	UnhealthyShards int
+}
+
+// Healthy returns true if all shards are healthy.
+func (h Health) Healthy() bool {
+	return h.UnhealthyShards == 0
+}
+
+// shardHealth tracks the health of a shard across restarts.
+type shardHealth struct {
+	// lastDispatch is the unix nano time of the last dispatch
+	lastDispatch int64
+	reconnects   int64
+
+	status        gateway.Status
+	since         time.Time
+	statusHistory []StatusChange
+	latency       time.Duration
+	restarts      int
+	restarting    bool
+	healthy       bool
+}
+
+// healthTracker tracks the health of all shards of a ShardManager.
+type healthTracker struct {
+	mu     sync.Mutex
+	shards map[int]*shardHealth
+}
+
+func (t *healthTracker) get(shardID int) *shardHealth {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.shards == nil {
+		t.shards = map[int]*shardHealth{}
+	}
+	health, ok := t.shards[shardID]
+	if !ok {
+		health = &shardHealth{since: time.Now()}
+		t.shards[shardID] = health
+	}
+	return health
+}
+
+func (t *healthTracker) dispatchReceived(shardID int) {
+	atomic.StoreInt64(&t.get(shardID).lastDispatch, time.Now().UnixNano())
+}
+
+func (t *healthTracker) reconnecting(shardID int) {
+	atomic.AddInt64(&t.get(shardID).reconnects, 1)
+}
+
+// snapshot returns the ShardHealth of the given shard. healthTracker.mu must be held.
+func (h *shardHealth) snapshot(shardID int) ShardHealth {
+	var lastDispatch time.Time
+	if nanos := atomic.LoadInt64(&h.lastDispatch); nanos != 0 {
+		lastDispatch = time.Unix(0, nanos)
+	}
+	statusHistory := make([]StatusChange, len(h.statusHistory))
+	copy(statusHistory, h.statusHistory)
+	return ShardHealth{
+		ShardID:       shardID,
+		Status:        h.status,
+		StatusHistory: statusHistory,
+		Latency:       h.latency,
+		LastDispatch:  lastDispatch,
+		Reconnects:    int(atomic.LoadInt64(&h.reconnects)),
+		Restarts:      h.restarts,
+		Healthy:       h.healthy,
+	}
+}
+
+// setStatus records the status if it changed. healthTracker.mu must be held.
+func (h *shardHealth) setStatus(status gateway.Status, now time.Time) {
+	if status == h.status && len(h.statusHistory) > 0 {
+		return
+	}
+	h.status = status
+	h.since = now
+	h.statusHistory = append(h.statusHistory, StatusChange{Status: status, Time: now})
+	if len(h.statusHistory) > maxStatusHistory {
+		h.statusHistory = h.statusHistory[len(h.statusHistory)-maxStatusHistory:]
+	}
+}
+
+func (m *shardManagerImpl) Health() Health {
+	shards := m.Shards()
+
+	m.health.mu.Lock()
+	defer m.health.mu.Unlock()
+	health := Health{
+		Shards: make(map[int]ShardHealth, len(shards)),
+	}
+	for shardID := range shards {
+		state, ok := m.health.shards[shardID]
+		if !ok {
+			state = &shardHealth{}
+		}
+		snapshot := state.snapshot(shardID)
+		health.Shards[shardID] = snapshot
+		if snapshot.Healthy {
+			health.HealthyShards++
+		} else {
+			health.UnhealthyShards++
+		}
+	}
+	return health
+}
+
+func (m *shardManagerImpl) superviseHealth(ctx context.Context) {
+	defer m.Logger().Debug("exiting shard health supervisor goroutine...")
+
+	ticker := time.NewTicker(m.config.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		for _, shard := range m.Shards() {
+			m.checkHealth(shard)
+		}
+	}
+}
+
+// checkHealth updates the health of the shard and restarts it if it is stuck or silent.
+func (m *shardManagerImpl) checkHealth(shard gateway.Gateway) {
+	shardID := shard.ShardID()
+	state := m.health.get(shardID)
+	status := shard.Status()
+	latency := shard.Latency()
+	now := time.Now()
+
+	m.health.mu.Lock()
+	oldHealth := state.snapshot(shardID)
+	state.setStatus(status, now)
+	state.latency = latency
+
+	var restartReason string
+	if status == gateway.StatusReady {
+		state.healthy = true
+		if m.config.ShardSilenceTimeout > 0 {
+			lastActivity := state.since
+			if nanos := atomic.LoadInt64(&state.lastDispatch); nanos != 0 && time.Unix(0, nanos).After(lastActivity) {
+				lastActivity = time.Unix(0, nanos)
+			}
+			if now.Sub(lastActivity) > m.config.ShardSilenceTimeout {
+				state.healthy = false
+				restartReason = "silent"
+			}
+		}
+	} else {
+		state.healthy = false
+		// restarting a reconnecting shard would leave two sessions for the shard once the reconnect succeeds
+		if m.config.ShardStuckTimeout > 0 && status != gateway.StatusReconnecting && now.Sub(state.since) > m.config.ShardStuckTimeout {
+			restartReason = fmt.Sprintf("stuck in status %d", status)
+		}
+	}
+
+	restart := restartReason != "" && !state.restarting
+	if restart {
+		state.restarting = true
+		state.restarts++
+	}
+	newHealth := state.snapshot(shardID)
+	m.health.mu.Unlock()
+
+	if m.config.HealthChangeHandlerFunc != nil && (oldHealth.Status != newHealth.Status || oldHealth.Healthy != newHealth.Healthy || oldHealth.Restarts != newHealth.Restarts) {
+		m.config.HealthChangeHandlerFunc(oldHealth, newHealth)
+	}
+
+	if restart {
+		m.Logger().Warnf("restarting shard %d, reason: %s", shardID, restartReason)
+		go m.restartShard(shard, state)
+	}
+}
+
+// restartShard replaces the shard with a new one unless it was replaced or closed in the meantime.
+func (m *shardManagerImpl) restartShard(shard gateway.Gateway, state *shardHealth) {
+	defer func() {
+		m.health.mu.Lock()
+		defer m.health.mu.Unlock()
+		state.restarting = false
+		// give the new shard the full timeout
+		state.since = time.Now()
+	}()
+
+	shardID := shard.ShardID()
+	m.shardsMu.Lock()
+	if m.shards[shardID] != shard {
+		m.shardsMu.Unlock()
+		return
+	}
+	delete(m.shards, shardID)
+	m.shardsMu.Unlock()
+
+	shard.Close(context.TODO())
+	if err := m.openShard(context.TODO(), shardID, shard.ShardCount()); err != nil {
+		m.Logger().Errorf("failed to restart shard %d: %s", shardID, err)
+	}
+}
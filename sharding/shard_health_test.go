@@ -0,0 +1,101 @@
+package sharding
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/gateway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGateway struct {
+	gateway.Gateway
+	config gateway.Config
+
+	mu     sync.Mutex
+	status gateway.Status
+	opened chan struct{}
+}
+
+func (g *fakeGateway) ShardID() int                 { return g.config.ShardID }
+func (g *fakeGateway) ShardCount() int              { return g.config.ShardCount }
+func (g *fakeGateway) Latency() time.Duration       { return time.Millisecond }
+func (g *fakeGateway) Close(_ context.Context)      {}
+func (g *fakeGateway) Open(_ context.Context) error { close(g.opened); return nil }
+
+func (g *fakeGateway) Status() gateway.Status {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.status
+}
+
+type noopRateLimiter struct {
+	RateLimiter
+}
+
+func (noopRateLimiter) WaitBucket(_ context.Context, _ int) error { return nil }
+func (noopRateLimiter) UnlockBucket(_ int)                        {}
+
+func TestShardHealth(t *testing.T) {
+	var (
+		gateways []*fakeGateway
+		changes  []ShardHealth
+	)
+	m := New("token", nil,
+		WithShardCount(1),
+		WithShardIDs(0),
+		WithRateLimiter(noopRateLimiter{}),
+		WithShardStuckTimeout(time.Millisecond),
+		WithHealthCheckInterval(0),
+		WithHealthChangeHandlerFunc(func(_ ShardHealth, newHealth ShardHealth) {
+			changes = append(changes, newHealth)
+		}),
+		WithGatewayCreateFunc(func(_ string, _ gateway.EventHandlerFunc, _ gateway.CloseHandlerFunc, opts ...gateway.ConfigOpt) gateway.Gateway {
+			g := &fakeGateway{config: *gateway.DefaultConfig(), opened: make(chan struct{})}
+			g.config.Apply(opts)
+			gateways = append(gateways, g)
+			return g
+		}),
+	).(*shardManagerImpl)
+	require.NoError(t, m.OpenShard(context.Background(), 0))
+	shard := gateways[0]
+
+	shard.mu.Lock()
+	shard.status = gateway.StatusReady
+	shard.mu.Unlock()
+	m.checkHealth(shard)
+	assert.True(t, m.Health().Healthy())
+
+	// reconnecting shards are left to their reconnect strategy
+	shard.mu.Lock()
+	shard.status = gateway.StatusReconnecting
+	shard.mu.Unlock()
+	m.checkHealth(shard)
+	time.Sleep(5 * time.Millisecond)
+	m.checkHealth(shard)
+	assert.Same(t, shard, m.Shard(0))
+
+	shard.mu.Lock()
+	shard.status = gateway.StatusWaitingForReady
+	shard.mu.Unlock()
+	m.checkHealth(shard)
+	assert.Equal(t, 1, m.Health().UnhealthyShards)
+
+	time.Sleep(5 * time.Millisecond)
+	m.checkHealth(shard)
+	require.Eventually(t, func() bool {
+		return m.Shard(0) != nil && m.Shard(0) != shard
+	}, time.Second, time.Millisecond)
+
+	health := m.Health().Shards[0]
+	assert.Equal(t, 1, health.Restarts)
+	assert.Equal(t, []gateway.Status{gateway.StatusReady, gateway.StatusReconnecting, gateway.StatusWaitingForReady}, []gateway.Status{health.StatusHistory[0].Status, health.StatusHistory[1].Status, health.StatusHistory[2].Status})
+	require.Len(t, changes, 4)
+	assert.True(t, changes[0].Healthy)
+	assert.False(t, changes[1].Healthy)
+	assert.Equal(t, gateway.StatusWaitingForReady, changes[2].Status)
+	assert.Equal(t, 1, changes[3].Restarts)
+}
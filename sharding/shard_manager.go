@@ -33,6 +33,21 @@ type ShardManager interface {
 
 	// Shards returns a copy of all shards as a map.
 	Shards() map[int]gateway.Gateway
+
+	// Health returns a snapshot of the health of all shards.
+	// The health is updated by the health checks, see WithHealthCheckInterval.
+	Health() Health
+
+	// Reshard opens a new set of shards with the given higher shard count in the background and waits until all of their guilds are ready.
+	// Then it swaps them in and closes the old shards. Events received by both the old and the new shards during the handover are only delivered once.
+	// The GUILD_CREATE events must not be filtered, as they are needed to know when the new shards are ready.
+	// If the context is done before the new shards are ready, they are closed again and the old shards keep running.
+	Reshard(ctx context.Context, shardCount int) error
+
+	// Reconfigure applies the given gateway.ConfigOpt(s) to all shards and to the shards opened later on.
+	// The shards are reconnected with a new session one identify bucket at a time, waiting for each of them to be ready before moving on.
+	// This lets you change the intents, presence or compression without restarting all shards at once.
+	Reconfigure(ctx context.Context, opts ...gateway.ConfigOpt) error
 }
 
 // ShardIDByGuild returns the shard ID for the given guildID and shardCount.
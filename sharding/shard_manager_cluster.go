@@ -0,0 +1,124 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// joinCluster joins the cluster of the Config.Coordinator and takes over its first ShardAssignment. m.shardsMu must be held.
+func (m *shardManagerImpl) joinCluster(ctx context.Context) error {
+	node, err := m.config.Coordinator.Join(ctx, m.config.NodeID)
+	if err != nil {
+		return fmt.Errorf("failed to join cluster as node %s: %w", m.config.NodeID, err)
+	}
+
+	var (
+		assignment ShardAssignment
+		ok         bool
+	)
+	select {
+	case assignment, ok = <-node.Assignments():
+		if !ok {
+			return ErrNodeLeft
+		}
+	case <-ctx.Done():
+		node.Close(context.TODO())
+		return ctx.Err()
+	}
+
+	m.node = node
+	// identifies are serialized across the cluster by the node
+	m.config.RateLimiter = node
+	m.config.ShardCount = assignment.ShardCount
+	m.config.ShardIDs = make(map[int]struct{}, len(assignment.ShardIDs))
+	for _, shardID := range assignment.ShardIDs {
+		m.config.ShardIDs[shardID] = struct{}{}
+	}
+	m.Logger().Debugf("joined cluster as node %s with shards %v of %d", node.ID(), assignment.ShardIDs, assignment.ShardCount)
+	// no shards are running yet, so there is nothing to close before the assigned ones can be opened
+	node.Applied(assignment)
+
+	go m.watchAssignments(node)
+	return nil
+}
+
+func (m *shardManagerImpl) watchAssignments(node ClusterNode) {
+	defer m.Logger().Debugf("exiting cluster node %s goroutine...", node.ID())
+
+	for assignment := range node.Assignments() {
+		m.applyAssignment(node, assignment)
+	}
+
+	// the node left the cluster without the ShardManager being closed, its shards now belong to other nodes
+	m.shardsMu.Lock()
+	shardCount := m.config.ShardCount
+	m.shardsMu.Unlock()
+	m.applyAssignment(node, ShardAssignment{ShardCount: shardCount})
+
+	m.shardsMu.Lock()
+	defer m.shardsMu.Unlock()
+	if m.node == node {
+		m.Logger().Errorf("cluster node %s left the cluster, closed all shards", node.ID())
+		// let Open join the cluster again
+		m.node = nil
+	}
+}
+
+// applyAssignment closes the shards which are no longer assigned to the node and opens the newly assigned ones.
+// The Coordinator only hands the closed shards to other nodes after the node confirmed it closed them, so they never run twice.
+func (m *shardManagerImpl) applyAssignment(node ClusterNode, assignment ShardAssignment) {
+	m.shardsMu.Lock()
+	if m.node != node {
+		m.shardsMu.Unlock()
+		return
+	}
+
+	shardIDs := make(map[int]struct{}, len(assignment.ShardIDs))
+	for _, shardID := range assignment.ShardIDs {
+		shardIDs[shardID] = struct{}{}
+	}
+	var closedShards []gateway.Gateway
+	for shardID, shard := range m.shards {
+		if _, ok := shardIDs[shardID]; !ok || shard.ShardCount() != assignment.ShardCount {
+			closedShards = append(closedShards, shard)
+			delete(m.shards, shardID)
+		}
+	}
+	var openedShardIDs []int
+	for shardID := range shardIDs {
+		if _, ok := m.shards[shardID]; !ok {
+			openedShardIDs = append(openedShardIDs, shardID)
+		}
+	}
+	m.config.ShardCount = assignment.ShardCount
+	m.config.ShardIDs = shardIDs
+	m.shardsMu.Unlock()
+
+	m.Logger().Debugf("cluster node %s was assigned shards %v of %d, closing %d shards and opening %v", node.ID(), assignment.ShardIDs, assignment.ShardCount, len(closedShards), openedShardIDs)
+	var wg sync.WaitGroup
+	for _, shard := range closedShards {
+		shard := shard
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			shard.Close(context.TODO())
+		}()
+	}
+	wg.Wait()
+	node.Applied(assignment)
+
+	for _, shardID := range openedShardIDs {
+		shardID := shardID
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := m.openShard(context.TODO(), shardID, assignment.ShardCount); err != nil {
+				m.Logger().Errorf("failed to open assigned shard %d: %s", shardID, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -1,16 +1,21 @@
 package sharding
 
 import (
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/rest"
 	"github.com/disgoorg/log"
 )
 
 // DefaultConfig returns a Config with sensible defaults.
 func DefaultConfig() *Config {
 	return &Config{
-		Logger:            log.Default(),
-		GatewayCreateFunc: gateway.New,
-		ShardSplitCount:   2,
+		Logger:                log.Default(),
+		GatewayCreateFunc:     gateway.New,
+		ShardSplitCount:       2,
+		ReshardHandoverWindow: 10 * time.Second,
 	}
 }
 
@@ -23,8 +28,19 @@ type Config struct {
 	AutoScaling               bool
 	GatewayCreateFunc         gateway.CreateFunc
 	GatewayConfigOpts         []gateway.ConfigOpt
+	SessionStore              gateway.SessionStore
 	RateLimiter               RateLimiter
 	RateRateLimiterConfigOpts []RateLimiterConfigOpt
+	GatewayBot                *discord.GatewayBot
+	ReshardGatewayRest        rest.Gateway
+	ReshardCheckInterval      time.Duration
+	ReshardHandoverWindow     time.Duration
+	Coordinator               Coordinator
+	NodeID                    string
+	HealthCheckInterval       time.Duration
+	ShardStuckTimeout         time.Duration
+	ShardSilenceTimeout       time.Duration
+	HealthChangeHandlerFunc   HealthChangeHandlerFunc
 }
 
 // ConfigOpt is a type alias for a function that takes a Config and is used to configure your Server.
@@ -35,6 +51,20 @@ func (c *Config) Apply(opts []ConfigOpt) {
 	for _, opt := range opts {
 		opt(c)
 	}
+	if c.GatewayBot != nil {
+		if c.ShardCount == 0 {
+			c.ShardCount = c.GatewayBot.Shards
+		}
+		if len(c.ShardIDs) == 0 {
+			c.ShardIDs = make(map[int]struct{}, c.ShardCount)
+			for shardID := 0; shardID < c.ShardCount; shardID++ {
+				c.ShardIDs[shardID] = struct{}{}
+			}
+		}
+		// prepend so explicitly configured values take precedence
+		c.GatewayConfigOpts = append([]gateway.ConfigOpt{gateway.WithGatewayURL(c.GatewayBot.URL)}, c.GatewayConfigOpts...)
+		c.RateRateLimiterConfigOpts = append([]RateLimiterConfigOpt{WithMaxConcurrency(c.GatewayBot.SessionStartLimit.MaxConcurrency)}, c.RateRateLimiterConfigOpts...)
+	}
 	if c.RateLimiter == nil {
 		c.RateLimiter = NewRateLimiter(c.RateRateLimiterConfigOpts...)
 	}
@@ -95,6 +125,14 @@ func WithGatewayConfigOpts(opts ...gateway.ConfigOpt) ConfigOpt {
 	}
 }
 
+// WithSessionStore sets the gateway.SessionStore all shards use to persist their sessions.
+// Shards resume their stored session when they are opened, as long as the shard count did not change.
+func WithSessionStore(sessionStore gateway.SessionStore) ConfigOpt {
+	return func(config *Config) {
+		config.SessionStore = sessionStore
+	}
+}
+
 // WithRateLimiter lets you inject your own srate.RateLimiter into the ShardManager.
 func WithRateLimiter(rateLimiter RateLimiter) ConfigOpt {
 	return func(config *Config) {
@@ -108,3 +146,70 @@ func WithRateRateLimiterConfigOpt(opts ...RateLimiterConfigOpt) ConfigOpt {
 		config.RateRateLimiterConfigOpts = append(config.RateRateLimiterConfigOpts, opts...)
 	}
 }
+
+// WithGatewayBot sets the discord.GatewayBot received from discord.
+// Its recommended shard count, gateway URL and max concurrency are used unless they are configured explicitly.
+// All shards are managed if no shard IDs are configured. See NewWithGatewayBot to request it automatically.
+func WithGatewayBot(gatewayBot discord.GatewayBot) ConfigOpt {
+	return func(config *Config) {
+		config.GatewayBot = &gatewayBot
+	}
+}
+
+// WithAutoResharding lets the ShardManager reshard proactively. Every checkInterval it requests the recommended shard count from discord
+// and reshards with ShardManager.Reshard once it is higher than the current shard count.
+// If only a subset of the shards is managed, the new shard count is rounded up to a multiple of the current one.
+func WithAutoResharding(gatewayRest rest.Gateway, checkInterval time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.ReshardGatewayRest = gatewayRest
+		config.ReshardCheckInterval = checkInterval
+	}
+}
+
+// WithReshardHandoverWindow sets how long events of the old and new shards are deduplicated while resharding.
+// It should be higher than the time a shard may lag behind another one.
+func WithReshardHandoverWindow(window time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.ReshardHandoverWindow = window
+	}
+}
+
+// WithCoordinator lets the ShardManager join the cluster of the given Coordinator as node with the given ID when it is opened.
+// The Coordinator then assigns the shard count & shard IDs and serializes identifies across all nodes, so the configured ones are ignored.
+// The node ID has to be unique within the cluster.
+func WithCoordinator(coordinator Coordinator, nodeID string) ConfigOpt {
+	return func(config *Config) {
+		config.Coordinator = coordinator
+		config.NodeID = nodeID
+	}
+}
+
+// WithHealthCheckInterval sets how often the ShardManager checks the health of its shards. 0 disables the health checks, which is the default.
+func WithHealthCheckInterval(healthCheckInterval time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.HealthCheckInterval = healthCheckInterval
+	}
+}
+
+// WithShardStuckTimeout sets how long a shard may not be gateway.StatusReady before it is restarted. 0 disables the restarts, which is the default.
+// Shards which are gateway.StatusReconnecting are not restarted, as their gateway.ReconnectStrategy is still trying to reconnect them.
+func WithShardStuckTimeout(shardStuckTimeout time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.ShardStuckTimeout = shardStuckTimeout
+	}
+}
+
+// WithShardSilenceTimeout sets how long a ready shard may not receive any dispatch before it is restarted. 0 disables the restarts.
+// Shards of bots with few guilds can be silent for a long time, so choose it accordingly.
+func WithShardSilenceTimeout(shardSilenceTimeout time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.ShardSilenceTimeout = shardSilenceTimeout
+	}
+}
+
+// WithHealthChangeHandlerFunc sets the HealthChangeHandlerFunc which is called when the status or health of a shard changes.
+func WithHealthChangeHandlerFunc(healthChangeHandlerFunc HealthChangeHandlerFunc) ConfigOpt {
+	return func(config *Config) {
+		config.HealthChangeHandlerFunc = healthChangeHandlerFunc
+	}
+}
@@ -2,10 +2,14 @@ package sharding
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"sync"
+	"time"
 
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/rest"
 	"github.com/disgoorg/log"
 	"github.com/disgoorg/snowflake/v2"
 	"github.com/gorilla/websocket"
@@ -18,8 +22,30 @@ func New(token string, eventHandlerFunc gateway.EventHandlerFunc, opts ...Config
 	config := DefaultConfig()
 	config.Apply(opts)
 
+	return newShardManager(token, eventHandlerFunc, config)
+}
+
+// NewWithGatewayBot creates a new default ShardManager like New, but first requests the recommended shard count, gateway URL and max concurrency from discord.
+// It returns a *SessionStartLimitError if the remaining session starts are not enough to identify all shards.
+func NewWithGatewayBot(token string, gatewayRest rest.Gateway, eventHandlerFunc gateway.EventHandlerFunc, opts ...ConfigOpt) (ShardManager, error) {
+	gatewayBot, err := gatewayRest.GetGatewayBot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gateway bot: %w", err)
+	}
+
+	config := DefaultConfig()
+	config.Apply(append(opts, WithGatewayBot(*gatewayBot)))
+	if err = config.CheckSessionStartLimit(); err != nil {
+		return nil, err
+	}
+
+	return newShardManager(token, eventHandlerFunc, config), nil
+}
+
+func newShardManager(token string, eventHandlerFunc gateway.EventHandlerFunc, config *Config) ShardManager {
 	return &shardManagerImpl{
 		shards:           map[int]gateway.Gateway{},
+		set:              newShardSet(eventHandlerFunc, config.SessionStore, true),
 		token:            token,
 		eventHandlerFunc: eventHandlerFunc,
 		config:           *config,
@@ -27,9 +53,20 @@ func New(token string, eventHandlerFunc gateway.EventHandlerFunc, opts ...Config
 }
 
 type shardManagerImpl struct {
-	shards   map[int]gateway.Gateway
+	shards map[int]gateway.Gateway
+	// set is the shardSet of the current shards
+	set      *shardSet
 	shardsMu sync.Mutex
 
+	reshardMu          sync.Mutex
+	stopAutoResharding context.CancelFunc
+
+	// node is the ClusterNode of the ShardManager if a Coordinator is configured
+	node ClusterNode
+
+	health               healthTracker
+	stopHealthSupervisor context.CancelFunc
+
 	token            string
 	eventHandlerFunc gateway.EventHandlerFunc
 	config           Config
@@ -39,16 +76,43 @@ func (m *shardManagerImpl) Logger() log.Logger {
 	return m.config.Logger
 }
 
+func (m *shardManagerImpl) createShard(set *shardSet, shardID int, shardCount int) gateway.Gateway {
+	opts := make([]gateway.ConfigOpt, 0, len(m.config.GatewayConfigOpts)+4)
+	opts = append(opts, m.config.GatewayConfigOpts...)
+	opts = append(opts, gateway.WithShardID(shardID), gateway.WithShardCount(shardCount), func(config *gateway.Config) {
+		// count reconnects without replacing the configured ReconnectHandlerFunc
+		reconnectHandlerFunc := config.ReconnectHandlerFunc
+		config.ReconnectHandlerFunc = func(g gateway.Gateway, try int, delay time.Duration, err error) {
+			m.health.reconnecting(g.ShardID())
+			if reconnectHandlerFunc != nil {
+				reconnectHandlerFunc(g, try, delay, err)
+			}
+		}
+	})
+	if m.config.SessionStore != nil {
+		opts = append(opts, gateway.WithSessionStore(set))
+	}
+	eventHandlerFunc := func(gatewayEventType discord.GatewayEventType, sequenceNumber int, shardID int, payload io.Reader) {
+		m.health.dispatchReceived(shardID)
+		set.handleEvent(gatewayEventType, sequenceNumber, shardID, payload)
+	}
+	return m.config.GatewayCreateFunc(m.token, eventHandlerFunc, m.closeHandler, opts...)
+}
+
 func (m *shardManagerImpl) closeHandler(shard gateway.Gateway, err error) {
 	if closeError, ok := err.(*websocket.CloseError); !m.config.AutoScaling || !ok || discord.GatewayCloseEventCode(closeError.Code) != discord.GatewayCloseEventCodeShardingRequired {
 		return
 	}
-	m.Logger().Debugf("shard %d requires re-sharding", shard.ShardID())
-	// make sure shard is closed
-	shard.Close(context.TODO())
 
 	m.shardsMu.Lock()
 	defer m.shardsMu.Unlock()
+	if m.shards[shard.ShardID()] != shard {
+		// shard was replaced by resharding
+		return
+	}
+	m.Logger().Debugf("shard %d requires re-sharding", shard.ShardID())
+	// make sure shard is closed
+	shard.Close(context.TODO())
 
 	delete(m.shards, shard.ShardID())
 	delete(m.config.ShardIDs, shard.ShardID())
@@ -78,7 +142,7 @@ func (m *shardManagerImpl) closeHandler(shard gateway.Gateway, err error) {
 			}
 			defer m.config.RateLimiter.UnlockBucket(shardID)
 
-			newShard := m.config.GatewayCreateFunc(m.token, m.eventHandlerFunc, m.closeHandler, append(m.config.GatewayConfigOpts, gateway.WithShardID(shardID), gateway.WithShardCount(newShardCount))...)
+			newShard := m.createShard(m.set, shardID, newShardCount)
 			m.shards[shardID] = newShard
 			if err := newShard.Open(context.TODO()); err != nil {
 				m.Logger().Errorf("failed to re shard %d, error: %s", shardID, err)
@@ -95,6 +159,22 @@ func (m *shardManagerImpl) Open(ctx context.Context) {
 
 	m.shardsMu.Lock()
 	defer m.shardsMu.Unlock()
+	if m.config.ReshardGatewayRest != nil && m.stopAutoResharding == nil {
+		autoReshardingCtx, cancel := context.WithCancel(context.Background())
+		m.stopAutoResharding = cancel
+		go m.autoReshard(autoReshardingCtx)
+	}
+	if m.config.HealthCheckInterval > 0 && m.stopHealthSupervisor == nil {
+		healthSupervisorCtx, cancel := context.WithCancel(context.Background())
+		m.stopHealthSupervisor = cancel
+		go m.superviseHealth(healthSupervisorCtx)
+	}
+	if m.config.Coordinator != nil && m.node == nil {
+		if err := m.joinCluster(ctx); err != nil {
+			m.Logger().Error("failed to open shards: ", err)
+			return
+		}
+	}
 	for shardInt := range m.config.ShardIDs {
 		shardID := shardInt
 		if _, ok := m.shards[shardID]; ok {
@@ -110,7 +190,7 @@ func (m *shardManagerImpl) Open(ctx context.Context) {
 			}
 			defer m.config.RateLimiter.UnlockBucket(shardID)
 
-			shard := m.config.GatewayCreateFunc(m.token, m.eventHandlerFunc, m.closeHandler, append(m.config.GatewayConfigOpts, gateway.WithShardID(shardID), gateway.WithShardCount(m.config.ShardCount))...)
+			shard := m.createShard(m.set, shardID, m.config.ShardCount)
 			m.shards[shardID] = shard
 			if err := shard.Open(ctx); err != nil {
 				m.Logger().Errorf("failed to open shard %d: %s", shardID, err)
@@ -126,6 +206,14 @@ func (m *shardManagerImpl) Close(ctx context.Context) {
 
 	m.shardsMu.Lock()
 	defer m.shardsMu.Unlock()
+	if m.stopAutoResharding != nil {
+		m.stopAutoResharding()
+		m.stopAutoResharding = nil
+	}
+	if m.stopHealthSupervisor != nil {
+		m.stopHealthSupervisor()
+		m.stopHealthSupervisor = nil
+	}
 	for shardID := range m.shards {
 		shard := m.shards[shardID]
 		delete(m.shards, shardID)
@@ -136,6 +224,12 @@ func (m *shardManagerImpl) Close(ctx context.Context) {
 		}()
 	}
 	wg.Wait()
+
+	// leave the cluster after closing the shards, so they are not run twice
+	if m.node != nil {
+		m.node.Close(ctx)
+		m.node = nil
+	}
 }
 
 func (m *shardManagerImpl) OpenShard(ctx context.Context, shardID int) error {
@@ -149,10 +243,10 @@ func (m *shardManagerImpl) openShard(ctx context.Context, shardID int, shardCoun
 		return err
 	}
 	defer m.config.RateLimiter.UnlockBucket(shardID)
-	shard := m.config.GatewayCreateFunc(m.token, m.eventHandlerFunc, m.closeHandler, append(m.config.GatewayConfigOpts, gateway.WithShardID(shardID), gateway.WithShardCount(shardCount))...)
 
 	m.shardsMu.Lock()
 	defer m.shardsMu.Unlock()
+	shard := m.createShard(m.set, shardID, shardCount)
 	m.config.ShardIDs[shardID] = struct{}{}
 	m.shards[shardID] = shard
 	return shard.Open(ctx)
@@ -192,5 +286,5 @@ func (m *shardManagerImpl) Shards() map[int]gateway.Gateway {
 	for shardID, shard := range m.shards {
 		shards[shardID] = shard
 	}
-	return m.shards
+	return shards
 }
@@ -0,0 +1,106 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/disgoorg/disgo/gateway"
+)
+
+func (m *shardManagerImpl) Reconfigure(ctx context.Context, opts ...gateway.ConfigOpt) error {
+	// don't reconfigure shards which are about to be replaced by a reshard
+	m.reshardMu.Lock()
+	defer m.reshardMu.Unlock()
+
+	m.shardsMu.Lock()
+	// shards opened later on use the new options too
+	gatewayConfigOpts := make([]gateway.ConfigOpt, 0, len(m.config.GatewayConfigOpts)+len(opts))
+	gatewayConfigOpts = append(gatewayConfigOpts, m.config.GatewayConfigOpts...)
+	m.config.GatewayConfigOpts = append(gatewayConfigOpts, opts...)
+	shards := make(map[int]gateway.Gateway, len(m.shards))
+	for shardID, shard := range m.shards {
+		shards[shardID] = shard
+	}
+	m.shardsMu.Unlock()
+
+	maxConcurrency := 1
+	if m.config.GatewayBot != nil && m.config.GatewayBot.SessionStartLimit.MaxConcurrency > 0 {
+		maxConcurrency = m.config.GatewayBot.SessionStartLimit.MaxConcurrency
+	}
+
+	for _, batch := range reconfigureBatches(shards, maxConcurrency) {
+		m.Logger().Debugf("reconfiguring shards %v...", batch)
+		if err := m.reconfigureShards(ctx, shards, batch, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconfigureShards reconfigures the given shards at once and waits until all of them are ready again.
+func (m *shardManagerImpl) reconfigureShards(ctx context.Context, shards map[int]gateway.Gateway, shardIDs []int, opts []gateway.ConfigOpt) error {
+	var (
+		wg       sync.WaitGroup
+		errMu    sync.Mutex
+		firstErr error
+	)
+	for _, shardID := range shardIDs {
+		shardID := shardID
+		shard := shards[shardID]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := m.config.RateLimiter.WaitBucket(ctx, shardID)
+			if err == nil {
+				err = shard.Reconfigure(ctx, opts...)
+				m.config.RateLimiter.UnlockBucket(shardID)
+			}
+			if err != nil {
+				errMu.Lock()
+				defer errMu.Unlock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to reconfigure shard %d: %w", shardID, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// reconfigureBatches splits the shards into batches which can identify at the same time.
+// Each batch holds at most one shard per identify bucket, in the order of the shard IDs.
+func reconfigureBatches(shards map[int]gateway.Gateway, maxConcurrency int) [][]int {
+	shardIDs := make([]int, 0, len(shards))
+	for shardID := range shards {
+		shardIDs = append(shardIDs, shardID)
+	}
+	sort.Ints(shardIDs)
+
+	var batches [][]int
+	for _, shardID := range shardIDs {
+		key := ShardMaxConcurrencyKey(shardID, maxConcurrency)
+		i := 0
+		for ; i < len(batches); i++ {
+			if !hasShardMaxConcurrencyKey(batches[i], key, maxConcurrency) {
+				break
+			}
+		}
+		if i == len(batches) {
+			batches = append(batches, nil)
+		}
+		batches[i] = append(batches[i], shardID)
+	}
+	return batches
+}
+
+func hasShardMaxConcurrencyKey(shardIDs []int, key int, maxConcurrency int) bool {
+	for _, shardID := range shardIDs {
+		if ShardMaxConcurrencyKey(shardID, maxConcurrency) == key {
+			return true
+		}
+	}
+	return false
+}
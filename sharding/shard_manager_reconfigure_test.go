@@ -0,0 +1,19 @@
+package sharding
+
+import (
+	"testing"
+
+	"github.com/disgoorg/disgo/gateway"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconfigureBatches(t *testing.T) {
+	shards := map[int]gateway.Gateway{}
+	for _, shardID := range []int{0, 1, 2, 3, 5, 8} {
+		shards[shardID] = nil
+	}
+
+	assert.Equal(t, [][]int{{0}, {1}, {2}, {3}, {5}, {8}}, reconfigureBatches(shards, 1))
+	assert.Equal(t, [][]int{{0, 1}, {2, 3}, {5, 8}}, reconfigureBatches(shards, 2))
+	assert.Equal(t, [][]int{{0, 1, 2, 3}, {5, 8}}, reconfigureBatches(shards, 4))
+}
@@ -0,0 +1,224 @@
+package sharding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/gorilla/websocket"
+)
+
+// ErrReshardShardCount is returned by ShardManager.Reshard when the new shard count is not higher than the current one.
+var ErrReshardShardCount = errors.New("new shard count must be higher than the current shard count")
+
+// ErrReshardCoordinator is returned by ShardManager.Reshard when the shards are assigned by a Coordinator.
+var ErrReshardCoordinator = errors.New("shards are assigned by the coordinator")
+
+func (m *shardManagerImpl) Reshard(ctx context.Context, shardCount int) error {
+	if m.config.Coordinator != nil {
+		return ErrReshardCoordinator
+	}
+	m.reshardMu.Lock()
+	defer m.reshardMu.Unlock()
+
+	m.shardsMu.Lock()
+	oldSet := m.set
+	oldShardCount := m.config.ShardCount
+	shardIDs, err := reshardShardIDs(m.config.ShardIDs, oldShardCount, shardCount)
+	m.shardsMu.Unlock()
+	if err != nil {
+		return err
+	}
+	m.Logger().Debugf("resharding from %d to %d shards, opening shards %v...", oldShardCount, shardCount, shardIDs)
+
+	handover := newReshardHandover(m.eventHandlerFunc, m.config.ReshardHandoverWindow)
+	newSet := newShardSet(handover.handleNewEvent, m.config.SessionStore, false)
+	newShards := make(map[int]gateway.Gateway, len(shardIDs))
+	for shardID := range shardIDs {
+		shard := m.createShard(newSet, shardID, shardCount)
+		handover.addShard(shardID, shard.GatewayIntents())
+		newShards[shardID] = shard
+	}
+	oldSet.setEventHandlerFunc(handover.handleOldEvent)
+
+	if err = m.openShards(ctx, newShards); err == nil {
+		err = handover.waitReady(ctx)
+	}
+
+	m.shardsMu.Lock()
+	if err == nil {
+		// don't swap in the new shards if the ShardManager was closed in the meantime
+		err = ctx.Err()
+	}
+	if err != nil {
+		m.shardsMu.Unlock()
+		oldSet.setEventHandlerFunc(m.eventHandlerFunc)
+		closeShards(context.TODO(), newShards)
+		return fmt.Errorf("failed to reshard to %d shards: %w", shardCount, err)
+	}
+
+	oldShards := m.shards
+	oldSet.setActive(false)
+	m.storeSessions(newShards)
+	newSet.setActive(true)
+	m.shards = newShards
+	m.set = newSet
+	m.config.ShardCount = shardCount
+	m.config.ShardIDs = shardIDs
+	m.shardsMu.Unlock()
+	handover.swap()
+
+	// once the old shards are closed there is nothing left to deduplicate
+	time.AfterFunc(m.config.ReshardHandoverWindow, func() {
+		newSet.setEventHandlerFunc(m.eventHandlerFunc)
+	})
+	closeShards(ctx, oldShards)
+	m.Logger().Debugf("resharded from %d to %d shards", oldShardCount, shardCount)
+	return nil
+}
+
+// openShards opens the given shards while respecting the RateLimiter.
+func (m *shardManagerImpl) openShards(ctx context.Context, shards map[int]gateway.Gateway) error {
+	var (
+		wg       sync.WaitGroup
+		errMu    sync.Mutex
+		firstErr error
+	)
+	for shardID := range shards {
+		shardID := shardID
+		shard := shards[shardID]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := m.config.RateLimiter.WaitBucket(ctx, shardID)
+			if err == nil {
+				err = shard.Open(ctx)
+				m.config.RateLimiter.UnlockBucket(shardID)
+			}
+			if err != nil {
+				errMu.Lock()
+				defer errMu.Unlock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to open shard %d: %w", shardID, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// storeSessions stores the sessions of the new shards, so they replace the sessions of the old shards in the gateway.SessionStore.
+func (m *shardManagerImpl) storeSessions(shards map[int]gateway.Gateway) {
+	if m.config.SessionStore == nil {
+		return
+	}
+	for shardID, shard := range shards {
+		sessionID, sequence := shard.SessionID(), shard.LastSequenceReceived()
+		if sessionID == nil || sequence == nil {
+			continue
+		}
+		if err := m.config.SessionStore.Put(shardID, gateway.Session{
+			ID:         *sessionID,
+			Sequence:   *sequence,
+			ShardCount: shard.ShardCount(),
+		}); err != nil {
+			m.Logger().Errorf("failed to store session of shard %d: %s", shardID, err)
+		}
+	}
+}
+
+// closeShards closes the given shards and invalidates their sessions.
+func closeShards(ctx context.Context, shards map[int]gateway.Gateway) {
+	var wg sync.WaitGroup
+	for _, shard := range shards {
+		shard := shard
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			shard.CloseWithCode(ctx, websocket.CloseNormalClosure, "Resharding")
+		}()
+	}
+	wg.Wait()
+}
+
+// reshardShardIDs returns the shard IDs which cover the guilds of the given shard IDs with the new shard count.
+// If only a subset of the shards is managed, the new shard count has to be a multiple of the old one, so no guild moves to a shard managed by another ShardManager.
+func reshardShardIDs(shardIDs map[int]struct{}, shardCount int, newShardCount int) (map[int]struct{}, error) {
+	if newShardCount <= shardCount {
+		return nil, ErrReshardShardCount
+	}
+
+	newShardIDs := map[int]struct{}{}
+	if len(shardIDs) >= shardCount {
+		for shardID := 0; shardID < newShardCount; shardID++ {
+			newShardIDs[shardID] = struct{}{}
+		}
+		return newShardIDs, nil
+	}
+
+	if newShardCount%shardCount != 0 {
+		return nil, fmt.Errorf("new shard count %d must be a multiple of the current shard count %d when only a subset of shards is managed", newShardCount, shardCount)
+	}
+	for shardID := 0; shardID < newShardCount; shardID++ {
+		if _, ok := shardIDs[shardID%shardCount]; ok {
+			newShardIDs[shardID] = struct{}{}
+		}
+	}
+	return newShardIDs, nil
+}
+
+func (m *shardManagerImpl) autoReshard(ctx context.Context) {
+	defer m.Logger().Debug("exiting auto resharding goroutine...")
+
+	ticker := time.NewTicker(m.config.ReshardCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := m.checkReshard(ctx); err != nil {
+			m.Logger().Error("failed to auto reshard: ", err)
+		}
+	}
+}
+
+// checkReshard reshards if discord recommends more shards than the current shard count.
+func (m *shardManagerImpl) checkReshard(ctx context.Context) error {
+	gatewayBot, err := m.config.ReshardGatewayRest.GetGatewayBot(rest.WithCtx(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to get gateway bot: %w", err)
+	}
+
+	m.shardsMu.Lock()
+	shardCount := m.config.ShardCount
+	if gatewayBot.Shards <= shardCount {
+		m.shardsMu.Unlock()
+		return nil
+	}
+	newShardCount := gatewayBot.Shards
+	if len(m.config.ShardIDs) < shardCount && newShardCount%shardCount != 0 {
+		// round up to the next multiple, so the managed guilds don't move to other ShardManager(s)
+		newShardCount += shardCount - newShardCount%shardCount
+	}
+	shardIDs, err := reshardShardIDs(m.config.ShardIDs, shardCount, newShardCount)
+	m.shardsMu.Unlock()
+	if err != nil {
+		return err
+	}
+	if len(shardIDs) > gatewayBot.SessionStartLimit.Remaining {
+		return &SessionStartLimitError{
+			Identifies:        len(shardIDs),
+			SessionStartLimit: gatewayBot.SessionStartLimit,
+		}
+	}
+
+	m.Logger().Infof("discord recommends %d shards, resharding from %d to %d shards", gatewayBot.Shards, shardCount, newShardCount)
+	return m.Reshard(ctx, newShardCount)
+}
@@ -74,7 +74,7 @@ func (r *rateLimiterImpl) getBucket(shardID int, create bool) *bucket {
 
 func (r *rateLimiterImpl) WaitBucket(ctx context.Context, shardID int) error {
 	b := r.getBucket(shardID, true)
-	r.Logger().Debugf("locking shard bucket: Key: %d, Reset: %s", b.Key, b.Reset)
+	r.Logger().Debugf("locking shard bucket: Key: %d", b.Key)
 	if err := b.mu.CLock(ctx); err != nil {
 		return err
 	}
@@ -88,6 +88,7 @@ func (r *rateLimiterImpl) WaitBucket(ctx context.Context, shardID int) error {
 
 	if until.After(now) {
 		if deadline, ok := ctx.Deadline(); ok && until.After(deadline) {
+			b.mu.Unlock()
 			return context.DeadlineExceeded
 		}
 
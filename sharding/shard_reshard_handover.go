@@ -0,0 +1,290 @@
+package sharding
+
+import (
+	"bytes"
+	"context"
+	"hash/fnv"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// reshardHandover routes the events of the old and the new shards while resharding.
+//
+// Until the swap the old shards deliver all events and the new shards only buffer theirs.
+// The READY & initial GUILD_CREATE events of the new shards are used to track their readiness and are not delivered.
+// On the swap the buffered events which were not delivered by the old shards yet are delivered.
+// After the swap the old shards are ignored and the new shards deliver all events which the old shards did not deliver already.
+// Events are matched by their type and payload within the handover window.
+// What to deliver is decided while holding mu, but the events are delivered after releasing it.
+type reshardHandover struct {
+	eventHandlerFunc gateway.EventHandlerFunc
+	window           time.Duration
+
+	mu      sync.Mutex
+	swapped bool
+	// flushing is true while swap delivers the buffered events, events of the new shards are buffered until then to keep their order
+	flushing bool
+
+	// delivered holds the events delivered by the old shards in order
+	delivered       []handoverEvent
+	deliveredHashes map[uint64]int
+	// buffered holds the events received by the new shards before the swap in order
+	buffered []handoverEvent
+
+	shards     map[int]*shardReadiness
+	ready      chan struct{}
+	readyClose sync.Once
+}
+
+type handoverEvent struct {
+	hash           uint64
+	received       time.Time
+	eventType      discord.GatewayEventType
+	sequenceNumber int
+	shardID        int
+	data           []byte
+}
+
+type shardReadiness struct {
+	ready bool
+	// trackGuilds is false if the shard has no discord.GatewayIntentGuilds and therefore receives no GUILD_CREATE events
+	trackGuilds bool
+	guildIDs    map[snowflake.ID]struct{}
+}
+
+func newReshardHandover(eventHandlerFunc gateway.EventHandlerFunc, window time.Duration) *reshardHandover {
+	return &reshardHandover{
+		eventHandlerFunc: eventHandlerFunc,
+		window:           window,
+		deliveredHashes:  map[uint64]int{},
+		shards:           map[int]*shardReadiness{},
+		ready:            make(chan struct{}),
+	}
+}
+
+// addShard registers a new shard which has to become ready before the swap.
+func (h *reshardHandover) addShard(shardID int, intents discord.GatewayIntents) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.shards[shardID] = &shardReadiness{
+		trackGuilds: intents.Has(discord.GatewayIntentGuilds),
+		guildIDs:    map[snowflake.ID]struct{}{},
+	}
+}
+
+// waitReady waits until all new shards received READY and all their guilds are available.
+func (h *reshardHandover) waitReady(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-h.ready:
+		return nil
+	}
+}
+
+func (h *reshardHandover) handleOldEvent(gatewayEventType discord.GatewayEventType, sequenceNumber int, shardID int, payload io.Reader) {
+	data, _ := io.ReadAll(payload)
+	now := time.Now()
+
+	h.mu.Lock()
+	if h.swapped {
+		h.mu.Unlock()
+		// the new shards deliver this event
+		return
+	}
+
+	h.pruneDelivered(now)
+	hash := hashEvent(gatewayEventType, data)
+	h.delivered = append(h.delivered, handoverEvent{hash: hash, received: now})
+	h.deliveredHashes[hash]++
+	h.mu.Unlock()
+
+	h.eventHandlerFunc(gatewayEventType, sequenceNumber, shardID, bytes.NewReader(data))
+}
+
+func (h *reshardHandover) handleNewEvent(gatewayEventType discord.GatewayEventType, sequenceNumber int, shardID int, payload io.Reader) {
+	data, _ := io.ReadAll(payload)
+	now := time.Now()
+
+	event := handoverEvent{
+		hash:           hashEvent(gatewayEventType, data),
+		received:       now,
+		eventType:      gatewayEventType,
+		sequenceNumber: sequenceNumber,
+		shardID:        shardID,
+		data:           data,
+	}
+
+	h.mu.Lock()
+	if !h.swapped {
+		if !h.trackReadiness(event) {
+			h.pruneBuffered(now)
+			h.buffered = append(h.buffered, event)
+		}
+		h.mu.Unlock()
+		return
+	}
+	if h.flushing {
+		h.buffered = append(h.buffered, event)
+		h.mu.Unlock()
+		return
+	}
+
+	h.pruneDelivered(now)
+	undelivered := h.undelivered(event)
+	h.mu.Unlock()
+
+	if undelivered {
+		h.deliver(event)
+	}
+}
+
+// swap makes the new shards deliver the events from now on.
+func (h *reshardHandover) swap() {
+	h.mu.Lock()
+	now := time.Now()
+	h.pruneDelivered(now)
+	h.pruneBuffered(now)
+	h.swapped = true
+	h.flushing = true
+
+	// events of the new shards received while delivering are buffered, so deliver until no more are left
+	for len(h.buffered) > 0 {
+		var events []handoverEvent
+		for _, event := range h.buffered {
+			if h.undelivered(event) {
+				events = append(events, event)
+			}
+		}
+		h.buffered = nil
+		h.mu.Unlock()
+
+		for _, event := range events {
+			h.deliver(event)
+		}
+		h.mu.Lock()
+	}
+	h.flushing = false
+	h.mu.Unlock()
+}
+
+// undelivered returns whether the event of the new shards was not delivered by the old shards already. h.mu must be held.
+func (h *reshardHandover) undelivered(event handoverEvent) bool {
+	if count, ok := h.deliveredHashes[event.hash]; ok {
+		if count <= 1 {
+			delete(h.deliveredHashes, event.hash)
+		} else {
+			h.deliveredHashes[event.hash] = count - 1
+		}
+		return false
+	}
+	return true
+}
+
+// deliver passes the event to the gateway.EventHandlerFunc. h.mu must not be held.
+func (h *reshardHandover) deliver(event handoverEvent) {
+	h.eventHandlerFunc(event.eventType, event.sequenceNumber, event.shardID, bytes.NewReader(event.data))
+}
+
+// trackReadiness updates the readiness of the new shard and returns whether the event was only needed for that. h.mu must be held.
+func (h *reshardHandover) trackReadiness(event handoverEvent) bool {
+	shard, ok := h.shards[event.shardID]
+	if !ok {
+		return false
+	}
+
+	switch event.eventType {
+	case discord.GatewayEventTypeReady:
+		var readyEvent discord.GatewayEventReady
+		if err := json.Unmarshal(event.data, &readyEvent); err != nil {
+			return false
+		}
+		shard.ready = true
+		if shard.trackGuilds {
+			for _, guild := range readyEvent.Guilds {
+				shard.guildIDs[guild.ID] = struct{}{}
+			}
+		}
+		h.checkReady()
+		return true
+
+	case discord.GatewayEventTypeGuildCreate:
+		var guild struct {
+			ID snowflake.ID `json:"id"`
+		}
+		if err := json.Unmarshal(event.data, &guild); err != nil {
+			return false
+		}
+		if _, ok = shard.guildIDs[guild.ID]; !ok {
+			return false
+		}
+		delete(shard.guildIDs, guild.ID)
+		h.checkReady()
+		return true
+
+	case discord.GatewayEventTypeGuildDelete:
+		var guild discord.UnavailableGuild
+		if err := json.Unmarshal(event.data, &guild); err != nil {
+			return false
+		}
+		if _, ok = shard.guildIDs[guild.ID]; !ok {
+			return false
+		}
+		// unavailable guilds won't become ready, guilds the bot got removed from are delivered by the old shards too
+		delete(shard.guildIDs, guild.ID)
+		h.checkReady()
+		return guild.Unavailable
+	}
+	return false
+}
+
+// checkReady closes the ready channel once all new shards are ready. h.mu must be held.
+func (h *reshardHandover) checkReady() {
+	for _, shard := range h.shards {
+		if !shard.ready || len(shard.guildIDs) > 0 {
+			return
+		}
+	}
+	h.readyClose.Do(func() {
+		close(h.ready)
+	})
+}
+
+// pruneDelivered removes delivered events older than the handover window. h.mu must be held.
+func (h *reshardHandover) pruneDelivered(now time.Time) {
+	var i int
+	for ; i < len(h.delivered) && now.Sub(h.delivered[i].received) > h.window; i++ {
+		hash := h.delivered[i].hash
+		if count, ok := h.deliveredHashes[hash]; ok {
+			if count <= 1 {
+				delete(h.deliveredHashes, hash)
+			} else {
+				h.deliveredHashes[hash] = count - 1
+			}
+		}
+	}
+	h.delivered = h.delivered[i:]
+}
+
+// pruneBuffered removes buffered events older than the handover window, the old shards delivered them already. h.mu must be held.
+func (h *reshardHandover) pruneBuffered(now time.Time) {
+	var i int
+	for i < len(h.buffered) && now.Sub(h.buffered[i].received) > h.window {
+		i++
+	}
+	h.buffered = h.buffered[i:]
+}
+
+func hashEvent(gatewayEventType discord.GatewayEventType, data []byte) uint64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(gatewayEventType))
+	_, _ = hash.Write([]byte{0})
+	_, _ = hash.Write(data)
+	return hash.Sum64()
+}
@@ -0,0 +1,96 @@
+package sharding
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReshardHandover(t *testing.T) {
+	var delivered []string
+	h := newReshardHandover(func(gatewayEventType discord.GatewayEventType, sequenceNumber int, shardID int, payload io.Reader) {
+		data, _ := io.ReadAll(payload)
+		delivered = append(delivered, string(gatewayEventType)+" "+string(data))
+	}, time.Minute)
+	h.addShard(0, discord.GatewayIntentGuilds)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Error(t, h.waitReady(ctx))
+
+	h.handleNewEvent(discord.GatewayEventTypeReady, 1, 0, strings.NewReader(`{"guilds":[{"id":"1","unavailable":true}]}`))
+	h.handleOldEvent(discord.GatewayEventTypeTypingStart, 10, 0, strings.NewReader(`{"a":1}`))
+	h.handleNewEvent(discord.GatewayEventTypeGuildCreate, 2, 0, strings.NewReader(`{"id":"1"}`))
+	h.handleNewEvent(discord.GatewayEventTypeTypingStart, 3, 0, strings.NewReader(`{"a":1}`))
+	h.handleNewEvent(discord.GatewayEventTypeTypingStart, 4, 0, strings.NewReader(`{"b":2}`))
+	require.NoError(t, h.waitReady(context.Background()))
+
+	h.swap()
+	h.handleOldEvent(discord.GatewayEventTypeTypingStart, 11, 0, strings.NewReader(`{"b":2}`))
+	h.handleOldEvent(discord.GatewayEventTypeTypingStart, 12, 0, strings.NewReader(`{"c":3}`))
+	h.handleNewEvent(discord.GatewayEventTypeTypingStart, 5, 0, strings.NewReader(`{"c":3}`))
+
+	assert.Equal(t, []string{
+		`TYPING_START {"a":1}`,
+		`TYPING_START {"b":2}`,
+		`TYPING_START {"c":3}`,
+	}, delivered)
+}
+
+func TestReshardHandoverDeduplicatesAfterSwap(t *testing.T) {
+	var delivered []string
+	h := newReshardHandover(func(gatewayEventType discord.GatewayEventType, sequenceNumber int, shardID int, payload io.Reader) {
+		data, _ := io.ReadAll(payload)
+		delivered = append(delivered, string(data))
+	}, time.Minute)
+
+	h.handleOldEvent(discord.GatewayEventTypeTypingStart, 10, 0, strings.NewReader(`{"a":1}`))
+	h.swap()
+	h.handleNewEvent(discord.GatewayEventTypeTypingStart, 1, 0, strings.NewReader(`{"a":1}`))
+	h.handleNewEvent(discord.GatewayEventTypeTypingStart, 2, 0, strings.NewReader(`{"a":1}`))
+
+	assert.Equal(t, []string{`{"a":1}`, `{"a":1}`}, delivered)
+}
+
+func TestReshardHandoverDeliversWithoutLock(t *testing.T) {
+	var (
+		h         *reshardHandover
+		delivered []string
+	)
+	h = newReshardHandover(func(gatewayEventType discord.GatewayEventType, sequenceNumber int, shardID int, payload io.Reader) {
+		data, _ := io.ReadAll(payload)
+		delivered = append(delivered, string(data))
+		// a new shard receives an event while the buffered events are delivered
+		if string(data) == `{"a":1}` {
+			h.handleNewEvent(discord.GatewayEventTypeTypingStart, 3, 0, strings.NewReader(`{"c":3}`))
+		}
+	}, time.Minute)
+
+	h.handleNewEvent(discord.GatewayEventTypeTypingStart, 1, 0, strings.NewReader(`{"a":1}`))
+	h.handleNewEvent(discord.GatewayEventTypeTypingStart, 2, 0, strings.NewReader(`{"b":2}`))
+	h.swap()
+
+	assert.Equal(t, []string{`{"a":1}`, `{"b":2}`, `{"c":3}`}, delivered)
+}
+
+func TestReshardShardIDs(t *testing.T) {
+	shardIDs, err := reshardShardIDs(map[int]struct{}{0: {}, 1: {}}, 2, 3)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]struct{}{0: {}, 1: {}, 2: {}}, shardIDs)
+
+	shardIDs, err = reshardShardIDs(map[int]struct{}{1: {}}, 2, 6)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]struct{}{1: {}, 3: {}, 5: {}}, shardIDs)
+
+	_, err = reshardShardIDs(map[int]struct{}{1: {}}, 2, 5)
+	assert.Error(t, err)
+
+	_, err = reshardShardIDs(map[int]struct{}{0: {}, 1: {}}, 2, 2)
+	assert.ErrorIs(t, err, ErrReshardShardCount)
+}
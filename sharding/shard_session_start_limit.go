@@ -0,0 +1,49 @@
+package sharding
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// SessionStartLimitError is returned when discord does not allow enough new sessions to identify all shards.
+type SessionStartLimitError struct {
+	// Identifies is the number of shards which need to identify.
+	Identifies int
+
+	// SessionStartLimit is the limit discord returned.
+	SessionStartLimit discord.SessionStartLimit
+}
+
+func (e *SessionStartLimitError) Error() string {
+	return fmt.Sprintf("not enough session starts remaining to identify %d shards: %d of %d remaining, resets in %s",
+		e.Identifies, e.SessionStartLimit.Remaining, e.SessionStartLimit.Total, time.Duration(e.SessionStartLimit.ResetAfter)*time.Millisecond,
+	)
+}
+
+// CheckSessionStartLimit returns a *SessionStartLimitError if the remaining session starts of the Config.GatewayBot are not enough to identify all shards.
+// Shards which can resume a session from the gateway.SessionStore don't need to identify.
+func (c *Config) CheckSessionStartLimit() error {
+	if c.GatewayBot == nil {
+		return nil
+	}
+
+	var identifies int
+	for shardID := range c.ShardIDs {
+		if c.SessionStore != nil {
+			if session, err := c.SessionStore.Get(shardID); err == nil && session != nil && session.ShardCount == c.ShardCount {
+				continue
+			}
+		}
+		identifies++
+	}
+
+	if identifies > c.GatewayBot.SessionStartLimit.Remaining {
+		return &SessionStartLimitError{
+			Identifies:        identifies,
+			SessionStartLimit: c.GatewayBot.SessionStartLimit,
+		}
+	}
+	return nil
+}
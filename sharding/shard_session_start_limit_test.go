@@ -0,0 +1,36 @@
+package sharding
+
+import (
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigGatewayBot(t *testing.T) {
+	gatewayBot := discord.GatewayBot{
+		URL:    "wss://gateway.discord.gg",
+		Shards: 4,
+		SessionStartLimit: discord.SessionStartLimit{
+			Total:          1000,
+			Remaining:      3,
+			ResetAfter:     60000,
+			MaxConcurrency: 16,
+		},
+	}
+
+	config := DefaultConfig()
+	config.Apply([]ConfigOpt{WithGatewayBot(gatewayBot)})
+	assert.Equal(t, 4, config.ShardCount)
+	assert.Equal(t, map[int]struct{}{0: {}, 1: {}, 2: {}, 3: {}}, config.ShardIDs)
+
+	var err *SessionStartLimitError
+	assert.ErrorAs(t, config.CheckSessionStartLimit(), &err)
+	assert.Equal(t, 4, err.Identifies)
+
+	config = DefaultConfig()
+	config.Apply([]ConfigOpt{WithGatewayBot(gatewayBot), WithShardCount(8), WithShardIDs(0, 2, 4)})
+	assert.Equal(t, 8, config.ShardCount)
+	assert.Len(t, config.ShardIDs, 3)
+	assert.NoError(t, config.CheckSessionStartLimit())
+}
@@ -0,0 +1,83 @@
+package sharding
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+var _ gateway.SessionStore = (*shardSet)(nil)
+
+// shardSet holds the state shared by all shards which were opened with the same shard count.
+// Events of its shards are routed through it, so they can be redirected while resharding.
+// It also acts as gateway.SessionStore of its shards and only forwards writes while it is active,
+// so an old and a new set of shards don't overwrite each other's sessions.
+type shardSet struct {
+	eventHandlerMu   sync.Mutex
+	eventHandlerFunc gateway.EventHandlerFunc
+
+	sessionStore gateway.SessionStore
+	active       int32
+}
+
+func newShardSet(eventHandlerFunc gateway.EventHandlerFunc, sessionStore gateway.SessionStore, active bool) *shardSet {
+	s := &shardSet{
+		eventHandlerFunc: eventHandlerFunc,
+		sessionStore:     sessionStore,
+	}
+	s.setActive(active)
+	return s
+}
+
+func (s *shardSet) handleEvent(gatewayEventType discord.GatewayEventType, sequenceNumber int, shardID int, payload io.Reader) {
+	s.eventHandlerMu.Lock()
+	eventHandlerFunc := s.eventHandlerFunc
+	s.eventHandlerMu.Unlock()
+	eventHandlerFunc(gatewayEventType, sequenceNumber, shardID, payload)
+}
+
+func (s *shardSet) setEventHandlerFunc(eventHandlerFunc gateway.EventHandlerFunc) {
+	s.eventHandlerMu.Lock()
+	defer s.eventHandlerMu.Unlock()
+	s.eventHandlerFunc = eventHandlerFunc
+}
+
+func (s *shardSet) setActive(active bool) {
+	var v int32
+	if active {
+		v = 1
+	}
+	atomic.StoreInt32(&s.active, v)
+}
+
+func (s *shardSet) isActive() bool {
+	return atomic.LoadInt32(&s.active) == 1
+}
+
+func (s *shardSet) Get(shardID int) (*gateway.Session, error) {
+	return s.sessionStore.Get(shardID)
+}
+
+func (s *shardSet) Put(shardID int, session gateway.Session) error {
+	if !s.isActive() {
+		return nil
+	}
+	return s.sessionStore.Put(shardID, session)
+}
+
+func (s *shardSet) UpdateSequence(shardID int, sequence int) error {
+	if !s.isActive() {
+		return nil
+	}
+	return s.sessionStore.UpdateSequence(shardID, sequence)
+}
+
+func (s *shardSet) Delete(shardID int) error {
+	if !s.isActive() {
+		return nil
+	}
+	return s.sessionStore.Delete(shardID)
+}
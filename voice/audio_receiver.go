@@ -0,0 +1,136 @@
+package voice
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/log"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// OpusFrameReceiver is called by the AudioReceiver with every Packet received from a user.
+type OpusFrameReceiver interface {
+	// ReceiveOpusFrame is called with every Packet received from the given user.
+	ReceiveOpusFrame(userID snowflake.ID, packet *Packet) error
+
+	// CleanupUser is called when the user left the voice channel and no more packets will be received from them.
+	CleanupUser(userID snowflake.ID)
+
+	// Close is called when the AudioReceiver is closed.
+	Close()
+}
+
+// AudioReceiver reads the Packet(s) of a Conn and passes them with the user who sent them to an OpusFrameReceiver.
+type AudioReceiver interface {
+	// Open starts receiving Packet(s) in a new goroutine.
+	Open()
+
+	// CleanupUser tells the OpusFrameReceiver that the user left the voice channel.
+	CleanupUser(userID snowflake.ID)
+
+	// Close stops receiving Packet(s) and closes the OpusFrameReceiver.
+	// It waits until the OpusFrameReceiver is no longer called with new Packet(s).
+	Close()
+}
+
+const (
+	// maxReadPacketFailures is the number of consecutive failed reads after which the AudioReceiver stops receiving.
+	maxReadPacketFailures = 10
+	// readPacketBackoff is how long the AudioReceiver waits after a failed read, doubled with every consecutive failure.
+	readPacketBackoff = 10 * time.Millisecond
+)
+
+var _ AudioReceiver = (*audioReceiverImpl)(nil)
+
+// NewAudioReceiver creates a new AudioReceiver which reads the Packet(s) of the given Conn and passes them to the OpusFrameReceiver.
+func NewAudioReceiver(logger log.Logger, opusReceiver OpusFrameReceiver, conn Conn) AudioReceiver {
+	return &audioReceiverImpl{
+		logger:       logger,
+		opusReceiver: opusReceiver,
+		conn:         conn,
+	}
+}
+
+type audioReceiverImpl struct {
+	logger       log.Logger
+	opusReceiver OpusFrameReceiver
+	conn         Conn
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	// done is closed once the receive goroutine exited
+	done chan struct{}
+}
+
+func (r *audioReceiverImpl) Open() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+	go r.receive(ctx, r.done)
+}
+
+func (r *audioReceiverImpl) receive(ctx context.Context, done chan<- struct{}) {
+	defer close(done)
+	defer r.logger.Debug("exiting audio receiver goroutine...")
+	failures := 0
+	for {
+		packet, err := r.conn.UDP().ReadPacket(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			failures++
+			if failures >= maxReadPacketFailures {
+				r.logger.Errorf("failed to read voice packet %d times in a row, stopping audio receiver. error: %s", failures, err)
+				return
+			}
+			r.logger.Error("failed to read voice packet. error: ", err)
+
+			timer := time.NewTimer(readPacketBackoff << (failures - 1))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			continue
+		}
+		failures = 0
+
+		// discord sends the speaking opcode before the first packet of a user, so packets of unknown users can be ignored
+		userID := r.conn.UserIDBySSRC(packet.SSRC)
+		if userID == 0 {
+			continue
+		}
+		if err = r.opusReceiver.ReceiveOpusFrame(userID, packet); err != nil {
+			r.logger.Error("failed to handle opus frame. error: ", err)
+		}
+	}
+}
+
+func (r *audioReceiverImpl) CleanupUser(userID snowflake.ID) {
+	r.opusReceiver.CleanupUser(userID)
+}
+
+func (r *audioReceiverImpl) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel != nil {
+		r.cancel()
+		// a Packet which is handled right now must not reach the OpusFrameReceiver after it was closed
+		<-r.done
+		r.cancel, r.done = nil, nil
+	}
+	r.opusReceiver.Close()
+}
@@ -0,0 +1,153 @@
+package voice
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/log"
+)
+
+// OpusFrameProvider provides the opus frames which are sent to discord. Each frame has to contain OpusFrameDuration of audio.
+type OpusFrameProvider interface {
+	// ProvideOpusFrame is called every OpusFrameDuration and returns the next opus frame.
+	// A nil frame means there is nothing to send right now. io.EOF means the provider has no more frames.
+	ProvideOpusFrame() ([]byte, error)
+
+	// Close closes the OpusFrameProvider.
+	Close()
+}
+
+// AudioSender sends the opus frames of an OpusFrameProvider to discord and updates the speaking state of the Conn.
+type AudioSender interface {
+	// Open starts sending opus frames in a new goroutine.
+	Open()
+
+	// Close stops sending opus frames. The OpusFrameProvider is not closed.
+	Close()
+}
+
+// silenceFrames is the number of SilenceAudioFrame(s) sent after the OpusFrameProvider stopped providing frames.
+const silenceFrames = 5
+
+var _ AudioSender = (*audioSenderImpl)(nil)
+
+// NewAudioSender creates a new AudioSender which sends the opus frames of the OpusFrameProvider via the given Conn.
+func NewAudioSender(logger log.Logger, opusProvider OpusFrameProvider, conn Conn) AudioSender {
+	return &audioSenderImpl{
+		logger:       logger,
+		opusProvider: opusProvider,
+		conn:         conn,
+	}
+}
+
+type audioSenderImpl struct {
+	logger       log.Logger
+	opusProvider OpusFrameProvider
+	conn         Conn
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+func (s *audioSenderImpl) Open() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	go s.send(ctx)
+}
+
+func (s *audioSenderImpl) send(ctx context.Context) {
+	defer s.logger.Debug("exiting audio sender goroutine...")
+
+	ticker := time.NewTicker(OpusFrameDuration)
+	defer ticker.Stop()
+
+	var (
+		speaking    bool
+		silenceSent int
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		frame, err := s.opusProvider.ProvideOpusFrame()
+		if err == io.EOF {
+			s.stopSpeaking(speaking, silenceSent)
+			return
+		}
+		if err != nil {
+			s.logger.Error("failed to provide opus frame. error: ", err)
+			frame = nil
+		}
+
+		if frame == nil {
+			if !speaking {
+				continue
+			}
+			// avoid unintended opus interpolation by sending silence first
+			if silenceSent < silenceFrames {
+				s.write(SilenceAudioFrame)
+				silenceSent++
+				continue
+			}
+			s.setSpeaking(SpeakingFlagNone)
+			speaking = false
+			continue
+		}
+
+		if !speaking {
+			s.setSpeaking(SpeakingFlagMicrophone)
+			speaking = true
+		}
+		silenceSent = 0
+		s.write(frame)
+	}
+}
+
+func (s *audioSenderImpl) stopSpeaking(speaking bool, silenceSent int) {
+	if !speaking {
+		return
+	}
+	for ; silenceSent < silenceFrames; silenceSent++ {
+		s.write(SilenceAudioFrame)
+	}
+	s.setSpeaking(SpeakingFlagNone)
+}
+
+func (s *audioSenderImpl) write(frame []byte) {
+	if _, err := s.conn.UDP().Write(frame); err != nil {
+		// audio is dropped until the connection is ready
+		if errors.Is(err, ErrUDPConnNotOpen) || errors.Is(err, ErrNoSecretKey) {
+			s.logger.Trace("dropping opus frame, voice connection not ready")
+			return
+		}
+		s.logger.Error("failed to write opus frame. error: ", err)
+	}
+}
+
+func (s *audioSenderImpl) setSpeaking(flags SpeakingFlags) {
+	ctx, cancel := context.WithTimeout(context.Background(), OpusFrameDuration)
+	defer cancel()
+	if err := s.conn.SetSpeaking(ctx, flags); err != nil {
+		s.logger.Debug("failed to set speaking. error: ", err)
+	}
+}
+
+func (s *audioSenderImpl) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+}
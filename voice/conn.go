@@ -0,0 +1,351 @@
+package voice
+
+import (
+	"context"
+	"sync"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/log"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// StateUpdateFunc is used to send a voice state update for the given guild via the main gateway. A nil channelID leaves the voice channel.
+type StateUpdateFunc func(ctx context.Context, guildID snowflake.ID, channelID *snowflake.ID, selfMute bool, selfDeaf bool) error
+
+// Conn is a connection to a voice channel of a guild.
+// It connects to the voice Gateway and the UDPConn once discord sent the VOICE_STATE_UPDATE and VOICE_SERVER_UPDATE events for it.
+type Conn interface {
+	// Logger returns the logger used by the Conn.
+	Logger() log.Logger
+
+	// Gateway returns the voice Gateway of the Conn.
+	Gateway() Gateway
+
+	// UDP returns the UDPConn opus frames can be written to.
+	UDP() UDPConn
+
+	// GuildID returns the ID of the guild the Conn belongs to.
+	GuildID() snowflake.ID
+
+	// ChannelID returns the ID of the voice channel the Conn is connected to or nil.
+	ChannelID() *snowflake.ID
+
+	// SetSpeaking sends the given SpeakingFlags to discord. This needs to be called before sending audio.
+	SetSpeaking(ctx context.Context, flags SpeakingFlags) error
+
+	// UserIDBySSRC returns the ID of the user who sends audio with the given SSRC or 0 if the user is unknown.
+	UserIDBySSRC(ssrc uint32) snowflake.ID
+
+	// UserVoiceState returns the cached discord.VoiceState of the given user in the guild of the Conn. See WithConnVoiceStateCache.
+	UserVoiceState(userID snowflake.ID) (discord.VoiceState, bool)
+
+	// SetOpusFrameReceiver starts receiving audio and passes it to the given OpusFrameReceiver. A nil OpusFrameReceiver stops receiving audio.
+	SetOpusFrameReceiver(receiver OpusFrameReceiver)
+
+	// SetOpusFrameProvider starts sending the audio of the given OpusFrameProvider. A nil OpusFrameProvider stops sending audio.
+	// The previous OpusFrameProvider is not closed.
+	SetOpusFrameProvider(provider OpusFrameProvider)
+
+	// Open joins the given voice channel and blocks until the UDPConn is ready to send audio or the context is cancelled.
+	Open(ctx context.Context, channelID snowflake.ID, selfMute bool, selfDeaf bool) error
+
+	// Close leaves the voice channel, stops sending & receiving audio, closes the Gateway and UDPConn and removes the Conn from its Manager.
+	Close(ctx context.Context)
+
+	// HandleVoiceStateUpdate should be called with all voice states of the guild of the Conn.
+	HandleVoiceStateUpdate(update discord.VoiceState)
+
+	// HandleVoiceServerUpdate should be called with the voice server of the guild of the Conn.
+	HandleVoiceServerUpdate(update discord.VoiceServerUpdate)
+}
+
+var _ Conn = (*connImpl)(nil)
+
+// NewConn creates a new Conn for the given guild and user. The removeConnFunc is called when the Conn was closed.
+func NewConn(guildID snowflake.ID, userID snowflake.ID, stateUpdateFunc StateUpdateFunc, removeConnFunc func(), opts ...ConnConfigOpt) Conn {
+	config := DefaultConnConfig()
+	config.Apply(opts)
+
+	c := &connImpl{
+		config:          *config,
+		stateUpdateFunc: stateUpdateFunc,
+		removeConnFunc:  removeConnFunc,
+		state: State{
+			GuildID: guildID,
+			UserID:  userID,
+		},
+		ssrcs: map[uint32]snowflake.ID{},
+	}
+	c.gateway = config.GatewayCreateFunc(c.handleGatewayMessage, c.handleGatewayClose, config.GatewayConfigOpts...)
+	c.udp = config.UDPConnCreateFunc(config.UDPConnConfigOpts...)
+	return c
+}
+
+type connImpl struct {
+	config          ConnConfig
+	stateUpdateFunc StateUpdateFunc
+	removeConnFunc  func()
+
+	gateway Gateway
+	udp     UDPConn
+
+	mu sync.Mutex
+	// state is the voice state and voice server discord sent us
+	state State
+	// serverUpdated is true when a voice server was received which the Gateway has not connected to yet
+	serverUpdated bool
+	// ready is closed once the UDPConn received its secret key
+	ready chan struct{}
+
+	ssrcs         map[uint32]snowflake.ID
+	audioReceiver AudioReceiver
+	audioSender   AudioSender
+}
+
+func (c *connImpl) Logger() log.Logger {
+	return c.config.Logger
+}
+
+func (c *connImpl) Gateway() Gateway {
+	return c.gateway
+}
+
+func (c *connImpl) UDP() UDPConn {
+	return c.udp
+}
+
+func (c *connImpl) GuildID() snowflake.ID {
+	return c.state.GuildID
+}
+
+func (c *connImpl) ChannelID() *snowflake.ID {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.state.ChannelID
+}
+
+func (c *connImpl) SetSpeaking(ctx context.Context, flags SpeakingFlags) error {
+	return c.gateway.Send(ctx, OpcodeSpeaking, GatewayMessageDataSpeaking{
+		Speaking: flags,
+		SSRC:     c.gateway.SSRC(),
+	})
+}
+
+func (c *connImpl) UserIDBySSRC(ssrc uint32) snowflake.ID {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ssrcs[ssrc]
+}
+
+func (c *connImpl) UserVoiceState(userID snowflake.ID) (discord.VoiceState, bool) {
+	if c.config.VoiceStateCache == nil {
+		return discord.VoiceState{}, false
+	}
+	return c.config.VoiceStateCache.Get(c.state.GuildID, userID)
+}
+
+func (c *connImpl) SetOpusFrameReceiver(receiver OpusFrameReceiver) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.audioReceiver != nil {
+		c.audioReceiver.Close()
+		c.audioReceiver = nil
+	}
+	if receiver == nil {
+		return
+	}
+	c.audioReceiver = NewAudioReceiver(c.Logger(), receiver, c)
+	c.audioReceiver.Open()
+}
+
+func (c *connImpl) SetOpusFrameProvider(provider OpusFrameProvider) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.audioSender != nil {
+		c.audioSender.Close()
+		c.audioSender = nil
+	}
+	if provider == nil {
+		return
+	}
+	c.audioSender = NewAudioSender(c.Logger(), provider, c)
+	c.audioSender.Open()
+}
+
+// removeUser forgets the SSRC of the given user and cleans up its received audio.
+func (c *connImpl) removeUser(userID snowflake.ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for ssrc, ssrcUserID := range c.ssrcs {
+		if ssrcUserID == userID {
+			delete(c.ssrcs, ssrc)
+		}
+	}
+	if c.audioReceiver != nil {
+		c.audioReceiver.CleanupUser(userID)
+	}
+}
+
+func (c *connImpl) Open(ctx context.Context, channelID snowflake.ID, selfMute bool, selfDeaf bool) error {
+	c.Logger().Debugf("opening voice connection to channel: %s", channelID)
+
+	ready := make(chan struct{})
+	c.mu.Lock()
+	c.ready = ready
+	c.mu.Unlock()
+
+	if err := c.stateUpdateFunc(ctx, c.state.GuildID, &channelID, selfMute, selfDeaf); err != nil {
+		return err
+	}
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		c.Close(context.TODO())
+		return ctx.Err()
+	}
+}
+
+func (c *connImpl) Close(ctx context.Context) {
+	c.Logger().Debug("closing voice connection")
+	if err := c.stateUpdateFunc(ctx, c.state.GuildID, nil, false, false); err != nil {
+		c.Logger().Error("failed to leave voice channel. error: ", err)
+	}
+	c.close()
+	c.removeConnFunc()
+}
+
+// close stops sending & receiving audio, closes the Gateway and UDPConn and resets the voice session.
+func (c *connImpl) close() {
+	c.SetOpusFrameProvider(nil)
+	c.SetOpusFrameReceiver(nil)
+
+	c.mu.Lock()
+	c.state.ChannelID = nil
+	c.state.SessionID = ""
+	c.state.Token = ""
+	c.state.Endpoint = ""
+	c.serverUpdated = false
+	c.ssrcs = map[uint32]snowflake.ID{}
+	c.mu.Unlock()
+
+	c.gateway.Close()
+	if err := c.udp.Close(); err != nil {
+		c.Logger().Debug("failed to close voice udp connection. error: ", err)
+	}
+}
+
+func (c *connImpl) HandleVoiceStateUpdate(update discord.VoiceState) {
+	if update.UserID != c.state.UserID {
+		c.mu.Lock()
+		channelID := c.state.ChannelID
+		c.mu.Unlock()
+		if channelID == nil || update.ChannelID == nil || *update.ChannelID != *channelID {
+			c.removeUser(update.UserID)
+		}
+		return
+	}
+
+	if update.ChannelID == nil {
+		c.close()
+		return
+	}
+
+	c.mu.Lock()
+	c.state.ChannelID = update.ChannelID
+	c.state.SessionID = update.SessionID
+	c.mu.Unlock()
+	c.openGateway()
+}
+
+func (c *connImpl) HandleVoiceServerUpdate(update discord.VoiceServerUpdate) {
+	// a nil endpoint means the voice server is gone, discord sends a new VOICE_SERVER_UPDATE once a new one is available
+	if update.Endpoint == nil {
+		return
+	}
+
+	c.mu.Lock()
+	c.state.Token = update.Token
+	c.state.Endpoint = *update.Endpoint
+	c.serverUpdated = true
+	c.mu.Unlock()
+	c.openGateway()
+}
+
+// openGateway (re)connects the Gateway to a new voice server once the session id is known.
+func (c *connImpl) openGateway() {
+	c.mu.Lock()
+	if !c.serverUpdated || c.state.SessionID == "" {
+		c.mu.Unlock()
+		return
+	}
+	c.serverUpdated = false
+	state := c.state
+	c.mu.Unlock()
+
+	// don't block the main gateway while connecting
+	go func() {
+		c.gateway.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.ConnectTimeout)
+		defer cancel()
+		if err := c.gateway.Open(ctx, state); err != nil {
+			c.Logger().Error("failed to open voice gateway. error: ", err)
+		}
+	}()
+}
+
+func (c *connImpl) handleGatewayMessage(opcode Opcode, data GatewayMessageData) {
+	switch d := data.(type) {
+	case GatewayMessageDataReady:
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.ConnectTimeout)
+		defer cancel()
+
+		address, port, err := c.udp.Open(ctx, d.IP, d.Port, d.SSRC)
+		if err != nil {
+			c.Logger().Error("failed to open voice udp connection. error: ", err)
+			c.gateway.Close()
+			return
+		}
+		if err = c.gateway.Send(ctx, OpcodeSelectProtocol, GatewayMessageDataSelectProtocol{
+			Protocol: ProtocolUDP,
+			Data: GatewayMessageDataSelectProtocolData{
+				Address: address,
+				Port:    port,
+				Mode:    EncryptionModeXSalsa20Poly1305,
+			},
+		}); err != nil {
+			c.Logger().Error("failed to send voice select protocol. error: ", err)
+		}
+
+	case GatewayMessageDataSessionDescription:
+		if d.Mode != EncryptionModeXSalsa20Poly1305 {
+			c.Logger().Errorf("unsupported voice encryption mode: %s", d.Mode)
+			return
+		}
+		c.udp.SetSecretKey(d.SecretKey)
+
+		c.mu.Lock()
+		if c.ready != nil {
+			close(c.ready)
+			c.ready = nil
+		}
+		c.mu.Unlock()
+
+	case GatewayMessageDataSpeaking:
+		c.mu.Lock()
+		c.ssrcs[d.SSRC] = d.UserID
+		c.mu.Unlock()
+
+	case GatewayMessageDataClientDisconnect:
+		c.removeUser(d.UserID)
+	}
+}
+
+func (c *connImpl) handleGatewayClose(_ Gateway, err error) {
+	c.Logger().Error("voice gateway closed. error: ", err)
+	if err = c.udp.Close(); err != nil {
+		c.Logger().Debug("failed to close voice udp connection. error: ", err)
+	}
+}
@@ -0,0 +1,101 @@
+package voice
+
+import (
+	"time"
+
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/log"
+)
+
+// DefaultConnConfig returns a ConnConfig with sensible defaults.
+func DefaultConnConfig() *ConnConfig {
+	return &ConnConfig{
+		Logger:            log.Default(),
+		GatewayCreateFunc: NewGateway,
+		UDPConnCreateFunc: NewUDPConn,
+		ConnectTimeout:    10 * time.Second,
+	}
+}
+
+// ConnConfig lets you configure your Conn instance.
+type ConnConfig struct {
+	Logger log.Logger
+
+	GatewayCreateFunc GatewayCreateFunc
+	GatewayConfigOpts []GatewayConfigOpt
+
+	UDPConnCreateFunc UDPConnCreateFunc
+	UDPConnConfigOpts []UDPConnConfigOpt
+
+	// ConnectTimeout is the timeout used for connecting to the voice gateway and the UDP IP discovery after discord sent the voice server.
+	ConnectTimeout time.Duration
+
+	// VoiceStateCache is used to look up the discord.VoiceState of users sending audio.
+	VoiceStateCache cache.GroupedCache[discord.VoiceState]
+}
+
+// GatewayCreateFunc is used to create a new Gateway for a Conn.
+type GatewayCreateFunc func(eventHandlerFunc EventHandlerFunc, closeHandlerFunc CloseHandlerFunc, opts ...GatewayConfigOpt) Gateway
+
+// UDPConnCreateFunc is used to create a new UDPConn for a Conn.
+type UDPConnCreateFunc func(opts ...UDPConnConfigOpt) UDPConn
+
+// ConnConfigOpt is a type alias for a function that takes a ConnConfig and is used to configure your Conn.
+type ConnConfigOpt func(config *ConnConfig)
+
+// Apply applies the given ConnConfigOpt(s) to the ConnConfig
+func (c *ConnConfig) Apply(opts []ConnConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithConnLogger sets the Logger for the Conn.
+func WithConnLogger(logger log.Logger) ConnConfigOpt {
+	return func(config *ConnConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithConnGatewayCreateFunc sets the GatewayCreateFunc for the Conn.
+func WithConnGatewayCreateFunc(gatewayCreateFunc GatewayCreateFunc) ConnConfigOpt {
+	return func(config *ConnConfig) {
+		config.GatewayCreateFunc = gatewayCreateFunc
+	}
+}
+
+// WithConnGatewayConfigOpts applies the given GatewayConfigOpt(s) to the Gateway of the Conn.
+func WithConnGatewayConfigOpts(opts ...GatewayConfigOpt) ConnConfigOpt {
+	return func(config *ConnConfig) {
+		config.GatewayConfigOpts = append(config.GatewayConfigOpts, opts...)
+	}
+}
+
+// WithConnUDPConnCreateFunc sets the UDPConnCreateFunc for the Conn.
+func WithConnUDPConnCreateFunc(udpConnCreateFunc UDPConnCreateFunc) ConnConfigOpt {
+	return func(config *ConnConfig) {
+		config.UDPConnCreateFunc = udpConnCreateFunc
+	}
+}
+
+// WithConnUDPConnConfigOpts applies the given UDPConnConfigOpt(s) to the UDPConn of the Conn.
+func WithConnUDPConnConfigOpts(opts ...UDPConnConfigOpt) ConnConfigOpt {
+	return func(config *ConnConfig) {
+		config.UDPConnConfigOpts = append(config.UDPConnConfigOpts, opts...)
+	}
+}
+
+// WithConnConnectTimeout sets the timeout used for connecting to the voice gateway and the UDP IP discovery.
+func WithConnConnectTimeout(timeout time.Duration) ConnConfigOpt {
+	return func(config *ConnConfig) {
+		config.ConnectTimeout = timeout
+	}
+}
+
+// WithConnVoiceStateCache sets the cache used to look up the discord.VoiceState of users sending audio.
+func WithConnVoiceStateCache(voiceStateCache cache.GroupedCache[discord.VoiceState]) ConnConfigOpt {
+	return func(config *ConnConfig) {
+		config.VoiceStateCache = voiceStateCache
+	}
+}
@@ -0,0 +1,80 @@
+package voice
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+var dca1Magic = []byte("DCA1")
+
+var _ OpusFrameProvider = (*dcaReader)(nil)
+
+// NewDCAReader creates a new OpusFrameProvider which reads the opus frames of a DCA stream.
+// Both DCA1 streams with a metadata header and legacy DCA0 streams without one are supported.
+// The reader is closed on Close if it implements io.Closer.
+func NewDCAReader(r io.Reader) OpusFrameProvider {
+	return &dcaReader{
+		r:      bufio.NewReader(r),
+		closer: r,
+	}
+}
+
+type dcaReader struct {
+	r          *bufio.Reader
+	closer     io.Reader
+	headerRead bool
+}
+
+func (d *dcaReader) ProvideOpusFrame() ([]byte, error) {
+	if !d.headerRead {
+		if err := d.skipHeader(); err != nil {
+			return nil, err
+		}
+		d.headerRead = true
+	}
+
+	var frameSize int16
+	if err := binary.Read(d.r, binary.LittleEndian, &frameSize); err != nil {
+		return nil, err
+	}
+	if frameSize <= 0 {
+		return nil, fmt.Errorf("invalid dca frame size: %d", frameSize)
+	}
+
+	frame := make([]byte, frameSize)
+	if _, err := io.ReadFull(d.r, frame); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return frame, nil
+}
+
+// skipHeader skips the DCA1 magic and JSON metadata. DCA0 streams start with the first frame.
+func (d *dcaReader) skipHeader() error {
+	magic, err := d.r.Peek(len(dca1Magic))
+	if err != nil || !bytes.Equal(magic, dca1Magic) {
+		// let reading the first frame report the error
+		return nil
+	}
+	if _, err = d.r.Discard(len(dca1Magic)); err != nil {
+		return err
+	}
+
+	var metadataSize int32
+	if err = binary.Read(d.r, binary.LittleEndian, &metadataSize); err != nil {
+		return unexpectedEOF(err)
+	}
+	if metadataSize < 0 {
+		return fmt.Errorf("invalid dca metadata size: %d", metadataSize)
+	}
+	if _, err = d.r.Discard(int(metadataSize)); err != nil {
+		return unexpectedEOF(err)
+	}
+	return nil
+}
+
+func (d *dcaReader) Close() {
+	closeReader(d.closer)
+}
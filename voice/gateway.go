@@ -0,0 +1,434 @@
+package voice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/log"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/gorilla/websocket"
+)
+
+var (
+	// ErrGatewayNotConnected is returned when sending a message to a Gateway which is not connected.
+	ErrGatewayNotConnected = errors.New("voice gateway not connected")
+
+	// ErrGatewayAlreadyConnected is returned when opening a Gateway which is already connected.
+	ErrGatewayAlreadyConnected = errors.New("voice gateway already connected")
+)
+
+// State is the information discord sends via the VOICE_STATE_UPDATE and VOICE_SERVER_UPDATE events which is needed to connect to the voice gateway.
+type State struct {
+	GuildID   snowflake.ID
+	UserID    snowflake.ID
+	ChannelID *snowflake.ID
+	SessionID string
+	Token     string
+	Endpoint  string
+}
+
+// EventHandlerFunc is called for every message received from the voice gateway.
+type EventHandlerFunc func(opcode Opcode, data GatewayMessageData)
+
+// CloseHandlerFunc is called when the Gateway was closed by discord and is not going to reconnect.
+type CloseHandlerFunc func(gateway Gateway, err error)
+
+// Gateway is a connection to the discord voice gateway. It identifies, keeps the connection alive with heartbeats and resumes the session when the connection is lost.
+type Gateway interface {
+	// Logger returns the logger used by the Gateway.
+	Logger() log.Logger
+
+	// SSRC returns the SSRC discord assigned to this voice session.
+	SSRC() uint32
+
+	// Latency returns the time it took discord to acknowledge the last heartbeat.
+	Latency() time.Duration
+
+	// Open connects to the voice gateway of the given State and identifies.
+	Open(ctx context.Context, state State) error
+
+	// Close closes the connection to the voice gateway.
+	Close()
+
+	// CloseWithCode closes the connection to the voice gateway with the given close code and message.
+	CloseWithCode(code int, message string)
+
+	// Send sends a message to the voice gateway.
+	Send(ctx context.Context, opcode Opcode, data GatewayMessageData) error
+}
+
+var _ Gateway = (*gatewayImpl)(nil)
+
+// NewGateway creates a new Gateway with the provided eventHandlerFunc, closeHandlerFunc and GatewayConfigOpt(s).
+func NewGateway(eventHandlerFunc EventHandlerFunc, closeHandlerFunc CloseHandlerFunc, opts ...GatewayConfigOpt) Gateway {
+	config := DefaultGatewayConfig()
+	config.Apply(opts)
+
+	return &gatewayImpl{
+		config:           *config,
+		eventHandlerFunc: eventHandlerFunc,
+		closeHandlerFunc: closeHandlerFunc,
+	}
+}
+
+type gatewayImpl struct {
+	config           GatewayConfig
+	eventHandlerFunc EventHandlerFunc
+	closeHandlerFunc CloseHandlerFunc
+
+	state    State
+	ssrc     uint32
+	resuming bool
+
+	conn            *websocket.Conn
+	connMu          sync.Mutex
+	heartbeatDone   chan struct{}
+	reconnectCtx    context.Context
+	reconnectCancel context.CancelFunc
+
+	heartbeatInterval     time.Duration
+	lastNonce             int64
+	lastHeartbeatSent     time.Time
+	lastHeartbeatReceived time.Time
+}
+
+func (g *gatewayImpl) Logger() log.Logger {
+	return g.config.Logger
+}
+
+func (g *gatewayImpl) SSRC() uint32 {
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+	return g.ssrc
+}
+
+func (g *gatewayImpl) Latency() time.Duration {
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+	return g.lastHeartbeatReceived.Sub(g.lastHeartbeatSent)
+}
+
+func (g *gatewayImpl) Open(ctx context.Context, state State) error {
+	g.connMu.Lock()
+	g.state = state
+	g.connMu.Unlock()
+	return g.open(ctx, false)
+}
+
+func (g *gatewayImpl) open(ctx context.Context, resume bool) error {
+	g.Logger().Debug("opening voice gateway connection")
+
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+	if g.conn != nil {
+		return ErrGatewayAlreadyConnected
+	}
+	// a reconnect which was cancelled by closing the Gateway while it waited for the lock must not connect anymore
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	gatewayURL := fmt.Sprintf("wss://%s/?v=%d", g.state.Endpoint, Version)
+	conn, rs, err := g.config.Dialer.DialContext(ctx, gatewayURL, nil)
+	if err != nil {
+		body := "null"
+		if rs != nil && rs.Body != nil {
+			defer func() {
+				_ = rs.Body.Close()
+			}()
+			if rawBody, bErr := io.ReadAll(rs.Body); bErr == nil {
+				body = string(rawBody)
+			}
+		}
+		g.Logger().Errorf("error connecting to the voice gateway. url: %s, error: %s, body: %s", gatewayURL, err, body)
+		return err
+	}
+
+	conn.SetCloseHandler(func(code int, text string) error {
+		return nil
+	})
+
+	g.conn = conn
+	g.resuming = resume
+	go g.listen(conn)
+	return nil
+}
+
+func (g *gatewayImpl) Close() {
+	g.CloseWithCode(websocket.CloseNormalClosure, "Shutting down")
+}
+
+func (g *gatewayImpl) CloseWithCode(code int, message string) {
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+	g.closeWithCode(code, message)
+}
+
+// closeWithCode closes the connection and cancels a pending reconnect. connMu must be held.
+func (g *gatewayImpl) closeWithCode(code int, message string) {
+	if g.reconnectCancel != nil {
+		g.reconnectCancel()
+		g.reconnectCtx, g.reconnectCancel = nil, nil
+	}
+	if g.heartbeatDone != nil {
+		g.Logger().Debug("closing voice heartbeat goroutine...")
+		close(g.heartbeatDone)
+		g.heartbeatDone = nil
+	}
+	if g.conn != nil {
+		g.Logger().Debugf("closing voice gateway connection with code: %d, message: %s", code, message)
+		if err := g.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, message)); err != nil && err != websocket.ErrCloseSent {
+			g.Logger().Debug("error writing close code. error: ", err)
+		}
+		_ = g.conn.Close()
+		g.conn = nil
+	}
+}
+
+func (g *gatewayImpl) Send(ctx context.Context, opcode Opcode, data GatewayMessageData) error {
+	rawData, err := json.Marshal(GatewayMessage{
+		Op: opcode,
+		D:  data,
+	})
+	if err != nil {
+		return err
+	}
+	g.Logger().Trace("sending voice gateway command: ", string(rawData))
+
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+	if g.conn == nil {
+		return ErrGatewayNotConnected
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = g.conn.SetWriteDeadline(deadline)
+		defer func() {
+			_ = g.conn.SetWriteDeadline(time.Time{})
+		}()
+	}
+	return g.conn.WriteMessage(websocket.TextMessage, rawData)
+}
+
+// reconnectWithCode closes conn and reconnects in a new goroutine. Closing the Gateway cancels the reconnect.
+// It does nothing if conn is not the current connection.
+func (g *gatewayImpl) reconnectWithCode(conn *websocket.Conn, code int, message string) {
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+	if conn == nil || g.conn != conn {
+		return
+	}
+	g.closeWithCode(code, message)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	g.reconnectCtx, g.reconnectCancel = ctx, cancel
+	go func() {
+		defer func() {
+			g.connMu.Lock()
+			if g.reconnectCtx == ctx {
+				g.reconnectCtx, g.reconnectCancel = nil, nil
+			}
+			g.connMu.Unlock()
+			cancel()
+		}()
+		g.reconnect(ctx)
+	}()
+}
+
+func (g *gatewayImpl) reconnect(ctx context.Context) {
+	var lastErr error
+	for try := 0; ; try++ {
+		delay, ok := g.config.ReconnectStrategy.NextDelay(try)
+		if !ok {
+			err := fmt.Errorf("failed to reconnect voice gateway. reconnect strategy gave up after %d tries: %w", try, lastErr)
+			g.Logger().Error(err)
+			if g.closeHandlerFunc != nil {
+				g.closeHandlerFunc(g, err)
+			}
+			return
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			g.Logger().Debug("voice reconnect cancelled as the gateway was closed")
+			return
+		case <-timer.C:
+		}
+
+		g.Logger().Debug("reconnecting voice gateway...")
+		openCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		lastErr = g.open(openCtx, true)
+		cancel()
+		if lastErr == nil || lastErr == ErrGatewayAlreadyConnected {
+			return
+		}
+		if ctx.Err() != nil {
+			g.Logger().Debug("voice reconnect cancelled as the gateway was closed")
+			return
+		}
+		g.Logger().Error("failed to reconnect voice gateway. error: ", lastErr)
+	}
+}
+
+func (g *gatewayImpl) heartbeat(interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	defer g.Logger().Debug("exiting voice heartbeat goroutine...")
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			g.sendHeartbeat()
+		}
+	}
+}
+
+func (g *gatewayImpl) sendHeartbeat() {
+	g.Logger().Debug("sending voice heartbeat...")
+
+	g.connMu.Lock()
+	conn := g.conn
+	g.connMu.Unlock()
+
+	nonce := time.Now().UnixMilli()
+	ctx, cancel := context.WithTimeout(context.Background(), g.heartbeatInterval)
+	defer cancel()
+	if err := g.Send(ctx, OpcodeHeartbeat, GatewayMessageDataHeartbeat(nonce)); err != nil {
+		if err == ErrGatewayNotConnected {
+			return
+		}
+		g.Logger().Error("failed to send voice heartbeat. error: ", err)
+		g.reconnectWithCode(conn, websocket.CloseServiceRestart, "heartbeat timeout")
+		return
+	}
+	g.connMu.Lock()
+	g.lastNonce = nonce
+	g.lastHeartbeatSent = time.Now().UTC()
+	g.connMu.Unlock()
+}
+
+func (g *gatewayImpl) identify() {
+	g.Logger().Debug("sending voice Identify command...")
+	g.connMu.Lock()
+	identify := GatewayMessageDataIdentify{
+		GuildID:   g.state.GuildID,
+		UserID:    g.state.UserID,
+		SessionID: g.state.SessionID,
+		Token:     g.state.Token,
+	}
+	g.connMu.Unlock()
+
+	if err := g.Send(context.TODO(), OpcodeIdentify, identify); err != nil {
+		g.Logger().Error("error sending voice Identify command. error: ", err)
+	}
+}
+
+func (g *gatewayImpl) resume() {
+	g.Logger().Debug("sending voice Resume command...")
+	g.connMu.Lock()
+	resume := GatewayMessageDataResume{
+		GuildID:   g.state.GuildID,
+		SessionID: g.state.SessionID,
+		Token:     g.state.Token,
+	}
+	g.connMu.Unlock()
+
+	if err := g.Send(context.TODO(), OpcodeResume, resume); err != nil {
+		g.Logger().Error("error sending voice Resume command. error: ", err)
+	}
+}
+
+func (g *gatewayImpl) listen(conn *websocket.Conn) {
+	defer g.Logger().Debug("exiting voice listen goroutine...")
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			g.connMu.Lock()
+			sameConnection := g.conn == conn
+			g.connMu.Unlock()
+
+			// if sameConnection is false, it means the connection has been closed by the user, and we can just exit
+			if !sameConnection {
+				return
+			}
+
+			reconnect := true
+			if closeError, ok := err.(*websocket.CloseError); ok {
+				closeCode := CloseCode(closeError.Code)
+				reconnect = closeCode.ShouldResume()
+				g.Logger().Debugf("voice gateway close received, reconnect: %t, code: %d, error: %s", g.config.AutoReconnect && reconnect, closeError.Code, closeError.Text)
+			} else if errors.Is(err, net.ErrClosed) {
+				// we closed the connection ourselves. Don't try to reconnect here
+				reconnect = false
+			} else {
+				g.Logger().Debug("failed to read next message from voice gateway. error: ", err)
+			}
+
+			if g.config.AutoReconnect && reconnect {
+				g.reconnectWithCode(conn, websocket.CloseServiceRestart, "reconnecting")
+			} else {
+				g.Close()
+				if g.closeHandlerFunc != nil {
+					go g.closeHandlerFunc(g, err)
+				}
+			}
+			return
+		}
+
+		var message GatewayMessage
+		if err = json.Unmarshal(data, &message); err != nil {
+			g.Logger().Error("error while parsing voice gateway message. error: ", err)
+			continue
+		}
+		g.Logger().Trace("received voice gateway message: ", string(data))
+
+		switch d := message.D.(type) {
+		case GatewayMessageDataHello:
+			interval := time.Duration(d.HeartbeatInterval * float64(time.Millisecond))
+			done := make(chan struct{})
+
+			g.connMu.Lock()
+			resuming := g.resuming
+			g.heartbeatInterval = interval
+			g.lastHeartbeatReceived = time.Now().UTC()
+			if g.heartbeatDone != nil {
+				close(g.heartbeatDone)
+			}
+			g.heartbeatDone = done
+			g.connMu.Unlock()
+
+			go g.heartbeat(interval, done)
+			if resuming {
+				g.resume()
+			} else {
+				g.identify()
+			}
+
+		case GatewayMessageDataReady:
+			g.connMu.Lock()
+			g.ssrc = d.SSRC
+			g.connMu.Unlock()
+
+		case GatewayMessageDataHeartbeatACK:
+			g.connMu.Lock()
+			if int64(d) == g.lastNonce {
+				g.lastHeartbeatReceived = time.Now().UTC()
+			}
+			g.connMu.Unlock()
+		}
+
+		if g.eventHandlerFunc != nil {
+			g.eventHandlerFunc(message.Op, message.D)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package voice
+
+import (
+	"time"
+
+	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/log"
+	"github.com/gorilla/websocket"
+)
+
+// DefaultGatewayConfig returns a GatewayConfig with sensible defaults.
+func DefaultGatewayConfig() *GatewayConfig {
+	return &GatewayConfig{
+		Logger:        log.Default(),
+		Dialer:        websocket.DefaultDialer,
+		AutoReconnect: true,
+	}
+}
+
+// GatewayConfig lets you configure your Gateway instance.
+type GatewayConfig struct {
+	Logger            log.Logger
+	Dialer            *websocket.Dialer
+	AutoReconnect     bool
+	ReconnectStrategy gateway.ReconnectStrategy
+}
+
+// GatewayConfigOpt is a type alias for a function that takes a GatewayConfig and is used to configure your Gateway.
+type GatewayConfigOpt func(config *GatewayConfig)
+
+// Apply applies the given GatewayConfigOpt(s) to the GatewayConfig
+func (c *GatewayConfig) Apply(opts []GatewayConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.ReconnectStrategy == nil {
+		c.ReconnectStrategy = gateway.NewExponentialBackoffReconnectStrategy(time.Second, 30*time.Second, 5)
+	}
+}
+
+// WithGatewayLogger sets the Logger for the Gateway.
+func WithGatewayLogger(logger log.Logger) GatewayConfigOpt {
+	return func(config *GatewayConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithGatewayDialer sets the websocket.Dialer for the Gateway.
+func WithGatewayDialer(dialer *websocket.Dialer) GatewayConfigOpt {
+	return func(config *GatewayConfig) {
+		config.Dialer = dialer
+	}
+}
+
+// WithGatewayAutoReconnect sets whether the Gateway should automatically resume the voice session after the connection was lost.
+func WithGatewayAutoReconnect(autoReconnect bool) GatewayConfigOpt {
+	return func(config *GatewayConfig) {
+		config.AutoReconnect = autoReconnect
+	}
+}
+
+// WithGatewayReconnectStrategy sets the gateway.ReconnectStrategy which decides how long the Gateway waits between reconnect tries and when it gives up.
+func WithGatewayReconnectStrategy(reconnectStrategy gateway.ReconnectStrategy) GatewayConfigOpt {
+	return func(config *GatewayConfig) {
+		config.ReconnectStrategy = reconnectStrategy
+	}
+}
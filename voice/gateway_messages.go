@@ -0,0 +1,254 @@
+package voice
+
+import (
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// Opcode are opcodes used by the voice gateway.
+type Opcode int
+
+// All Opcode(s) used by the voice gateway.
+const (
+	OpcodeIdentify Opcode = iota
+	OpcodeSelectProtocol
+	OpcodeReady
+	OpcodeHeartbeat
+	OpcodeSessionDescription
+	OpcodeSpeaking
+	OpcodeHeartbeatACK
+	OpcodeResume
+	OpcodeHello
+	OpcodeResumed
+	_
+	_
+	_
+	OpcodeClientDisconnect
+)
+
+// CloseCode is a close code sent by the voice gateway.
+type CloseCode int
+
+// All CloseCode(s) sent by the voice gateway.
+const (
+	CloseCodeUnknownOpcode CloseCode = iota + 4001
+	CloseCodeFailedToDecode
+	CloseCodeNotAuthenticated
+	CloseCodeAuthenticationFailed
+	CloseCodeAlreadyAuthenticated
+	CloseCodeSessionNoLongerValid
+	_
+	_
+	CloseCodeSessionTimeout
+	_
+	CloseCodeServerNotFound
+	CloseCodeUnknownProtocol
+	_
+	CloseCodeDisconnected
+	CloseCodeVoiceServerCrashed
+	CloseCodeUnknownEncryptionMode
+)
+
+// ShouldResume returns whether the voice gateway connection can be resumed after being closed with the CloseCode.
+// Connections closed without a voice gateway close code, like websocket.CloseAbnormalClosure or websocket.CloseGoingAway, can be resumed.
+// Voice gateway close codes are fatal except for CloseCodeVoiceServerCrashed.
+func (c CloseCode) ShouldResume() bool {
+	if c < 4000 || c >= 5000 {
+		return true
+	}
+	return c == CloseCodeVoiceServerCrashed
+}
+
+// EncryptionMode is the mode used to encrypt voice packets.
+type EncryptionMode string
+
+// All EncryptionMode(s) supported by disgo.
+const (
+	EncryptionModeXSalsa20Poly1305 EncryptionMode = "xsalsa20_poly1305"
+)
+
+// Protocol is the protocol used to send voice packets.
+type Protocol string
+
+// All Protocol(s) supported by disgo.
+const (
+	ProtocolUDP Protocol = "udp"
+)
+
+// SpeakingFlags are used to tell discord what kind of audio is sent.
+type SpeakingFlags int
+
+// All SpeakingFlags.
+const (
+	SpeakingFlagMicrophone SpeakingFlags = 1 << iota
+	SpeakingFlagSoundshare
+	SpeakingFlagPriority
+	SpeakingFlagNone SpeakingFlags = 0
+)
+
+// GatewayMessage raw voice GatewayMessage type
+type GatewayMessage struct {
+	Op Opcode             `json:"op"`
+	D  GatewayMessageData `json:"d,omitempty"`
+}
+
+func (m *GatewayMessage) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Op Opcode          `json:"op"`
+		D  json.RawMessage `json:"d"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var (
+		messageData GatewayMessageData
+		err         error
+	)
+
+	switch v.Op {
+	case OpcodeReady:
+		var d GatewayMessageDataReady
+		err = json.Unmarshal(v.D, &d)
+		messageData = d
+
+	case OpcodeSessionDescription:
+		var d GatewayMessageDataSessionDescription
+		err = json.Unmarshal(v.D, &d)
+		messageData = d
+
+	case OpcodeSpeaking:
+		var d GatewayMessageDataSpeaking
+		err = json.Unmarshal(v.D, &d)
+		messageData = d
+
+	case OpcodeHeartbeatACK:
+		var d GatewayMessageDataHeartbeatACK
+		err = json.Unmarshal(v.D, &d)
+		messageData = d
+
+	case OpcodeHello:
+		var d GatewayMessageDataHello
+		err = json.Unmarshal(v.D, &d)
+		messageData = d
+
+	case OpcodeResumed:
+		messageData = GatewayMessageDataResumed{}
+
+	case OpcodeClientDisconnect:
+		var d GatewayMessageDataClientDisconnect
+		err = json.Unmarshal(v.D, &d)
+		messageData = d
+
+	default:
+		var d GatewayMessageDataUnknown
+		err = json.Unmarshal(v.D, &d)
+		messageData = d
+	}
+	if err != nil {
+		return err
+	}
+	m.Op = v.Op
+	m.D = messageData
+	return nil
+}
+
+// GatewayMessageData is the data of a GatewayMessage.
+type GatewayMessageData interface {
+	voiceGatewayMessageData()
+}
+
+// GatewayMessageDataIdentify is sent to start a new voice session.
+type GatewayMessageDataIdentify struct {
+	GuildID   snowflake.ID `json:"server_id"`
+	UserID    snowflake.ID `json:"user_id"`
+	SessionID string       `json:"session_id"`
+	Token     string       `json:"token"`
+}
+
+func (GatewayMessageDataIdentify) voiceGatewayMessageData() {}
+
+// GatewayMessageDataSelectProtocol is sent to tell discord which address and EncryptionMode to use for voice packets.
+type GatewayMessageDataSelectProtocol struct {
+	Protocol Protocol                             `json:"protocol"`
+	Data     GatewayMessageDataSelectProtocolData `json:"data"`
+}
+
+func (GatewayMessageDataSelectProtocol) voiceGatewayMessageData() {}
+
+type GatewayMessageDataSelectProtocolData struct {
+	Address string         `json:"address"`
+	Port    int            `json:"port"`
+	Mode    EncryptionMode `json:"mode"`
+}
+
+// GatewayMessageDataReady is received once the voice session was created.
+type GatewayMessageDataReady struct {
+	SSRC  uint32           `json:"ssrc"`
+	IP    string           `json:"ip"`
+	Port  int              `json:"port"`
+	Modes []EncryptionMode `json:"modes"`
+}
+
+func (GatewayMessageDataReady) voiceGatewayMessageData() {}
+
+// GatewayMessageDataHeartbeat is sent to keep the voice gateway connection alive. It contains a nonce which is returned in the GatewayMessageDataHeartbeatACK.
+type GatewayMessageDataHeartbeat int64
+
+func (GatewayMessageDataHeartbeat) voiceGatewayMessageData() {}
+
+// GatewayMessageDataSessionDescription is received after the GatewayMessageDataSelectProtocol and contains the key to encrypt voice packets with.
+type GatewayMessageDataSessionDescription struct {
+	Mode      EncryptionMode `json:"mode"`
+	SecretKey [32]byte       `json:"secret_key"`
+}
+
+func (GatewayMessageDataSessionDescription) voiceGatewayMessageData() {}
+
+// GatewayMessageDataSpeaking is sent before sending audio and received when a user starts speaking.
+type GatewayMessageDataSpeaking struct {
+	Speaking SpeakingFlags `json:"speaking"`
+	Delay    int           `json:"delay"`
+	SSRC     uint32        `json:"ssrc"`
+	UserID   snowflake.ID  `json:"user_id,omitempty"`
+}
+
+func (GatewayMessageDataSpeaking) voiceGatewayMessageData() {}
+
+// GatewayMessageDataHeartbeatACK is received as answer to a GatewayMessageDataHeartbeat and contains its nonce.
+type GatewayMessageDataHeartbeatACK int64
+
+func (GatewayMessageDataHeartbeatACK) voiceGatewayMessageData() {}
+
+// GatewayMessageDataResume is sent to resume an existing voice session.
+type GatewayMessageDataResume struct {
+	GuildID   snowflake.ID `json:"server_id"`
+	SessionID string       `json:"session_id"`
+	Token     string       `json:"token"`
+}
+
+func (GatewayMessageDataResume) voiceGatewayMessageData() {}
+
+// GatewayMessageDataHello is the first message received and contains the heartbeat interval in milliseconds.
+type GatewayMessageDataHello struct {
+	HeartbeatInterval float64 `json:"heartbeat_interval"`
+}
+
+func (GatewayMessageDataHello) voiceGatewayMessageData() {}
+
+// GatewayMessageDataResumed is received once the voice session was resumed.
+type GatewayMessageDataResumed struct{}
+
+func (GatewayMessageDataResumed) voiceGatewayMessageData() {}
+
+// GatewayMessageDataClientDisconnect is received when a user disconnects from the voice channel.
+type GatewayMessageDataClientDisconnect struct {
+	UserID snowflake.ID `json:"user_id"`
+}
+
+func (GatewayMessageDataClientDisconnect) voiceGatewayMessageData() {}
+
+// GatewayMessageDataUnknown is the raw data of all undocumented opcodes.
+type GatewayMessageDataUnknown json.RawMessage
+
+func (GatewayMessageDataUnknown) voiceGatewayMessageData() {}
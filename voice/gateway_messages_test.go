@@ -0,0 +1,17 @@
+package voice
+
+import (
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloseCodeShouldResume(t *testing.T) {
+	for _, code := range []CloseCode{websocket.CloseGoingAway, websocket.CloseAbnormalClosure, websocket.CloseServiceRestart, CloseCodeVoiceServerCrashed} {
+		assert.True(t, code.ShouldResume(), "close code %d", code)
+	}
+	for _, code := range []CloseCode{CloseCodeAuthenticationFailed, CloseCodeSessionNoLongerValid, CloseCodeSessionTimeout, CloseCodeDisconnected, CloseCodeUnknownEncryptionMode} {
+		assert.False(t, code.ShouldResume(), "close code %d", code)
+	}
+}
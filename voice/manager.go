@@ -0,0 +1,128 @@
+package voice
+
+import (
+	"context"
+	"sync"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/log"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// Manager keeps track of all voice Conn(s) of a bot and forwards the VOICE_STATE_UPDATE and VOICE_SERVER_UPDATE events to them.
+type Manager interface {
+	// Logger returns the logger used by the Manager.
+	Logger() log.Logger
+
+	// HandleVoiceStateUpdate forwards the voice state to the Conn of the guild.
+	HandleVoiceStateUpdate(update discord.VoiceState)
+
+	// HandleVoiceServerUpdate forwards the voice server to the Conn of the guild.
+	HandleVoiceServerUpdate(update discord.VoiceServerUpdate)
+
+	// CreateConn returns the Conn for the given guild or creates a new one.
+	CreateConn(guildID snowflake.ID) Conn
+
+	// GetConn returns the Conn for the given guild or nil.
+	GetConn(guildID snowflake.ID) Conn
+
+	// ForEachConn calls the given function for every Conn.
+	ForEachConn(f func(conn Conn))
+
+	// RemoveConn removes the Conn for the given guild without closing it.
+	RemoveConn(guildID snowflake.ID)
+
+	// Close closes all Conn(s).
+	Close(ctx context.Context)
+}
+
+var _ Manager = (*managerImpl)(nil)
+
+// NewManager creates a new Manager for the given user. The StateUpdateFunc is used by the Conn(s) to join and leave voice channels.
+func NewManager(stateUpdateFunc StateUpdateFunc, userID snowflake.ID, opts ...ManagerConfigOpt) Manager {
+	config := DefaultManagerConfig()
+	config.Apply(opts)
+
+	return &managerImpl{
+		config:          *config,
+		stateUpdateFunc: stateUpdateFunc,
+		userID:          userID,
+		conns:           map[snowflake.ID]Conn{},
+	}
+}
+
+type managerImpl struct {
+	config          ManagerConfig
+	stateUpdateFunc StateUpdateFunc
+	userID          snowflake.ID
+
+	conns   map[snowflake.ID]Conn
+	connsMu sync.Mutex
+}
+
+func (m *managerImpl) Logger() log.Logger {
+	return m.config.Logger
+}
+
+func (m *managerImpl) HandleVoiceStateUpdate(update discord.VoiceState) {
+	conn := m.GetConn(update.GuildID)
+	if conn == nil {
+		return
+	}
+	conn.HandleVoiceStateUpdate(update)
+	if update.UserID == m.userID && update.ChannelID == nil {
+		m.RemoveConn(update.GuildID)
+	}
+}
+
+func (m *managerImpl) HandleVoiceServerUpdate(update discord.VoiceServerUpdate) {
+	conn := m.GetConn(update.GuildID)
+	if conn == nil {
+		return
+	}
+	conn.HandleVoiceServerUpdate(update)
+}
+
+func (m *managerImpl) CreateConn(guildID snowflake.ID) Conn {
+	m.connsMu.Lock()
+	defer m.connsMu.Unlock()
+	if conn, ok := m.conns[guildID]; ok {
+		return conn
+	}
+	conn := m.config.ConnCreateFunc(guildID, m.userID, m.stateUpdateFunc, func() {
+		m.RemoveConn(guildID)
+	}, m.config.ConnOpts...)
+	m.conns[guildID] = conn
+	return conn
+}
+
+func (m *managerImpl) GetConn(guildID snowflake.ID) Conn {
+	m.connsMu.Lock()
+	defer m.connsMu.Unlock()
+	return m.conns[guildID]
+}
+
+func (m *managerImpl) ForEachConn(f func(conn Conn)) {
+	m.connsMu.Lock()
+	conns := make([]Conn, 0, len(m.conns))
+	for _, conn := range m.conns {
+		conns = append(conns, conn)
+	}
+	m.connsMu.Unlock()
+
+	for _, conn := range conns {
+		f(conn)
+	}
+}
+
+func (m *managerImpl) RemoveConn(guildID snowflake.ID) {
+	m.connsMu.Lock()
+	defer m.connsMu.Unlock()
+	delete(m.conns, guildID)
+}
+
+func (m *managerImpl) Close(ctx context.Context) {
+	m.ForEachConn(func(conn Conn) {
+		conn.Close(ctx)
+	})
+}
@@ -0,0 +1,55 @@
+package voice
+
+import (
+	"github.com/disgoorg/log"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// DefaultManagerConfig returns a ManagerConfig with sensible defaults.
+func DefaultManagerConfig() *ManagerConfig {
+	return &ManagerConfig{
+		Logger:         log.Default(),
+		ConnCreateFunc: NewConn,
+	}
+}
+
+// ManagerConfig lets you configure your Manager instance.
+type ManagerConfig struct {
+	Logger         log.Logger
+	ConnCreateFunc ConnCreateFunc
+	ConnOpts       []ConnConfigOpt
+}
+
+// ConnCreateFunc is used to create a new Conn for a guild.
+type ConnCreateFunc func(guildID snowflake.ID, userID snowflake.ID, stateUpdateFunc StateUpdateFunc, removeConnFunc func(), opts ...ConnConfigOpt) Conn
+
+// ManagerConfigOpt is a type alias for a function that takes a ManagerConfig and is used to configure your Manager.
+type ManagerConfigOpt func(config *ManagerConfig)
+
+// Apply applies the given ManagerConfigOpt(s) to the ManagerConfig
+func (c *ManagerConfig) Apply(opts []ManagerConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithLogger sets the Logger for the Manager.
+func WithLogger(logger log.Logger) ManagerConfigOpt {
+	return func(config *ManagerConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithConnCreateFunc sets the ConnCreateFunc for the Manager.
+func WithConnCreateFunc(connCreateFunc ConnCreateFunc) ManagerConfigOpt {
+	return func(config *ManagerConfig) {
+		config.ConnCreateFunc = connCreateFunc
+	}
+}
+
+// WithConnConfigOpts applies the given ConnConfigOpt(s) to all Conn(s) created by the Manager.
+func WithConnConfigOpts(opts ...ConnConfigOpt) ManagerConfigOpt {
+	return func(config *ManagerConfig) {
+		config.ConnOpts = append(config.ConnOpts, opts...)
+	}
+}
@@ -0,0 +1,159 @@
+package voice
+
+import (
+	"io"
+	"sort"
+	"sync"
+)
+
+// Mixer is an OpusFrameProvider which plays several OpusFrameProvider(s).
+// Opus frames can't be mixed without decoding them, so the Mixer interleaves the frames of its providers instead:
+// providers with a higher priority are played while they provide frames, providers with the same priority take turns frame by frame.
+// Providers are removed and closed once they return io.EOF.
+type Mixer interface {
+	OpusFrameProvider
+
+	// Add adds the OpusFrameProvider with the given priority.
+	Add(provider OpusFrameProvider, priority int)
+
+	// Remove removes the OpusFrameProvider without closing it.
+	Remove(provider OpusFrameProvider)
+
+	// Skip removes and closes the OpusFrameProvider which provided the last frame.
+	Skip()
+
+	// Pause pauses all OpusFrameProvider(s).
+	Pause()
+
+	// Resume resumes all OpusFrameProvider(s).
+	Resume()
+
+	// Paused returns whether the Mixer is paused.
+	Paused() bool
+}
+
+var _ Mixer = (*mixerImpl)(nil)
+
+// NewMixer creates a new empty Mixer.
+func NewMixer() Mixer {
+	return &mixerImpl{}
+}
+
+type mixerSource struct {
+	provider OpusFrameProvider
+	priority int
+}
+
+type mixerImpl struct {
+	mu sync.Mutex
+	// sources are sorted by priority, the source which provided the last frame is moved to the end of its priority
+	sources []mixerSource
+	last    OpusFrameProvider
+	paused  bool
+}
+
+func (m *mixerImpl) ProvideOpusFrame() ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.paused {
+		return nil, nil
+	}
+
+	for i := 0; i < len(m.sources); {
+		source := m.sources[i]
+		frame, err := source.provider.ProvideOpusFrame()
+		if err == io.EOF {
+			source.provider.Close()
+			m.remove(source.provider)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if frame == nil {
+			i++
+			continue
+		}
+
+		// let the other sources with the same priority take their turn
+		m.remove(source.provider)
+		m.insert(source)
+		m.last = source.provider
+		return frame, nil
+	}
+	return nil, nil
+}
+
+// insert adds the source after all sources with the same or a higher priority. m.mu must be held.
+func (m *mixerImpl) insert(source mixerSource) {
+	i := sort.Search(len(m.sources), func(i int) bool {
+		return m.sources[i].priority < source.priority
+	})
+	m.sources = append(m.sources, mixerSource{})
+	copy(m.sources[i+1:], m.sources[i:])
+	m.sources[i] = source
+}
+
+// remove removes the source of the given provider. m.mu must be held.
+func (m *mixerImpl) remove(provider OpusFrameProvider) {
+	for i, source := range m.sources {
+		if source.provider == provider {
+			m.sources = append(m.sources[:i], m.sources[i+1:]...)
+			break
+		}
+	}
+	if m.last == provider {
+		m.last = nil
+	}
+}
+
+func (m *mixerImpl) Add(provider OpusFrameProvider, priority int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.insert(mixerSource{provider: provider, priority: priority})
+}
+
+func (m *mixerImpl) Remove(provider OpusFrameProvider) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.remove(provider)
+}
+
+func (m *mixerImpl) Skip() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.last == nil {
+		return
+	}
+	last := m.last
+	m.remove(last)
+	last.Close()
+}
+
+func (m *mixerImpl) Pause() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.paused = true
+}
+
+func (m *mixerImpl) Resume() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.paused = false
+}
+
+func (m *mixerImpl) Paused() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.paused
+}
+
+func (m *mixerImpl) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, source := range m.sources {
+		source.provider.Close()
+	}
+	m.sources = nil
+	m.last = nil
+}
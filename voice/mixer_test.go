@@ -0,0 +1,89 @@
+package voice
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testProvider struct {
+	frames [][]byte
+	closed bool
+}
+
+func (p *testProvider) ProvideOpusFrame() ([]byte, error) {
+	if len(p.frames) == 0 {
+		return nil, io.EOF
+	}
+	frame := p.frames[0]
+	p.frames = p.frames[1:]
+	return frame, nil
+}
+
+func (p *testProvider) Close() {
+	p.closed = true
+}
+
+func provideFrames(provider OpusFrameProvider, n int) []byte {
+	var frames []byte
+	for i := 0; i < n; i++ {
+		frame, _ := provider.ProvideOpusFrame()
+		frames = append(frames, frame...)
+	}
+	return frames
+}
+
+func TestMixer(t *testing.T) {
+	mixer := NewMixer()
+	music1 := &testProvider{frames: [][]byte{{1}, {1}, {1}}}
+	music2 := &testProvider{frames: [][]byte{{2}, {2}, {2}}}
+	mixer.Add(music1, 0)
+	mixer.Add(music2, 0)
+
+	// same priority takes turns
+	assert.Equal(t, []byte{1, 2}, provideFrames(mixer, 2))
+
+	// higher priority takes over until it is done
+	announcement := &testProvider{frames: [][]byte{{9}, {9}}}
+	mixer.Add(announcement, 1)
+	assert.Equal(t, []byte{9, 9, 1}, provideFrames(mixer, 3))
+	assert.True(t, announcement.closed)
+
+	mixer.Pause()
+	assert.Empty(t, provideFrames(mixer, 1))
+	mixer.Resume()
+
+	assert.Equal(t, []byte{2}, provideFrames(mixer, 1))
+	mixer.Skip()
+	assert.True(t, music2.closed)
+	assert.Equal(t, []byte{1}, provideFrames(mixer, 2))
+}
+
+func TestQueue(t *testing.T) {
+	newTrack := func(title string, frames ...[]byte) Track {
+		return Track{Title: title, Open: func() (OpusFrameProvider, error) {
+			return &testProvider{frames: frames}, nil
+		}}
+	}
+
+	queue := NewQueue(newTrack("first", []byte{1}, []byte{1}))
+	queue.Add(newTrack("second", []byte{2}, []byte{2}), newTrack("third", []byte{3}))
+
+	assert.Equal(t, []byte{1}, provideFrames(queue, 1))
+	current, ok := queue.Current()
+	assert.True(t, ok)
+	assert.Equal(t, "first", current.Title)
+
+	queue.Skip()
+	assert.Equal(t, []byte{2}, provideFrames(queue, 1))
+
+	queue.Pause()
+	assert.Empty(t, provideFrames(queue, 1))
+	queue.Resume()
+
+	assert.Equal(t, []byte{2, 3}, provideFrames(queue, 3))
+	assert.Empty(t, queue.Tracks())
+	_, ok = queue.Current()
+	assert.False(t, ok)
+}
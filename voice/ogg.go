@@ -0,0 +1,124 @@
+package voice
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+const (
+	oggPageHeaderSize   = 27
+	oggMaxSegmentLength = 255
+)
+
+var (
+	oggCapturePattern = []byte("OggS")
+	opusHeadMagic     = []byte("OpusHead")
+	opusTagsMagic     = []byte("OpusTags")
+)
+
+// ErrInvalidOggPage is returned when an Ogg page does not start with the Ogg capture pattern.
+var ErrInvalidOggPage = errors.New("invalid ogg page")
+
+var _ OpusFrameProvider = (*oggOpusReader)(nil)
+
+// NewOggOpusReader creates a new OpusFrameProvider which demuxes the opus frames of an Ogg/Opus stream.
+// Only the first logical bitstream is read and the OpusHead & OpusTags header packets are skipped.
+// The reader is closed on Close if it implements io.Closer.
+func NewOggOpusReader(r io.Reader) OpusFrameProvider {
+	return &oggOpusReader{
+		r:      bufio.NewReader(r),
+		closer: r,
+	}
+}
+
+type oggOpusReader struct {
+	r      *bufio.Reader
+	closer io.Reader
+
+	serial    uint32
+	serialSet bool
+	// packets are the complete packets of the last page
+	packets [][]byte
+	// partial is a packet continued on the next page
+	partial []byte
+}
+
+func (o *oggOpusReader) ProvideOpusFrame() ([]byte, error) {
+	for len(o.packets) == 0 {
+		if err := o.readPage(); err != nil {
+			return nil, err
+		}
+	}
+	packet := o.packets[0]
+	o.packets = o.packets[1:]
+	return packet, nil
+}
+
+func (o *oggOpusReader) readPage() error {
+	var header [oggPageHeaderSize]byte
+	if _, err := io.ReadFull(o.r, header[:]); err != nil {
+		return err
+	}
+	if !bytes.Equal(header[0:4], oggCapturePattern) {
+		return ErrInvalidOggPage
+	}
+	serial := binary.LittleEndian.Uint32(header[14:18])
+
+	segmentTable := make([]byte, header[26])
+	if _, err := io.ReadFull(o.r, segmentTable); err != nil {
+		return unexpectedEOF(err)
+	}
+	var dataSize int
+	for _, segmentLength := range segmentTable {
+		dataSize += int(segmentLength)
+	}
+	data := make([]byte, dataSize)
+	if _, err := io.ReadFull(o.r, data); err != nil {
+		return unexpectedEOF(err)
+	}
+
+	if !o.serialSet {
+		o.serial = serial
+		o.serialSet = true
+	} else if serial != o.serial {
+		return nil
+	}
+
+	var offset int
+	for _, segmentLength := range segmentTable {
+		o.partial = append(o.partial, data[offset:offset+int(segmentLength)]...)
+		offset += int(segmentLength)
+		// a segment shorter than the max length ends the packet
+		if segmentLength == oggMaxSegmentLength {
+			continue
+		}
+		packet := o.partial
+		o.partial = nil
+		if len(packet) == 0 || bytes.HasPrefix(packet, opusHeadMagic) || bytes.HasPrefix(packet, opusTagsMagic) {
+			continue
+		}
+		o.packets = append(o.packets, packet)
+	}
+	return nil
+}
+
+func (o *oggOpusReader) Close() {
+	closeReader(o.closer)
+}
+
+// unexpectedEOF turns an io.EOF in the middle of a frame into an io.ErrUnexpectedEOF.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func closeReader(r io.Reader) {
+	if closer, ok := r.(io.Closer); ok {
+		_ = closer.Close()
+	}
+}
@@ -0,0 +1,74 @@
+package voice
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// oggPage builds an ogg page with the given serial and lacing values. The crc is not checked by the reader.
+func oggPage(serial uint32, segmentTable []byte, data []byte) []byte {
+	header := make([]byte, oggPageHeaderSize)
+	copy(header, oggCapturePattern)
+	binary.LittleEndian.PutUint32(header[14:18], serial)
+	header[26] = byte(len(segmentTable))
+	return append(append(header, segmentTable...), data...)
+}
+
+func TestOggOpusReader(t *testing.T) {
+	longPacket := bytes.Repeat([]byte{0xAB}, 300)
+
+	var stream []byte
+	stream = append(stream, oggPage(1, []byte{19}, append([]byte("OpusHead"), make([]byte, 11)...))...)
+	stream = append(stream, oggPage(1, []byte{8}, []byte("OpusTags"))...)
+	// two packets in one page, the second one continues on the next page
+	stream = append(stream, oggPage(1, []byte{3, 255}, append([]byte{1, 2, 3}, longPacket[:255]...))...)
+	// a page of another logical bitstream is skipped
+	stream = append(stream, oggPage(2, []byte{1}, []byte{9})...)
+	stream = append(stream, oggPage(1, []byte{45}, longPacket[255:])...)
+
+	reader := NewOggOpusReader(bytes.NewReader(stream))
+	defer reader.Close()
+
+	frame, err := reader.ProvideOpusFrame()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, frame)
+
+	frame, err = reader.ProvideOpusFrame()
+	require.NoError(t, err)
+	assert.Equal(t, longPacket, frame)
+
+	_, err = reader.ProvideOpusFrame()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestDCAReader(t *testing.T) {
+	var stream bytes.Buffer
+	stream.Write(dca1Magic)
+	metadata := []byte(`{"dca":{"version":1}}`)
+	_ = binary.Write(&stream, binary.LittleEndian, int32(len(metadata)))
+	stream.Write(metadata)
+	for _, frame := range [][]byte{{1, 2, 3}, SilenceAudioFrame} {
+		_ = binary.Write(&stream, binary.LittleEndian, int16(len(frame)))
+		stream.Write(frame)
+	}
+
+	provider, err := NewOpusFrameProvider(&stream)
+	require.NoError(t, err)
+	defer provider.Close()
+
+	frame, err := provider.ProvideOpusFrame()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, frame)
+
+	frame, err = provider.ProvideOpusFrame()
+	require.NoError(t, err)
+	assert.Equal(t, SilenceAudioFrame, frame)
+
+	_, err = provider.ProvideOpusFrame()
+	assert.Equal(t, io.EOF, err)
+}
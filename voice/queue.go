@@ -0,0 +1,204 @@
+package voice
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// NewOpusFrameProvider creates a new OpusFrameProvider for the given Ogg/Opus or DCA stream. The format is detected from the first bytes of the stream.
+func NewOpusFrameProvider(r io.Reader) (OpusFrameProvider, error) {
+	bufReader := bufio.NewReader(r)
+	magic, err := bufReader.Peek(len(oggCapturePattern))
+	if err != nil {
+		closeReader(r)
+		return nil, unexpectedEOF(err)
+	}
+	// keep the peeked bytes but still close the underlying reader
+	var reader io.Reader = bufReader
+	if closer, ok := r.(io.Closer); ok {
+		reader = struct {
+			io.Reader
+			io.Closer
+		}{Reader: bufReader, Closer: closer}
+	}
+
+	if bytes.Equal(magic, oggCapturePattern) {
+		return NewOggOpusReader(reader), nil
+	}
+	return NewDCAReader(reader), nil
+}
+
+// OpenFile opens the given Ogg/Opus or DCA file as OpusFrameProvider.
+func OpenFile(name string) (OpusFrameProvider, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return NewOpusFrameProvider(file)
+}
+
+// Track is an entry of a Queue. It is only opened once it starts playing.
+type Track struct {
+	Title string
+	Open  func() (OpusFrameProvider, error)
+}
+
+// NewFileTrack creates a new Track for the given Ogg/Opus or DCA file.
+func NewFileTrack(name string) Track {
+	return Track{
+		Title: filepath.Base(name),
+		Open: func() (OpusFrameProvider, error) {
+			return OpenFile(name)
+		},
+	}
+}
+
+// Queue is an OpusFrameProvider which plays Track(s) one after another. It provides no frames while it is empty or paused.
+type Queue interface {
+	OpusFrameProvider
+
+	// Add adds the given Track(s) to the end of the Queue.
+	Add(tracks ...Track)
+
+	// Tracks returns the Track(s) which are played after the current one.
+	Tracks() []Track
+
+	// Current returns the Track which is currently playing.
+	Current() (Track, bool)
+
+	// Skip stops the current Track and starts the next one.
+	Skip()
+
+	// Clear removes all Track(s) after the current one.
+	Clear()
+
+	// Pause pauses the current Track.
+	Pause()
+
+	// Resume resumes the current Track.
+	Resume()
+
+	// Paused returns whether the Queue is paused.
+	Paused() bool
+}
+
+var _ Queue = (*queueImpl)(nil)
+
+// NewQueue creates a new empty Queue.
+func NewQueue(tracks ...Track) Queue {
+	return &queueImpl{
+		tracks: tracks,
+	}
+}
+
+type queueImpl struct {
+	mu       sync.Mutex
+	tracks   []Track
+	current  *Track
+	provider OpusFrameProvider
+	paused   bool
+}
+
+func (q *queueImpl) ProvideOpusFrame() ([]byte, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.paused {
+		return nil, nil
+	}
+
+	for {
+		if q.provider == nil {
+			if len(q.tracks) == 0 {
+				return nil, nil
+			}
+			track := q.tracks[0]
+			q.tracks = q.tracks[1:]
+			provider, err := track.Open()
+			if err != nil {
+				return nil, err
+			}
+			q.current = &track
+			q.provider = provider
+		}
+
+		frame, err := q.provider.ProvideOpusFrame()
+		if err == io.EOF {
+			q.stopCurrent()
+			continue
+		}
+		return frame, err
+	}
+}
+
+// stopCurrent closes the provider of the current Track. q.mu must be held.
+func (q *queueImpl) stopCurrent() {
+	if q.provider != nil {
+		q.provider.Close()
+		q.provider = nil
+	}
+	q.current = nil
+}
+
+func (q *queueImpl) Add(tracks ...Track) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.tracks = append(q.tracks, tracks...)
+}
+
+func (q *queueImpl) Tracks() []Track {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	tracks := make([]Track, len(q.tracks))
+	copy(tracks, q.tracks)
+	return tracks
+}
+
+func (q *queueImpl) Current() (Track, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.current == nil {
+		return Track{}, false
+	}
+	return *q.current, true
+}
+
+func (q *queueImpl) Skip() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.stopCurrent()
+}
+
+func (q *queueImpl) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.tracks = nil
+}
+
+func (q *queueImpl) Pause() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.paused = true
+}
+
+func (q *queueImpl) Resume() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.paused = false
+}
+
+func (q *queueImpl) Paused() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.paused
+}
+
+func (q *queueImpl) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.stopCurrent()
+	q.tracks = nil
+}
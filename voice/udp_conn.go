@@ -0,0 +1,378 @@
+package voice
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/log"
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+const (
+	// RTPHeaderSize is the size of the RTP header prepended to every voice packet.
+	RTPHeaderSize = 12
+
+	ipDiscoveryPacketSize   = 74
+	ipDiscoveryTypeRequest  = 0x1
+	ipDiscoveryTypeResponse = 0x2
+
+	rtpVersionFlags = 0x80
+	rtpPayloadType  = 0x78
+
+	rtpExtensionFlag   = 0x10
+	rtpCSRCCountMask   = 0x0F
+	rtpPayloadTypeMask = 0x7F
+
+	// rtcp packets share the port with rtp packets and are identified by their payload type
+	rtcpPayloadTypeMin = 72
+	rtcpPayloadTypeMax = 76
+
+	maxPacketSize = 1500
+)
+
+var (
+	// ErrUDPConnNotOpen is returned when writing to a UDPConn which is not open.
+	ErrUDPConnNotOpen = errors.New("voice udp connection not open")
+
+	// ErrNoSecretKey is returned when writing to a UDPConn before the secret key from the GatewayMessageDataSessionDescription was set.
+	ErrNoSecretKey = errors.New("voice udp connection has no secret key")
+)
+
+// Packet is a decrypted voice packet received from discord.
+type Packet struct {
+	// Sequence is incremented by one for every packet sent by the user.
+	Sequence uint16
+
+	// Timestamp is incremented by OpusFrameSize for every opus frame sent by the user.
+	Timestamp uint32
+
+	// SSRC identifies the user who sent the packet. See Conn.UserIDBySSRC.
+	SSRC uint32
+
+	// Opus is the opus frame without the RTP header and header extension.
+	Opus []byte
+}
+
+// IsSilence returns whether the Packet contains a SilenceAudioFrame. Discord sends them when a user stops speaking.
+func (p Packet) IsSilence() bool {
+	return bytes.Equal(p.Opus, SilenceAudioFrame)
+}
+
+// UDPConn is the UDP connection voice packets are sent over. Every packet written is wrapped in an RTP header and encrypted with xsalsa20_poly1305.
+type UDPConn interface {
+	// Logger returns the logger used by the UDPConn.
+	Logger() log.Logger
+
+	// LocalAddr returns the local address of the UDPConn.
+	LocalAddr() net.Addr
+
+	// RemoteAddr returns the address of the discord voice server.
+	RemoteAddr() net.Addr
+
+	// Open connects to the voice server and performs IP discovery. It returns the external address and port which need to be sent via GatewayMessageDataSelectProtocol.
+	Open(ctx context.Context, ip string, port int, ssrc uint32) (string, int, error)
+
+	// SetSecretKey sets the key used to encrypt voice packets.
+	SetSecretKey(secretKey [32]byte)
+
+	// Write writes a single opus frame to discord.
+	Write(opusFrame []byte) (int, error)
+
+	// ReadPacket reads and decrypts the next voice Packet. RTCP packets and packets which can't be decrypted are skipped.
+	// If the UDPConn is not open, it blocks until it is opened or the context is cancelled. ReadPacket must not be called concurrently.
+	ReadPacket(ctx context.Context) (*Packet, error)
+
+	// Close closes the UDPConn.
+	Close() error
+}
+
+var _ UDPConn = (*udpConnImpl)(nil)
+
+// NewUDPConn creates a new UDPConn with the given UDPConnConfigOpt(s).
+func NewUDPConn(opts ...UDPConnConfigOpt) UDPConn {
+	config := DefaultUDPConnConfig()
+	config.Apply(opts)
+
+	return &udpConnImpl{
+		config: *config,
+		opened: make(chan struct{}),
+	}
+}
+
+type udpConnImpl struct {
+	config UDPConnConfig
+
+	mu        sync.Mutex
+	conn      net.Conn
+	ssrc      uint32
+	secretKey *[32]byte
+	// opened is closed once the UDPConn is opened and replaced when it is closed
+	opened chan struct{}
+
+	sequence  uint16
+	timestamp uint32
+	header    [RTPHeaderSize]byte
+	nonce     [24]byte
+	packet    []byte
+
+	readBuf [maxPacketSize]byte
+}
+
+func (c *udpConnImpl) Logger() log.Logger {
+	return c.config.Logger
+}
+
+func (c *udpConnImpl) LocalAddr() net.Addr {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.LocalAddr()
+}
+
+func (c *udpConnImpl) RemoteAddr() net.Addr {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.RemoteAddr()
+}
+
+func (c *udpConnImpl) Open(ctx context.Context, ip string, port int, ssrc uint32) (string, int, error) {
+	c.Logger().Debug("opening voice udp connection")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn != nil {
+		_ = c.conn.Close()
+		c.conn = nil
+	}
+
+	conn, err := c.config.Dialer.DialContext(ctx, "udp", net.JoinHostPort(ip, strconv.Itoa(port)))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to dial voice udp connection: %w", err)
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(c.config.IPDiscoveryTimeout)
+	}
+	_ = conn.SetDeadline(deadline)
+	address, ourPort, err := discoverIP(conn, ssrc)
+	_ = conn.SetDeadline(time.Time{})
+	if err != nil {
+		_ = conn.Close()
+		return "", 0, err
+	}
+
+	c.conn = conn
+	c.ssrc = ssrc
+	c.secretKey = nil
+	c.header = [RTPHeaderSize]byte{rtpVersionFlags, rtpPayloadType}
+	binary.BigEndian.PutUint32(c.header[8:12], ssrc)
+	select {
+	case <-c.opened:
+	default:
+		close(c.opened)
+	}
+	return address, ourPort, nil
+}
+
+// discoverIP sends an IP discovery request and returns the external address and port of the connection.
+// See https://discord.com/developers/docs/topics/voice-connections#ip-discovery
+func discoverIP(conn net.Conn, ssrc uint32) (string, int, error) {
+	request := make([]byte, ipDiscoveryPacketSize)
+	binary.BigEndian.PutUint16(request[0:2], ipDiscoveryTypeRequest)
+	binary.BigEndian.PutUint16(request[2:4], ipDiscoveryPacketSize-4)
+	binary.BigEndian.PutUint32(request[4:8], ssrc)
+	if _, err := conn.Write(request); err != nil {
+		return "", 0, fmt.Errorf("failed to send ip discovery request: %w", err)
+	}
+
+	response := make([]byte, ipDiscoveryPacketSize)
+	n, err := conn.Read(response)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read ip discovery response: %w", err)
+	}
+	return parseIPDiscoveryResponse(response[:n])
+}
+
+func parseIPDiscoveryResponse(response []byte) (string, int, error) {
+	if len(response) != ipDiscoveryPacketSize || binary.BigEndian.Uint16(response[0:2]) != ipDiscoveryTypeResponse {
+		return "", 0, errors.New("invalid ip discovery response")
+	}
+	address := response[8:72]
+	if i := bytes.IndexByte(address, 0); i >= 0 {
+		address = address[:i]
+	}
+	return string(address), int(binary.BigEndian.Uint16(response[72:74])), nil
+}
+
+func (c *udpConnImpl) SetSecretKey(secretKey [32]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.secretKey = &secretKey
+}
+
+func (c *udpConnImpl) Write(opusFrame []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil {
+		return 0, ErrUDPConnNotOpen
+	}
+	if c.secretKey == nil {
+		return 0, ErrNoSecretKey
+	}
+
+	binary.BigEndian.PutUint16(c.header[2:4], c.sequence)
+	binary.BigEndian.PutUint32(c.header[4:8], c.timestamp)
+
+	// the nonce is the rtp header padded with zeros
+	copy(c.nonce[:], c.header[:])
+	c.packet = secretbox.Seal(append(c.packet[:0], c.header[:]...), opusFrame, &c.nonce, c.secretKey)
+
+	if _, err := c.conn.Write(c.packet); err != nil {
+		return 0, err
+	}
+	c.sequence++
+	c.timestamp += OpusFrameSize
+	return len(opusFrame), nil
+}
+
+func (c *udpConnImpl) ReadPacket(ctx context.Context) (*Packet, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		conn := c.conn
+		opened := c.opened
+		c.mu.Unlock()
+
+		if conn == nil {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-opened:
+				continue
+			}
+		}
+
+		n, err := readContext(ctx, conn, c.readBuf[:])
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			c.mu.Lock()
+			replaced := c.conn != conn
+			c.mu.Unlock()
+			// the connection was closed or replaced by a new one, wait for it
+			if replaced {
+				continue
+			}
+			return nil, err
+		}
+
+		c.mu.Lock()
+		secretKey := c.secretKey
+		c.mu.Unlock()
+		if secretKey == nil {
+			continue
+		}
+
+		if packet, ok := decryptPacket(c.readBuf[:n], secretKey); ok {
+			return packet, nil
+		}
+	}
+}
+
+// readContext reads from the net.Conn like net.Conn.Read, but cancelling the context interrupts the read.
+func readContext(ctx context.Context, conn net.Conn, buf []byte) (int, error) {
+	if ctx.Done() == nil {
+		return conn.Read(buf)
+	}
+
+	stop := make(chan struct{})
+	interrupted := make(chan bool, 1)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.SetReadDeadline(time.Now())
+			interrupted <- true
+		case <-stop:
+			interrupted <- false
+		}
+	}()
+
+	n, err := conn.Read(buf)
+	close(stop)
+	if <-interrupted {
+		// don't let the next read time out immediately
+		_ = conn.SetReadDeadline(time.Time{})
+	}
+	return n, err
+}
+
+// decryptPacket parses the RTP header and decrypts the opus frame of the given voice packet.
+// It returns false for RTCP packets and packets which are malformed or can't be decrypted.
+func decryptPacket(data []byte, secretKey *[32]byte) (*Packet, bool) {
+	if len(data) < RTPHeaderSize+secretbox.Overhead {
+		return nil, false
+	}
+	if payloadType := data[1] & rtpPayloadTypeMask; payloadType >= rtcpPayloadTypeMin && payloadType <= rtcpPayloadTypeMax {
+		return nil, false
+	}
+
+	var nonce [24]byte
+	copy(nonce[:], data[:RTPHeaderSize])
+	opus, ok := secretbox.Open(nil, data[RTPHeaderSize:], &nonce, secretKey)
+	if !ok {
+		return nil, false
+	}
+
+	// csrc identifiers and the header extension are encrypted together with the opus frame
+	csrcLength := int(data[0]&rtpCSRCCountMask) * 4
+	if len(opus) < csrcLength {
+		return nil, false
+	}
+	opus = opus[csrcLength:]
+	if data[0]&rtpExtensionFlag != 0 {
+		if len(opus) < 4 {
+			return nil, false
+		}
+		extensionLength := 4 + int(binary.BigEndian.Uint16(opus[2:4]))*4
+		if len(opus) < extensionLength {
+			return nil, false
+		}
+		opus = opus[extensionLength:]
+	}
+
+	return &Packet{
+		Sequence:  binary.BigEndian.Uint16(data[2:4]),
+		Timestamp: binary.BigEndian.Uint32(data[4:8]),
+		SSRC:      binary.BigEndian.Uint32(data[8:12]),
+		Opus:      opus,
+	}, true
+}
+
+func (c *udpConnImpl) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	c.opened = make(chan struct{})
+	return err
+}
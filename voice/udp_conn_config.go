@@ -0,0 +1,55 @@
+package voice
+
+import (
+	"net"
+	"time"
+
+	"github.com/disgoorg/log"
+)
+
+// DefaultUDPConnConfig returns a UDPConnConfig with sensible defaults.
+func DefaultUDPConnConfig() *UDPConnConfig {
+	return &UDPConnConfig{
+		Logger:             log.Default(),
+		Dialer:             &net.Dialer{},
+		IPDiscoveryTimeout: 5 * time.Second,
+	}
+}
+
+// UDPConnConfig lets you configure your UDPConn instance.
+type UDPConnConfig struct {
+	Logger             log.Logger
+	Dialer             *net.Dialer
+	IPDiscoveryTimeout time.Duration
+}
+
+// UDPConnConfigOpt is a type alias for a function that takes a UDPConnConfig and is used to configure your UDPConn.
+type UDPConnConfigOpt func(config *UDPConnConfig)
+
+// Apply applies the given UDPConnConfigOpt(s) to the UDPConnConfig
+func (c *UDPConnConfig) Apply(opts []UDPConnConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithUDPConnLogger sets the Logger for the UDPConn.
+func WithUDPConnLogger(logger log.Logger) UDPConnConfigOpt {
+	return func(config *UDPConnConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithUDPConnDialer sets the net.Dialer for the UDPConn.
+func WithUDPConnDialer(dialer *net.Dialer) UDPConnConfigOpt {
+	return func(config *UDPConnConfig) {
+		config.Dialer = dialer
+	}
+}
+
+// WithUDPConnIPDiscoveryTimeout sets how long the UDPConn waits for the IP discovery response if the context passed to UDPConn.Open has no deadline.
+func WithUDPConnIPDiscoveryTimeout(timeout time.Duration) UDPConnConfigOpt {
+	return func(config *UDPConnConfig) {
+		config.IPDiscoveryTimeout = timeout
+	}
+}
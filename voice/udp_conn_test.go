@@ -0,0 +1,99 @@
+package voice
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+func TestUDPConn(t *testing.T) {
+	server, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer server.Close()
+
+	conn := NewUDPConn()
+	defer conn.Close()
+
+	const ssrc = 1234
+	go func() {
+		buf := make([]byte, ipDiscoveryPacketSize)
+		n, addr, err := server.ReadFrom(buf)
+		if err != nil || n != ipDiscoveryPacketSize {
+			return
+		}
+		response := make([]byte, ipDiscoveryPacketSize)
+		binary.BigEndian.PutUint16(response[0:2], ipDiscoveryTypeResponse)
+		binary.BigEndian.PutUint16(response[2:4], ipDiscoveryPacketSize-4)
+		copy(response[4:8], buf[4:8])
+		copy(response[8:], "203.0.113.7")
+		binary.BigEndian.PutUint16(response[72:74], 50000)
+		_, _ = server.WriteTo(response, addr)
+	}()
+
+	serverAddr := server.LocalAddr().(*net.UDPAddr)
+	address, port, err := conn.Open(context.Background(), serverAddr.IP.String(), serverAddr.Port, ssrc)
+	require.NoError(t, err)
+	assert.Equal(t, "203.0.113.7", address)
+	assert.Equal(t, 50000, port)
+
+	_, err = conn.Write(SilenceAudioFrame)
+	assert.ErrorIs(t, err, ErrNoSecretKey)
+
+	secretKey := [32]byte{1, 2, 3}
+	conn.SetSecretKey(secretKey)
+
+	for i := 0; i < 2; i++ {
+		_, err = conn.Write(SilenceAudioFrame)
+		require.NoError(t, err)
+
+		packet := make([]byte, 1024)
+		n, _, err := server.ReadFrom(packet)
+		require.NoError(t, err)
+		packet = packet[:n]
+
+		assert.Equal(t, uint16(i), binary.BigEndian.Uint16(packet[2:4]))
+		assert.Equal(t, uint32(i*OpusFrameSize), binary.BigEndian.Uint32(packet[4:8]))
+		assert.Equal(t, uint32(ssrc), binary.BigEndian.Uint32(packet[8:12]))
+
+		var nonce [24]byte
+		copy(nonce[:], packet[:RTPHeaderSize])
+		opusFrame, ok := secretbox.Open(nil, packet[RTPHeaderSize:], &nonce, &secretKey)
+		require.True(t, ok)
+		assert.Equal(t, SilenceAudioFrame, opusFrame)
+	}
+
+	// cancelling the context interrupts a read which waits for a packet
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = conn.ReadPacket(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestDecryptPacket(t *testing.T) {
+	secretKey := [32]byte{4, 5, 6}
+	header := []byte{rtpVersionFlags | rtpExtensionFlag, rtpPayloadType, 0, 7, 0, 0, 0x1A, 0x40, 0, 0, 0x04, 0xD2}
+	// one byte header extension with a single 32-bit word of data
+	payload := append([]byte{0xBE, 0xDE, 0, 1, 0x10, 0xFF, 0, 0}, SilenceAudioFrame...)
+
+	var nonce [24]byte
+	copy(nonce[:], header)
+	data := secretbox.Seal(append([]byte{}, header...), payload, &nonce, &secretKey)
+
+	packet, ok := decryptPacket(data, &secretKey)
+	require.True(t, ok)
+	assert.Equal(t, uint16(7), packet.Sequence)
+	assert.Equal(t, uint32(6720), packet.Timestamp)
+	assert.Equal(t, uint32(1234), packet.SSRC)
+	assert.True(t, packet.IsSilence())
+
+	// rtcp receiver report
+	data[1] = 0xC9
+	_, ok = decryptPacket(data, &secretKey)
+	assert.False(t, ok)
+}
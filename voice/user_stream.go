@@ -0,0 +1,146 @@
+package voice
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// ErrUserStreamFull is returned by the OpusFrameReceiver of NewUserStreamReceiver when a UserStream is not read fast enough and a Packet was dropped.
+var ErrUserStreamFull = errors.New("user stream buffer is full, dropping packet")
+
+// UserStreamHandlerFunc is called in a new goroutine for every user who starts sending audio.
+type UserStreamHandlerFunc func(stream UserStream)
+
+// UserStream is the audio received from a single user.
+type UserStream interface {
+	// UserID returns the ID of the user who sends the audio.
+	UserID() snowflake.ID
+
+	// VoiceState returns the cached discord.VoiceState of the user. See WithConnVoiceStateCache.
+	VoiceState() (discord.VoiceState, bool)
+
+	// Packets returns the Packet(s) received from the user in the order they were received.
+	// The channel is closed once the user left the voice channel or the receiver was closed.
+	Packets() <-chan *Packet
+
+	// Speaking returns whether the user is currently sending audio.
+	// A user stops speaking when discord sends a SilenceAudioFrame or no Packet was received for the configured silence timeout.
+	Speaking() bool
+}
+
+var _ OpusFrameReceiver = (*userStreamReceiver)(nil)
+
+// NewUserStreamReceiver creates an OpusFrameReceiver which splits the received audio of the Conn into a UserStream per user.
+// Set it via Conn.SetOpusFrameReceiver.
+func NewUserStreamReceiver(conn Conn, streamHandlerFunc UserStreamHandlerFunc, opts ...UserStreamConfigOpt) OpusFrameReceiver {
+	config := DefaultUserStreamConfig()
+	config.Apply(opts)
+
+	return &userStreamReceiver{
+		config:            *config,
+		conn:              conn,
+		streamHandlerFunc: streamHandlerFunc,
+		streams:           map[snowflake.ID]*userStreamImpl{},
+	}
+}
+
+type userStreamReceiver struct {
+	config            UserStreamConfig
+	conn              Conn
+	streamHandlerFunc UserStreamHandlerFunc
+
+	mu      sync.Mutex
+	streams map[snowflake.ID]*userStreamImpl
+	closed  bool
+}
+
+func (r *userStreamReceiver) ReceiveOpusFrame(userID snowflake.ID, packet *Packet) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// a new UserStream would never be closed after Close
+	if r.closed {
+		return nil
+	}
+
+	stream, ok := r.streams[userID]
+	if !ok {
+		stream = &userStreamImpl{
+			userID:         userID,
+			conn:           r.conn,
+			silenceTimeout: r.config.SilenceTimeout,
+			packets:        make(chan *Packet, r.config.BufferSize),
+		}
+		r.streams[userID] = stream
+		go r.streamHandlerFunc(stream)
+	}
+	stream.update(packet)
+
+	// never block the receiver because of a slow UserStream
+	select {
+	case stream.packets <- packet:
+		return nil
+	default:
+		return ErrUserStreamFull
+	}
+}
+
+func (r *userStreamReceiver) CleanupUser(userID snowflake.ID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stream, ok := r.streams[userID]; ok {
+		close(stream.packets)
+		delete(r.streams, userID)
+	}
+}
+
+func (r *userStreamReceiver) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	for userID, stream := range r.streams {
+		close(stream.packets)
+		delete(r.streams, userID)
+	}
+}
+
+var _ UserStream = (*userStreamImpl)(nil)
+
+type userStreamImpl struct {
+	userID         snowflake.ID
+	conn           Conn
+	silenceTimeout time.Duration
+	packets        chan *Packet
+
+	mu             sync.Mutex
+	lastPacketTime time.Time
+	silent         bool
+}
+
+func (s *userStreamImpl) UserID() snowflake.ID {
+	return s.userID
+}
+
+func (s *userStreamImpl) VoiceState() (discord.VoiceState, bool) {
+	return s.conn.UserVoiceState(s.userID)
+}
+
+func (s *userStreamImpl) Packets() <-chan *Packet {
+	return s.packets
+}
+
+func (s *userStreamImpl) Speaking() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.silent && time.Since(s.lastPacketTime) < s.silenceTimeout
+}
+
+func (s *userStreamImpl) update(packet *Packet) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastPacketTime = time.Now()
+	s.silent = packet.IsSilence()
+}
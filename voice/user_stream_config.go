@@ -0,0 +1,44 @@
+package voice
+
+import "time"
+
+// DefaultUserStreamConfig returns a UserStreamConfig with sensible defaults.
+func DefaultUserStreamConfig() *UserStreamConfig {
+	return &UserStreamConfig{
+		BufferSize:     50,
+		SilenceTimeout: 5 * OpusFrameDuration,
+	}
+}
+
+// UserStreamConfig lets you configure the UserStream(s) created by NewUserStreamReceiver.
+type UserStreamConfig struct {
+	// BufferSize is the number of Packet(s) buffered per UserStream before new ones are dropped.
+	BufferSize int
+
+	// SilenceTimeout is the duration without a Packet after which a user is no longer speaking.
+	SilenceTimeout time.Duration
+}
+
+// UserStreamConfigOpt is a type alias for a function that takes a UserStreamConfig and is used to configure your UserStream(s).
+type UserStreamConfigOpt func(config *UserStreamConfig)
+
+// Apply applies the given UserStreamConfigOpt(s) to the UserStreamConfig
+func (c *UserStreamConfig) Apply(opts []UserStreamConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithUserStreamBufferSize sets the number of Packet(s) buffered per UserStream.
+func WithUserStreamBufferSize(bufferSize int) UserStreamConfigOpt {
+	return func(config *UserStreamConfig) {
+		config.BufferSize = bufferSize
+	}
+}
+
+// WithUserStreamSilenceTimeout sets the duration without a Packet after which a user is no longer speaking.
+func WithUserStreamSilenceTimeout(silenceTimeout time.Duration) UserStreamConfigOpt {
+	return func(config *UserStreamConfig) {
+		config.SilenceTimeout = silenceTimeout
+	}
+}
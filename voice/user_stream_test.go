@@ -0,0 +1,28 @@
+package voice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserStreamReceiver(t *testing.T) {
+	streams := make(chan UserStream, 2)
+	receiver := NewUserStreamReceiver(nil, func(stream UserStream) {
+		streams <- stream
+	})
+
+	packet := &Packet{Opus: SilenceAudioFrame}
+	require.NoError(t, receiver.ReceiveOpusFrame(1, packet))
+	stream := <-streams
+	assert.Equal(t, packet, <-stream.Packets())
+
+	receiver.Close()
+	_, ok := <-stream.Packets()
+	assert.False(t, ok)
+
+	// frames received after closing are dropped instead of opening a stream which is never closed
+	assert.NoError(t, receiver.ReceiveOpusFrame(2, packet))
+	assert.Empty(t, streams)
+}
@@ -0,0 +1,26 @@
+// Package voice is used to connect to Discord voice channels.
+// It runs the voice gateway and the encrypted UDP connection audio is sent over.
+//
+// A Conn is created via the Manager for a guild and opened with Conn.Open. Opus frames can then be written to Conn.UDP
+// or sent every OpusFrameDuration from an OpusFrameProvider set via Conn.SetOpusFrameProvider, see NewQueue and NewMixer.
+// Received audio is passed to an OpusFrameReceiver set via Conn.SetOpusFrameReceiver, see NewUserStreamReceiver for a stream per user.
+package voice
+
+import "time"
+
+// Version defines which voice gateway version disgo uses to connect to discord.
+const Version = 4
+
+const (
+	// OpusFrameSize is the number of samples per channel in a single opus frame.
+	OpusFrameSize = 960
+
+	// OpusSampleRate is the sample rate discord expects opus audio in.
+	OpusSampleRate = 48000
+
+	// OpusFrameDuration is the duration of a single opus frame.
+	OpusFrameDuration = 20 * time.Millisecond
+)
+
+// SilenceAudioFrame is an opus frame of silence. Discord recommends sending 5 of them when you stop sending audio to avoid audio interpolation.
+var SilenceAudioFrame = []byte{0xF8, 0xFF, 0xFE}